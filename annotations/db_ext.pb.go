@@ -0,0 +1,1076 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.2
+// source: annotations/db_ext.proto
+
+package annotations
+
+import (
+	db_annotations "github.com/imran31415/protobuf-db/db-annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Extended column types complementing db_annotations.DbColumnType.
+// When set, db_extended_type takes precedence over db_column_type.
+type DbExtendedColumnType int32
+
+const (
+	DbExtendedColumnType_DB_TYPE_UNSPECIFIED DbExtendedColumnType = 0  // Fall back to db_column_type
+	DbExtendedColumnType_DB_TYPE_TINYINT     DbExtendedColumnType = 1  // 1 byte integer
+	DbExtendedColumnType_DB_TYPE_SMALLINT    DbExtendedColumnType = 2  // 2 byte integer
+	DbExtendedColumnType_DB_TYPE_BIGINT      DbExtendedColumnType = 3  // 8 byte integer
+	DbExtendedColumnType_DB_TYPE_DECIMAL     DbExtendedColumnType = 4  // Fixed point number, sized by db_precision and db_scale
+	DbExtendedColumnType_DB_TYPE_CHAR        DbExtendedColumnType = 5  // Fixed-length string, sized by db_length
+	DbExtendedColumnType_DB_TYPE_MEDIUMTEXT  DbExtendedColumnType = 6  // Text up to 16MB
+	DbExtendedColumnType_DB_TYPE_DATE        DbExtendedColumnType = 7  // Calendar date
+	DbExtendedColumnType_DB_TYPE_TIME        DbExtendedColumnType = 8  // Time of day
+	DbExtendedColumnType_DB_TYPE_TIMESTAMP   DbExtendedColumnType = 9  // Timestamp
+	DbExtendedColumnType_DB_TYPE_JSON        DbExtendedColumnType = 10 // JSON document
+	DbExtendedColumnType_DB_TYPE_UUID        DbExtendedColumnType = 11 // UUID stored as 16 raw bytes
+)
+
+// Enum value maps for DbExtendedColumnType.
+var (
+	DbExtendedColumnType_name = map[int32]string{
+		0:  "DB_TYPE_UNSPECIFIED",
+		1:  "DB_TYPE_TINYINT",
+		2:  "DB_TYPE_SMALLINT",
+		3:  "DB_TYPE_BIGINT",
+		4:  "DB_TYPE_DECIMAL",
+		5:  "DB_TYPE_CHAR",
+		6:  "DB_TYPE_MEDIUMTEXT",
+		7:  "DB_TYPE_DATE",
+		8:  "DB_TYPE_TIME",
+		9:  "DB_TYPE_TIMESTAMP",
+		10: "DB_TYPE_JSON",
+		11: "DB_TYPE_UUID",
+	}
+	DbExtendedColumnType_value = map[string]int32{
+		"DB_TYPE_UNSPECIFIED": 0,
+		"DB_TYPE_TINYINT":     1,
+		"DB_TYPE_SMALLINT":    2,
+		"DB_TYPE_BIGINT":      3,
+		"DB_TYPE_DECIMAL":     4,
+		"DB_TYPE_CHAR":        5,
+		"DB_TYPE_MEDIUMTEXT":  6,
+		"DB_TYPE_DATE":        7,
+		"DB_TYPE_TIME":        8,
+		"DB_TYPE_TIMESTAMP":   9,
+		"DB_TYPE_JSON":        10,
+		"DB_TYPE_UUID":        11,
+	}
+)
+
+func (x DbExtendedColumnType) Enum() *DbExtendedColumnType {
+	p := new(DbExtendedColumnType)
+	*p = x
+	return p
+}
+
+func (x DbExtendedColumnType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DbExtendedColumnType) Descriptor() protoreflect.EnumDescriptor {
+	return file_annotations_db_ext_proto_enumTypes[0].Descriptor()
+}
+
+func (DbExtendedColumnType) Type() protoreflect.EnumType {
+	return &file_annotations_db_ext_proto_enumTypes[0]
+}
+
+func (x DbExtendedColumnType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DbExtendedColumnType.Descriptor instead.
+func (DbExtendedColumnType) EnumDescriptor() ([]byte, []int) {
+	return file_annotations_db_ext_proto_rawDescGZIP(), []int{0}
+}
+
+// Storage of a generated (computed) column
+type DbGeneratedStorage int32
+
+const (
+	DbGeneratedStorage_DB_GENERATED_STORAGE_UNSPECIFIED DbGeneratedStorage = 0 // Defaults to VIRTUAL
+	DbGeneratedStorage_DB_GENERATED_STORAGE_VIRTUAL     DbGeneratedStorage = 1 // Computed when read
+	DbGeneratedStorage_DB_GENERATED_STORAGE_STORED      DbGeneratedStorage = 2 // Computed when written and stored
+)
+
+// Enum value maps for DbGeneratedStorage.
+var (
+	DbGeneratedStorage_name = map[int32]string{
+		0: "DB_GENERATED_STORAGE_UNSPECIFIED",
+		1: "DB_GENERATED_STORAGE_VIRTUAL",
+		2: "DB_GENERATED_STORAGE_STORED",
+	}
+	DbGeneratedStorage_value = map[string]int32{
+		"DB_GENERATED_STORAGE_UNSPECIFIED": 0,
+		"DB_GENERATED_STORAGE_VIRTUAL":     1,
+		"DB_GENERATED_STORAGE_STORED":      2,
+	}
+)
+
+func (x DbGeneratedStorage) Enum() *DbGeneratedStorage {
+	p := new(DbGeneratedStorage)
+	*p = x
+	return p
+}
+
+func (x DbGeneratedStorage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DbGeneratedStorage) Descriptor() protoreflect.EnumDescriptor {
+	return file_annotations_db_ext_proto_enumTypes[1].Descriptor()
+}
+
+func (DbGeneratedStorage) Type() protoreflect.EnumType {
+	return &file_annotations_db_ext_proto_enumTypes[1]
+}
+
+func (x DbGeneratedStorage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DbGeneratedStorage.Descriptor instead.
+func (DbGeneratedStorage) EnumDescriptor() ([]byte, []int) {
+	return file_annotations_db_ext_proto_rawDescGZIP(), []int{1}
+}
+
+// InnoDB/MyISAM row format of a MySQL table
+type DbRowFormat int32
+
+const (
+	DbRowFormat_DB_ROW_FORMAT_UNSPECIFIED DbRowFormat = 0 // Engine default
+	DbRowFormat_DB_ROW_FORMAT_DYNAMIC     DbRowFormat = 1
+	DbRowFormat_DB_ROW_FORMAT_COMPACT     DbRowFormat = 2
+	DbRowFormat_DB_ROW_FORMAT_REDUNDANT   DbRowFormat = 3
+	DbRowFormat_DB_ROW_FORMAT_COMPRESSED  DbRowFormat = 4
+	DbRowFormat_DB_ROW_FORMAT_FIXED       DbRowFormat = 5
+)
+
+// Enum value maps for DbRowFormat.
+var (
+	DbRowFormat_name = map[int32]string{
+		0: "DB_ROW_FORMAT_UNSPECIFIED",
+		1: "DB_ROW_FORMAT_DYNAMIC",
+		2: "DB_ROW_FORMAT_COMPACT",
+		3: "DB_ROW_FORMAT_REDUNDANT",
+		4: "DB_ROW_FORMAT_COMPRESSED",
+		5: "DB_ROW_FORMAT_FIXED",
+	}
+	DbRowFormat_value = map[string]int32{
+		"DB_ROW_FORMAT_UNSPECIFIED": 0,
+		"DB_ROW_FORMAT_DYNAMIC":     1,
+		"DB_ROW_FORMAT_COMPACT":     2,
+		"DB_ROW_FORMAT_REDUNDANT":   3,
+		"DB_ROW_FORMAT_COMPRESSED":  4,
+		"DB_ROW_FORMAT_FIXED":       5,
+	}
+)
+
+func (x DbRowFormat) Enum() *DbRowFormat {
+	p := new(DbRowFormat)
+	*p = x
+	return p
+}
+
+func (x DbRowFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DbRowFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_annotations_db_ext_proto_enumTypes[2].Descriptor()
+}
+
+func (DbRowFormat) Type() protoreflect.EnumType {
+	return &file_annotations_db_ext_proto_enumTypes[2]
+}
+
+func (x DbRowFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DbRowFormat.Descriptor instead.
+func (DbRowFormat) EnumDescriptor() ([]byte, []int) {
+	return file_annotations_db_ext_proto_rawDescGZIP(), []int{2}
+}
+
+// Index method
+type DbIndexMethod int32
+
+const (
+	DbIndexMethod_DB_INDEX_METHOD_UNSPECIFIED DbIndexMethod = 0 // Engine default
+	DbIndexMethod_DB_INDEX_METHOD_BTREE       DbIndexMethod = 1
+	DbIndexMethod_DB_INDEX_METHOD_HASH        DbIndexMethod = 2
+)
+
+// Enum value maps for DbIndexMethod.
+var (
+	DbIndexMethod_name = map[int32]string{
+		0: "DB_INDEX_METHOD_UNSPECIFIED",
+		1: "DB_INDEX_METHOD_BTREE",
+		2: "DB_INDEX_METHOD_HASH",
+	}
+	DbIndexMethod_value = map[string]int32{
+		"DB_INDEX_METHOD_UNSPECIFIED": 0,
+		"DB_INDEX_METHOD_BTREE":       1,
+		"DB_INDEX_METHOD_HASH":        2,
+	}
+)
+
+func (x DbIndexMethod) Enum() *DbIndexMethod {
+	p := new(DbIndexMethod)
+	*p = x
+	return p
+}
+
+func (x DbIndexMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DbIndexMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_annotations_db_ext_proto_enumTypes[3].Descriptor()
+}
+
+func (DbIndexMethod) Type() protoreflect.EnumType {
+	return &file_annotations_db_ext_proto_enumTypes[3]
+}
+
+func (x DbIndexMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DbIndexMethod.Descriptor instead.
+func (DbIndexMethod) EnumDescriptor() ([]byte, []int) {
+	return file_annotations_db_ext_proto_rawDescGZIP(), []int{3}
+}
+
+// Column of a table index, either a column name or an expression
+type DbIndexColumn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                      // Column name
+	Desc         bool   `protobuf:"varint,2,opt,name=desc,proto3" json:"desc,omitempty"`                                     // Sort the column in descending order
+	PrefixLength uint32 `protobuf:"varint,3,opt,name=prefix_length,json=prefixLength,proto3" json:"prefix_length,omitempty"` // MySQL prefix length for string columns
+	Expression   string `protobuf:"bytes,4,opt,name=expression,proto3" json:"expression,omitempty"`                          // Indexed expression, e.g. LOWER(email) (MySQL 8.0.13+ and SQLite)
+}
+
+func (x *DbIndexColumn) Reset() {
+	*x = DbIndexColumn{}
+	mi := &file_annotations_db_ext_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DbIndexColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbIndexColumn) ProtoMessage() {}
+
+func (x *DbIndexColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_annotations_db_ext_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbIndexColumn.ProtoReflect.Descriptor instead.
+func (*DbIndexColumn) Descriptor() ([]byte, []int) {
+	return file_annotations_db_ext_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DbIndexColumn) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DbIndexColumn) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *DbIndexColumn) GetPrefixLength() uint32 {
+	if x != nil {
+		return x.PrefixLength
+	}
+	return 0
+}
+
+func (x *DbIndexColumn) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+// Table index spanning one or more columns
+type DbTableIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                // Index name, derived from the table and column names when empty
+	Columns []*DbIndexColumn `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`                          // Ordered index columns
+	Unique  bool             `protobuf:"varint,3,opt,name=unique,proto3" json:"unique,omitempty"`                           // Whether the index is a UNIQUE index
+	Method  DbIndexMethod    `protobuf:"varint,4,opt,name=method,proto3,enum=db_ext.DbIndexMethod" json:"method,omitempty"` // MySQL index method
+	Where   string           `protobuf:"bytes,5,opt,name=where,proto3" json:"where,omitempty"`                              // Predicate of a partial index, e.g. deleted_at IS NULL (SQLite and PostgreSQL)
+}
+
+func (x *DbTableIndex) Reset() {
+	*x = DbTableIndex{}
+	mi := &file_annotations_db_ext_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DbTableIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbTableIndex) ProtoMessage() {}
+
+func (x *DbTableIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_annotations_db_ext_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbTableIndex.ProtoReflect.Descriptor instead.
+func (*DbTableIndex) Descriptor() ([]byte, []int) {
+	return file_annotations_db_ext_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DbTableIndex) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DbTableIndex) GetColumns() []*DbIndexColumn {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *DbTableIndex) GetUnique() bool {
+	if x != nil {
+		return x.Unique
+	}
+	return false
+}
+
+func (x *DbTableIndex) GetMethod() DbIndexMethod {
+	if x != nil {
+		return x.Method
+	}
+	return DbIndexMethod_DB_INDEX_METHOD_UNSPECIFIED
+}
+
+func (x *DbTableIndex) GetWhere() string {
+	if x != nil {
+		return x.Where
+	}
+	return ""
+}
+
+// Named CHECK constraint
+type DbCheckConstraint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`             // Constraint name, derived from the table and checked columns when empty
+	Expression string `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"` // Checked expression, e.g. quantity > 0
+}
+
+func (x *DbCheckConstraint) Reset() {
+	*x = DbCheckConstraint{}
+	mi := &file_annotations_db_ext_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DbCheckConstraint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbCheckConstraint) ProtoMessage() {}
+
+func (x *DbCheckConstraint) ProtoReflect() protoreflect.Message {
+	mi := &file_annotations_db_ext_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbCheckConstraint.ProtoReflect.Descriptor instead.
+func (*DbCheckConstraint) Descriptor() ([]byte, []int) {
+	return file_annotations_db_ext_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DbCheckConstraint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DbCheckConstraint) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+// Foreign key spanning one or more columns
+type DbForeignKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name              string                            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                 // Constraint name, derived from the table and columns when empty
+	Columns           []string                          `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`                                                           // Referencing columns
+	ReferencesTable   string                            `protobuf:"bytes,3,opt,name=references_table,json=referencesTable,proto3" json:"references_table,omitempty"`                    // Referenced table
+	ReferencesColumns []string                          `protobuf:"bytes,4,rep,name=references_columns,json=referencesColumns,proto3" json:"references_columns,omitempty"`              // Referenced columns, matched to columns by position
+	OnDelete          db_annotations.DbForeignKeyAction `protobuf:"varint,5,opt,name=on_delete,json=onDelete,proto3,enum=db_annotations.DbForeignKeyAction" json:"on_delete,omitempty"` // Action on delete
+	OnUpdate          db_annotations.DbForeignKeyAction `protobuf:"varint,6,opt,name=on_update,json=onUpdate,proto3,enum=db_annotations.DbForeignKeyAction" json:"on_update,omitempty"` // Action on update
+	ReferencesMessage string                            `protobuf:"bytes,7,opt,name=references_message,json=referencesMessage,proto3" json:"references_message,omitempty"`              // Referenced message, e.g. userauth.OrderDetails. Replaces references_table,
+}
+
+func (x *DbForeignKey) Reset() {
+	*x = DbForeignKey{}
+	mi := &file_annotations_db_ext_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DbForeignKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbForeignKey) ProtoMessage() {}
+
+func (x *DbForeignKey) ProtoReflect() protoreflect.Message {
+	mi := &file_annotations_db_ext_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbForeignKey.ProtoReflect.Descriptor instead.
+func (*DbForeignKey) Descriptor() ([]byte, []int) {
+	return file_annotations_db_ext_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DbForeignKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DbForeignKey) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *DbForeignKey) GetReferencesTable() string {
+	if x != nil {
+		return x.ReferencesTable
+	}
+	return ""
+}
+
+func (x *DbForeignKey) GetReferencesColumns() []string {
+	if x != nil {
+		return x.ReferencesColumns
+	}
+	return nil
+}
+
+func (x *DbForeignKey) GetOnDelete() db_annotations.DbForeignKeyAction {
+	if x != nil {
+		return x.OnDelete
+	}
+	return db_annotations.DbForeignKeyAction(0)
+}
+
+func (x *DbForeignKey) GetOnUpdate() db_annotations.DbForeignKeyAction {
+	if x != nil {
+		return x.OnUpdate
+	}
+	return db_annotations.DbForeignKeyAction(0)
+}
+
+func (x *DbForeignKey) GetReferencesMessage() string {
+	if x != nil {
+		return x.ReferencesMessage
+	}
+	return ""
+}
+
+var file_annotations_db_ext_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*DbExtendedColumnType)(nil),
+		Field:         51001,
+		Name:          "db_ext.db_extended_type",
+		Tag:           "varint,51001,opt,name=db_extended_type,enum=db_ext.DbExtendedColumnType",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*int32)(nil),
+		Field:         51002,
+		Name:          "db_ext.db_length",
+		Tag:           "varint,51002,opt,name=db_length",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51003,
+		Name:          "db_ext.db_unsigned",
+		Tag:           "varint,51003,opt,name=db_unsigned",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51004,
+		Name:          "db_ext.db_generated_expression",
+		Tag:           "bytes,51004,opt,name=db_generated_expression",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*DbGeneratedStorage)(nil),
+		Field:         51005,
+		Name:          "db_ext.db_generated_storage",
+		Tag:           "varint,51005,opt,name=db_generated_storage,enum=db_ext.DbGeneratedStorage",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*db_annotations.DbIndexType)(nil),
+		Field:         51006,
+		Name:          "db_ext.db_index_type",
+		Tag:           "varint,51006,opt,name=db_index_type,enum=db_annotations.DbIndexType",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51007,
+		Name:          "db_ext.db_references",
+		Tag:           "bytes,51007,opt,name=db_references",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51008,
+		Name:          "db_ext.db_fake",
+		Tag:           "bytes,51008,opt,name=db_fake",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51101,
+		Name:          "db_ext.db_engine",
+		Tag:           "bytes,51101,opt,name=db_engine",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51102,
+		Name:          "db_ext.db_default_charset",
+		Tag:           "bytes,51102,opt,name=db_default_charset",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51103,
+		Name:          "db_ext.db_table_collate",
+		Tag:           "bytes,51103,opt,name=db_table_collate",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*DbRowFormat)(nil),
+		Field:         51104,
+		Name:          "db_ext.db_row_format",
+		Tag:           "varint,51104,opt,name=db_row_format,enum=db_ext.DbRowFormat",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*uint64)(nil),
+		Field:         51105,
+		Name:          "db_ext.db_auto_increment_start",
+		Tag:           "varint,51105,opt,name=db_auto_increment_start",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51106,
+		Name:          "db_ext.db_sqlite_strict",
+		Tag:           "varint,51106,opt,name=db_sqlite_strict",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51107,
+		Name:          "db_ext.db_sqlite_without_rowid",
+		Tag:           "varint,51107,opt,name=db_sqlite_without_rowid",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: ([]*DbTableIndex)(nil),
+		Field:         51108,
+		Name:          "db_ext.db_table_index",
+		Tag:           "bytes,51108,rep,name=db_table_index",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: ([]*DbCheckConstraint)(nil),
+		Field:         51109,
+		Name:          "db_ext.db_check",
+		Tag:           "bytes,51109,rep,name=db_check",
+		Filename:      "annotations/db_ext.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: ([]*DbForeignKey)(nil),
+		Field:         51110,
+		Name:          "db_ext.db_foreign_key",
+		Tag:           "bytes,51110,rep,name=db_foreign_key",
+		Filename:      "annotations/db_ext.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// Extended column type, overrides db_column_type
+	//
+	// optional db_ext.DbExtendedColumnType db_extended_type = 51001;
+	E_DbExtendedType = &file_annotations_db_ext_proto_extTypes[0]
+	// Length for VARCHAR, CHAR and BINARY columns (VARCHAR defaults to 255)
+	//
+	// optional int32 db_length = 51002;
+	E_DbLength = &file_annotations_db_ext_proto_extTypes[1]
+	// Whether a numeric column is UNSIGNED
+	//
+	// optional bool db_unsigned = 51003;
+	E_DbUnsigned = &file_annotations_db_ext_proto_extTypes[2]
+	// Expression of a generated column, e.g. CONCAT(first_name, ' ', last_name)
+	//
+	// optional string db_generated_expression = 51004;
+	E_DbGeneratedExpression = &file_annotations_db_ext_proto_extTypes[3]
+	// Storage of a generated column
+	//
+	// optional db_ext.DbGeneratedStorage db_generated_storage = 51005;
+	E_DbGeneratedStorage = &file_annotations_db_ext_proto_extTypes[4]
+	// Type of the single column index on the field (SIMPLE, FULLTEXT or SPATIAL).
+	// db_annotations.db_index = true is equivalent to SIMPLE.
+	//
+	// optional db_annotations.DbIndexType db_index_type = 51006;
+	E_DbIndexType = &file_annotations_db_ext_proto_extTypes[5]
+	// Referenced field of a foreign key, e.g. userauth.Customer.customer_id. Resolved to the table and column
+	// of the field, replaces db_foreign_key_table and db_foreign_key_column.
+	//
+	// optional string db_references = 51007;
+	E_DbReferences = &file_annotations_db_ext_proto_extTypes[6]
+	// Fake data generator of the column used when seeding, e.g. email, name or money
+	//
+	// optional string db_fake = 51008;
+	E_DbFake = &file_annotations_db_ext_proto_extTypes[7]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// MySQL storage engine, e.g. InnoDB
+	//
+	// optional string db_engine = 51101;
+	E_DbEngine = &file_annotations_db_ext_proto_extTypes[8]
+	// MySQL default character set of the table, e.g. utf8mb4
+	//
+	// optional string db_default_charset = 51102;
+	E_DbDefaultCharset = &file_annotations_db_ext_proto_extTypes[9]
+	// MySQL default collation of the table, e.g. utf8mb4_unicode_ci
+	//
+	// optional string db_table_collate = 51103;
+	E_DbTableCollate = &file_annotations_db_ext_proto_extTypes[10]
+	// MySQL row format
+	//
+	// optional db_ext.DbRowFormat db_row_format = 51104;
+	E_DbRowFormat = &file_annotations_db_ext_proto_extTypes[11]
+	// MySQL initial AUTO_INCREMENT value
+	//
+	// optional uint64 db_auto_increment_start = 51105;
+	E_DbAutoIncrementStart = &file_annotations_db_ext_proto_extTypes[12]
+	// SQLite STRICT table, column types are enforced
+	//
+	// optional bool db_sqlite_strict = 51106;
+	E_DbSqliteStrict = &file_annotations_db_ext_proto_extTypes[13]
+	// SQLite WITHOUT ROWID table, requires a primary key
+	//
+	// optional bool db_sqlite_without_rowid = 51107;
+	E_DbSqliteWithoutRowid = &file_annotations_db_ext_proto_extTypes[14]
+	// Table indexes, e.g. { name: "orders_recent_idx" columns: [{ name: "customer_id" }, { name: "order_date" desc: true }] }
+	//
+	// repeated db_ext.DbTableIndex db_table_index = 51108;
+	E_DbTableIndex = &file_annotations_db_ext_proto_extTypes[15]
+	// Named CHECK constraints, e.g. { name: "orderitems_quantity_chk" expression: "quantity > 0" }
+	//
+	// repeated db_ext.DbCheckConstraint db_check = 51109;
+	E_DbCheck = &file_annotations_db_ext_proto_extTypes[16]
+	// Foreign keys, e.g. { name: "shipments_order_detail_fk" columns: ["order_id", "product_id"] references_table: "OrderDetails" references_columns: ["order_id", "product_id"] }
+	//
+	// repeated db_ext.DbForeignKey db_foreign_key = 51110;
+	E_DbForeignKey = &file_annotations_db_ext_proto_extTypes[17]
+)
+
+var File_annotations_db_ext_proto protoreflect.FileDescriptor
+
+var file_annotations_db_ext_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x64, 0x62,
+	0x5f, 0x65, 0x78, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x64, 0x62, 0x5f, 0x65,
+	0x78, 0x74, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2d, 0x64,
+	0x62, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x7c, 0x0a, 0x0d, 0x44, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12,
+	0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0xb0, 0x01, 0x0a, 0x0c, 0x44, 0x62, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x62, 0x5f, 0x65, 0x78, 0x74, 0x2e, 0x44,
+	0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x12, 0x2d, 0x0a,
+	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
+	0x64, 0x62, 0x5f, 0x65, 0x78, 0x74, 0x2e, 0x44, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x77, 0x68, 0x65, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x77, 0x68, 0x65,
+	0x72, 0x65, 0x22, 0x47, 0x0a, 0x11, 0x44, 0x62, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6e,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65,
+	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xc7, 0x02, 0x0a, 0x0c,
+	0x44, 0x62, 0x46, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x11, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x43, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x73, 0x12, 0x3f, 0x0a, 0x09, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x64, 0x62, 0x5f, 0x61, 0x6e, 0x6e,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x44, 0x62, 0x46, 0x6f, 0x72, 0x65, 0x69,
+	0x67, 0x6e, 0x4b, 0x65, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6f, 0x6e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x3f, 0x0a, 0x09, 0x6f, 0x6e, 0x5f, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x64, 0x62, 0x5f, 0x61, 0x6e,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x44, 0x62, 0x46, 0x6f, 0x72, 0x65,
+	0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6f, 0x6e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0x8c, 0x02, 0x0a, 0x14, 0x44, 0x62, 0x45, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17,
+	0x0a, 0x13, 0x44, 0x42, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x42, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x54, 0x49, 0x4e, 0x59, 0x49, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10,
+	0x44, 0x42, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4d, 0x41, 0x4c, 0x4c, 0x49, 0x4e, 0x54,
+	0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x42, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x49,
+	0x47, 0x49, 0x4e, 0x54, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x42, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x44, 0x45, 0x43, 0x49, 0x4d, 0x41, 0x4c, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x44,
+	0x42, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x52, 0x10, 0x05, 0x12, 0x16, 0x0a,
+	0x12, 0x44, 0x42, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x45, 0x44, 0x49, 0x55, 0x4d, 0x54,
+	0x45, 0x58, 0x54, 0x10, 0x06, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x42, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x44, 0x41, 0x54, 0x45, 0x10, 0x07, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x42, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x08, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x42, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x10, 0x09,
+	0x12, 0x10, 0x0a, 0x0c, 0x44, 0x42, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4a, 0x53, 0x4f, 0x4e,
+	0x10, 0x0a, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x42, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x55,
+	0x49, 0x44, 0x10, 0x0b, 0x2a, 0x7d, 0x0a, 0x12, 0x44, 0x62, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x44, 0x42,
+	0x5f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x41,
+	0x47, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x20, 0x0a, 0x1c, 0x44, 0x42, 0x5f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x45, 0x44,
+	0x5f, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x56, 0x49, 0x52, 0x54, 0x55, 0x41, 0x4c,
+	0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x44, 0x42, 0x5f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54,
+	0x45, 0x44, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45,
+	0x44, 0x10, 0x02, 0x2a, 0xb6, 0x01, 0x0a, 0x0b, 0x44, 0x62, 0x52, 0x6f, 0x77, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x19, 0x44, 0x42, 0x5f, 0x52, 0x4f, 0x57, 0x5f, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x44, 0x42, 0x5f, 0x52, 0x4f, 0x57, 0x5f, 0x46, 0x4f, 0x52,
+	0x4d, 0x41, 0x54, 0x5f, 0x44, 0x59, 0x4e, 0x41, 0x4d, 0x49, 0x43, 0x10, 0x01, 0x12, 0x19, 0x0a,
+	0x15, 0x44, 0x42, 0x5f, 0x52, 0x4f, 0x57, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x43,
+	0x4f, 0x4d, 0x50, 0x41, 0x43, 0x54, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x44, 0x42, 0x5f, 0x52,
+	0x4f, 0x57, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x52, 0x45, 0x44, 0x55, 0x4e, 0x44,
+	0x41, 0x4e, 0x54, 0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x44, 0x42, 0x5f, 0x52, 0x4f, 0x57, 0x5f,
+	0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53, 0x53, 0x45,
+	0x44, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x42, 0x5f, 0x52, 0x4f, 0x57, 0x5f, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x5f, 0x46, 0x49, 0x58, 0x45, 0x44, 0x10, 0x05, 0x2a, 0x65, 0x0a, 0x0d,
+	0x44, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1f, 0x0a,
+	0x1b, 0x44, 0x42, 0x5f, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19,
+	0x0a, 0x15, 0x44, 0x42, 0x5f, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f,
+	0x44, 0x5f, 0x42, 0x54, 0x52, 0x45, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x44, 0x42, 0x5f,
+	0x49, 0x4e, 0x44, 0x45, 0x58, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x48, 0x41, 0x53,
+	0x48, 0x10, 0x02, 0x3a, 0x67, 0x0a, 0x10, 0x64, 0x62, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xb9, 0x8e, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c,
+	0x2e, 0x64, 0x62, 0x5f, 0x65, 0x78, 0x74, 0x2e, 0x44, 0x62, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x64, 0x62,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x54, 0x79, 0x70, 0x65, 0x3a, 0x3c, 0x0a, 0x09,
+	0x64, 0x62, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xba, 0x8e, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x64, 0x62, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x3a, 0x40, 0x0a, 0x0b, 0x64, 0x62,
+	0x5f, 0x75, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xbb, 0x8e, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x64, 0x62, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x3a, 0x57, 0x0a, 0x17,
+	0x64, 0x62, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x65, 0x78, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xbc, 0x8e, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15,
+	0x64, 0x62, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x45, 0x78, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x3a, 0x6d, 0x0a, 0x14, 0x64, 0x62, 0x5f, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xbd, 0x8e, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x64, 0x62, 0x5f, 0x65, 0x78, 0x74, 0x2e, 0x44, 0x62,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x52, 0x12, 0x64, 0x62, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x53, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x3a, 0x60, 0x0a, 0x0d, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0xbe, 0x8e, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x64,
+	0x62, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x44, 0x62,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x64, 0x62, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x54, 0x79, 0x70, 0x65, 0x3a, 0x44, 0x0a, 0x0d, 0x64, 0x62, 0x5f, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xbf, 0x8e, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x64, 0x62, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x3a, 0x38, 0x0a, 0x07,
+	0x64, 0x62, 0x5f, 0x66, 0x61, 0x6b, 0x65, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xc0, 0x8e, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x64, 0x62, 0x46, 0x61, 0x6b, 0x65, 0x3a, 0x3e, 0x0a, 0x09, 0x64, 0x62, 0x5f, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9d, 0x8f, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x62,
+	0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x3a, 0x4f, 0x0a, 0x12, 0x64, 0x62, 0x5f, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x72, 0x73, 0x65, 0x74, 0x12, 0x1f, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9e, 0x8f,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x64, 0x62, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x43, 0x68, 0x61, 0x72, 0x73, 0x65, 0x74, 0x3a, 0x4b, 0x0a, 0x10, 0x64, 0x62, 0x5f, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x9f, 0x8f, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x62, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x6f, 0x6c,
+	0x6c, 0x61, 0x74, 0x65, 0x3a, 0x5a, 0x0a, 0x0d, 0x64, 0x62, 0x5f, 0x72, 0x6f, 0x77, 0x5f, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xa0, 0x8f, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x64, 0x62, 0x5f, 0x65, 0x78, 0x74, 0x2e, 0x44, 0x62, 0x52, 0x6f, 0x77, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x52, 0x0b, 0x64, 0x62, 0x52, 0x6f, 0x77, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x3a, 0x58, 0x0a, 0x17, 0x64, 0x62, 0x5f, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x69, 0x6e, 0x63, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1f, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xa1, 0x8f, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x64, 0x62, 0x41, 0x75, 0x74, 0x6f, 0x49, 0x6e, 0x63, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74, 0x3a, 0x4b, 0x0a, 0x10, 0x64, 0x62,
+	0x5f, 0x73, 0x71, 0x6c, 0x69, 0x74, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x12, 0x1f,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0xa2, 0x8f, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x64, 0x62, 0x53, 0x71, 0x6c, 0x69, 0x74,
+	0x65, 0x53, 0x74, 0x72, 0x69, 0x63, 0x74, 0x3a, 0x58, 0x0a, 0x17, 0x64, 0x62, 0x5f, 0x73, 0x71,
+	0x6c, 0x69, 0x74, 0x65, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x5f, 0x72, 0x6f, 0x77,
+	0x69, 0x64, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0xa3, 0x8f, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x64, 0x62, 0x53,
+	0x71, 0x6c, 0x69, 0x74, 0x65, 0x57, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x52, 0x6f, 0x77, 0x69,
+	0x64, 0x3a, 0x5d, 0x0a, 0x0e, 0x64, 0x62, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0xa4, 0x8f, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64,
+	0x62, 0x5f, 0x65, 0x78, 0x74, 0x2e, 0x44, 0x62, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x52, 0x0c, 0x64, 0x62, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x3a, 0x57, 0x0a, 0x08, 0x64, 0x62, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1f, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xa5, 0x8f,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x62, 0x5f, 0x65, 0x78, 0x74, 0x2e, 0x44,
+	0x62, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74,
+	0x52, 0x07, 0x64, 0x62, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x3a, 0x5d, 0x0a, 0x0e, 0x64, 0x62, 0x5f,
+	0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x12, 0x1f, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xa6, 0x8f, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x62, 0x5f, 0x65, 0x78, 0x74, 0x2e, 0x44, 0x62,
+	0x46, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x52, 0x0c, 0x64, 0x62, 0x46, 0x6f,
+	0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x42, 0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6d, 0x72, 0x61, 0x6e, 0x33, 0x31, 0x34, 0x31,
+	0x35, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2d, 0x64, 0x62, 0x2d, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x3b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_annotations_db_ext_proto_rawDescOnce sync.Once
+	file_annotations_db_ext_proto_rawDescData = file_annotations_db_ext_proto_rawDesc
+)
+
+func file_annotations_db_ext_proto_rawDescGZIP() []byte {
+	file_annotations_db_ext_proto_rawDescOnce.Do(func() {
+		file_annotations_db_ext_proto_rawDescData = protoimpl.X.CompressGZIP(file_annotations_db_ext_proto_rawDescData)
+	})
+	return file_annotations_db_ext_proto_rawDescData
+}
+
+var file_annotations_db_ext_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_annotations_db_ext_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_annotations_db_ext_proto_goTypes = []any{
+	(DbExtendedColumnType)(0),              // 0: db_ext.DbExtendedColumnType
+	(DbGeneratedStorage)(0),                // 1: db_ext.DbGeneratedStorage
+	(DbRowFormat)(0),                       // 2: db_ext.DbRowFormat
+	(DbIndexMethod)(0),                     // 3: db_ext.DbIndexMethod
+	(*DbIndexColumn)(nil),                  // 4: db_ext.DbIndexColumn
+	(*DbTableIndex)(nil),                   // 5: db_ext.DbTableIndex
+	(*DbCheckConstraint)(nil),              // 6: db_ext.DbCheckConstraint
+	(*DbForeignKey)(nil),                   // 7: db_ext.DbForeignKey
+	(db_annotations.DbForeignKeyAction)(0), // 8: db_annotations.DbForeignKeyAction
+	(*descriptorpb.FieldOptions)(nil),      // 9: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil),    // 10: google.protobuf.MessageOptions
+	(db_annotations.DbIndexType)(0),        // 11: db_annotations.DbIndexType
+}
+var file_annotations_db_ext_proto_depIdxs = []int32{
+	4,  // 0: db_ext.DbTableIndex.columns:type_name -> db_ext.DbIndexColumn
+	3,  // 1: db_ext.DbTableIndex.method:type_name -> db_ext.DbIndexMethod
+	8,  // 2: db_ext.DbForeignKey.on_delete:type_name -> db_annotations.DbForeignKeyAction
+	8,  // 3: db_ext.DbForeignKey.on_update:type_name -> db_annotations.DbForeignKeyAction
+	9,  // 4: db_ext.db_extended_type:extendee -> google.protobuf.FieldOptions
+	9,  // 5: db_ext.db_length:extendee -> google.protobuf.FieldOptions
+	9,  // 6: db_ext.db_unsigned:extendee -> google.protobuf.FieldOptions
+	9,  // 7: db_ext.db_generated_expression:extendee -> google.protobuf.FieldOptions
+	9,  // 8: db_ext.db_generated_storage:extendee -> google.protobuf.FieldOptions
+	9,  // 9: db_ext.db_index_type:extendee -> google.protobuf.FieldOptions
+	9,  // 10: db_ext.db_references:extendee -> google.protobuf.FieldOptions
+	9,  // 11: db_ext.db_fake:extendee -> google.protobuf.FieldOptions
+	10, // 12: db_ext.db_engine:extendee -> google.protobuf.MessageOptions
+	10, // 13: db_ext.db_default_charset:extendee -> google.protobuf.MessageOptions
+	10, // 14: db_ext.db_table_collate:extendee -> google.protobuf.MessageOptions
+	10, // 15: db_ext.db_row_format:extendee -> google.protobuf.MessageOptions
+	10, // 16: db_ext.db_auto_increment_start:extendee -> google.protobuf.MessageOptions
+	10, // 17: db_ext.db_sqlite_strict:extendee -> google.protobuf.MessageOptions
+	10, // 18: db_ext.db_sqlite_without_rowid:extendee -> google.protobuf.MessageOptions
+	10, // 19: db_ext.db_table_index:extendee -> google.protobuf.MessageOptions
+	10, // 20: db_ext.db_check:extendee -> google.protobuf.MessageOptions
+	10, // 21: db_ext.db_foreign_key:extendee -> google.protobuf.MessageOptions
+	0,  // 22: db_ext.db_extended_type:type_name -> db_ext.DbExtendedColumnType
+	1,  // 23: db_ext.db_generated_storage:type_name -> db_ext.DbGeneratedStorage
+	11, // 24: db_ext.db_index_type:type_name -> db_annotations.DbIndexType
+	2,  // 25: db_ext.db_row_format:type_name -> db_ext.DbRowFormat
+	5,  // 26: db_ext.db_table_index:type_name -> db_ext.DbTableIndex
+	6,  // 27: db_ext.db_check:type_name -> db_ext.DbCheckConstraint
+	7,  // 28: db_ext.db_foreign_key:type_name -> db_ext.DbForeignKey
+	29, // [29:29] is the sub-list for method output_type
+	29, // [29:29] is the sub-list for method input_type
+	22, // [22:29] is the sub-list for extension type_name
+	4,  // [4:22] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_annotations_db_ext_proto_init() }
+func file_annotations_db_ext_proto_init() {
+	if File_annotations_db_ext_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_annotations_db_ext_proto_rawDesc,
+			NumEnums:      4,
+			NumMessages:   4,
+			NumExtensions: 18,
+			NumServices:   0,
+		},
+		GoTypes:           file_annotations_db_ext_proto_goTypes,
+		DependencyIndexes: file_annotations_db_ext_proto_depIdxs,
+		EnumInfos:         file_annotations_db_ext_proto_enumTypes,
+		MessageInfos:      file_annotations_db_ext_proto_msgTypes,
+		ExtensionInfos:    file_annotations_db_ext_proto_extTypes,
+	}.Build()
+	File_annotations_db_ext_proto = out.File
+	file_annotations_db_ext_proto_rawDesc = nil
+	file_annotations_db_ext_proto_goTypes = nil
+	file_annotations_db_ext_proto_depIdxs = nil
+}
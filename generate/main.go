@@ -34,7 +34,9 @@ func main() {
 		&user_proto.Product{},
 		&user_proto.Orders{},
 		&user_proto.OrderDetails{},
+		&user_proto.OrderDetailShipments{},
 		&user_proto.OrderItems{},
+		&user_proto.Payment{},
 	}
 	// Generate validated Create table statements that were validated by applying to an actual database
 	statements, err := translator.ValidateSchema(inputProtos)
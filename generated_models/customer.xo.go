@@ -1,4 +1,4 @@
-// Package generated_models contains generated code for schema 'protodbtranslatortestdb'.
+// Package generated_models contains generated code for schema 'proto_db_default'.
 package generated_models
 
 // Code generated by xo. DO NOT EDIT.
@@ -10,6 +10,10 @@ import (
 	"log"
 	"strings"
 	"time"
+
+	"github.com/imran31415/proto-db-translator/user"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Customer represents a row from 'Customer'.
@@ -18,6 +22,7 @@ type Customer struct {
 	CustomerName string         `json:"customer_name"` // customer_name
 	Email        string         `json:"email"`         // email
 	Phone        sql.NullString `json:"phone"`         // phone
+	EmailLower   sql.NullString `json:"email_lower"`   // email_lower
 	CreatedAt    time.Time      `json:"created_at"`    // created_at
 	UpdatedAt    time.Time      `json:"updated_at"`    // updated_at
 	// xo fields
@@ -212,7 +217,7 @@ func CustomerKeysetPage(ctx context.Context, db DB, column string, key interface
 			_exists: true,
 		}
 		if err := rows.Scan(
-			&c.CustomerID, &c.CustomerName, &c.Email, &c.Phone, &c.CreatedAt, &c.UpdatedAt,
+			&c.CustomerID, &c.CustomerName, &c.Email, &c.Phone, &c.EmailLower, &c.CreatedAt, &c.UpdatedAt,
 		); err != nil {
 			return nil, nil, logerror(err)
 		}
@@ -238,7 +243,7 @@ func CustomerKeysetPage(ctx context.Context, db DB, column string, key interface
 func CustomerByCustomerID(ctx context.Context, db DB, customerID int) (*Customer, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`customer_id, customer_name, email, phone, created_at, updated_at ` +
+		`customer_id, customer_name, email, phone, email_lower, created_at, updated_at ` +
 		`FROM Customer ` +
 		`WHERE customer_id = ?`
 	// run
@@ -246,19 +251,53 @@ func CustomerByCustomerID(ctx context.Context, db DB, customerID int) (*Customer
 	c := Customer{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, customerID).Scan(&c.CustomerID, &c.CustomerName, &c.Email, &c.Phone, &c.CreatedAt, &c.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, customerID).Scan(&c.CustomerID, &c.CustomerName, &c.Email, &c.Phone, &c.EmailLower, &c.CreatedAt, &c.UpdatedAt); err != nil {
 		return nil, logerror(err)
 	}
 	return &c, nil
 }
 
+// CustomerByCustomerName retrieves a row from 'Customer' as a [Customer].
+//
+// Generated from index 'Customer_customer_name_idx'.
+func CustomerByCustomerName(ctx context.Context, db DB, customerName string) ([]*Customer, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`customer_id, customer_name, email, phone, email_lower, created_at, updated_at ` +
+		`FROM Customer ` +
+		`WHERE customer_name = ?`
+	// run
+	logf(sqlstr, customerName)
+	rows, err := db.QueryContext(ctx, sqlstr, customerName)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Customer
+	for rows.Next() {
+		c := Customer{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&c.CustomerID, &c.CustomerName, &c.Email, &c.Phone, &c.EmailLower, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
 // CustomerByEmail retrieves a row from 'Customer' as a [Customer].
 //
 // Generated from index 'email'.
 func CustomerByEmail(ctx context.Context, db DB, email string) (*Customer, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`customer_id, customer_name, email, phone, created_at, updated_at ` +
+		`customer_id, customer_name, email, phone, email_lower, created_at, updated_at ` +
 		`FROM Customer ` +
 		`WHERE email = ?`
 	// run
@@ -266,8 +305,218 @@ func CustomerByEmail(ctx context.Context, db DB, email string) (*Customer, error
 	c := Customer{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, email).Scan(&c.CustomerID, &c.CustomerName, &c.Email, &c.Phone, &c.CreatedAt, &c.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, email).Scan(&c.CustomerID, &c.CustomerName, &c.Email, &c.Phone, &c.EmailLower, &c.CreatedAt, &c.UpdatedAt); err != nil {
 		return nil, logerror(err)
 	}
 	return &c, nil
 }
+
+// ToProto converts the [Customer] to a [user.Customer].
+func (c *Customer) ToProto() (*user.Customer, error) {
+	m := &user.Customer{}
+	m.CustomerId = int32(c.CustomerID)
+	m.CustomerName = c.CustomerName
+	m.Email = c.Email
+	if c.Phone.Valid {
+		m.Phone = c.Phone.String
+	}
+	if c.EmailLower.Valid {
+		m.EmailLower = c.EmailLower.String
+	}
+	m.CreatedAt = timestamppb.New(c.CreatedAt)
+	m.UpdatedAt = timestamppb.New(c.UpdatedAt)
+	return m, nil
+}
+
+// FromProto sets the [Customer] from a [user.Customer].
+func (c *Customer) FromProto(m *user.Customer) error {
+	c.CustomerID = int(m.CustomerId)
+	c.CustomerName = m.CustomerName
+	c.Email = m.Email
+	if m.Phone != "" {
+		c.Phone = sql.NullString{String: m.Phone, Valid: true}
+	} else {
+		c.Phone = sql.NullString{}
+	}
+	if m.CreatedAt != nil {
+		c.CreatedAt = m.CreatedAt.AsTime()
+	} else {
+		c.CreatedAt = time.Time{}
+	}
+	if m.UpdatedAt != nil {
+		c.UpdatedAt = m.UpdatedAt.AsTime()
+	} else {
+		c.UpdatedAt = time.Time{}
+	}
+	return nil
+}
+
+// CustomerRepository reads and writes [Customer] rows, see
+// [NewSQLCustomerRepository] and [NewMemoryCustomerRepository].
+type CustomerRepository interface {
+	Insert(ctx context.Context, c *Customer) error
+	Update(ctx context.Context, c *Customer) error
+	Upsert(ctx context.Context, c *Customer) error
+	Delete(ctx context.Context, c *Customer) error
+	CustomerByCustomerName(ctx context.Context, customerName string) ([]*Customer, error)
+	CustomerByEmail(ctx context.Context, email string) (*Customer, error)
+	CustomerByCustomerID(ctx context.Context, customerID int) (*Customer, error)
+}
+
+// NewSQLCustomerRepository returns a [CustomerRepository] running the
+// queries of the [Customer] funcs on db.
+func NewSQLCustomerRepository(db DB) CustomerRepository {
+	return sqlCustomerRepository{db: db}
+}
+
+type sqlCustomerRepository struct {
+	db DB
+}
+
+func (repo sqlCustomerRepository) Insert(ctx context.Context, c *Customer) error {
+	return c.Insert(ctx, repo.db)
+}
+
+func (repo sqlCustomerRepository) Update(ctx context.Context, c *Customer) error {
+	return c.Update(ctx, repo.db)
+}
+
+func (repo sqlCustomerRepository) Upsert(ctx context.Context, c *Customer) error {
+	return c.Upsert(ctx, repo.db)
+}
+
+func (repo sqlCustomerRepository) Delete(ctx context.Context, c *Customer) error {
+	return c.Delete(ctx, repo.db)
+}
+
+func (repo sqlCustomerRepository) CustomerByCustomerName(ctx context.Context, customerName string) ([]*Customer, error) {
+	return CustomerByCustomerName(ctx, repo.db, customerName)
+}
+
+func (repo sqlCustomerRepository) CustomerByEmail(ctx context.Context, email string) (*Customer, error) {
+	return CustomerByEmail(ctx, repo.db, email)
+}
+
+func (repo sqlCustomerRepository) CustomerByCustomerID(ctx context.Context, customerID int) (*Customer, error) {
+	return CustomerByCustomerID(ctx, repo.db, customerID)
+}
+
+// NewMemoryCustomerRepository returns a [CustomerRepository] storing the
+// rows in memdb.
+func NewMemoryCustomerRepository(memdb *MemoryDB) CustomerRepository {
+	return memoryCustomerRepository{memdb: memdb}
+}
+
+type memoryCustomerRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryCustomerRepository) Insert(ctx context.Context, c *Customer) error {
+	switch {
+	case c._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case c._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("Customer", c.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	c.setMemoryRow(row)
+	c._exists = true
+	return nil
+}
+
+func (repo memoryCustomerRepository) Update(ctx context.Context, c *Customer) error {
+	switch {
+	case !c._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case c._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("Customer", c.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryCustomerRepository) Upsert(ctx context.Context, c *Customer) error {
+	switch {
+	case c._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("Customer", c.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	c._exists = true
+	return nil
+}
+
+func (repo memoryCustomerRepository) Delete(ctx context.Context, c *Customer) error {
+	switch {
+	case !c._exists: // doesn't exist
+		return nil
+	case c._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("Customer", c.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	c._deleted = true
+	return nil
+}
+
+func (repo memoryCustomerRepository) CustomerByCustomerName(ctx context.Context, customerName string) ([]*Customer, error) {
+	rows := repo.memdb.find("Customer", []string{"customer_name"}, customerName)
+	var res []*Customer
+	for _, row := range rows {
+		c := Customer{_exists: true}
+		c.setMemoryRow(row)
+		res = append(res, &c)
+	}
+	return res, nil
+}
+
+func (repo memoryCustomerRepository) CustomerByEmail(ctx context.Context, email string) (*Customer, error) {
+	rows := repo.memdb.find("Customer", []string{"email"}, email)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	c := Customer{_exists: true}
+	c.setMemoryRow(rows[0])
+	return &c, nil
+}
+
+func (repo memoryCustomerRepository) CustomerByCustomerID(ctx context.Context, customerID int) (*Customer, error) {
+	rows := repo.memdb.find("Customer", []string{"customer_id"}, customerID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	c := Customer{_exists: true}
+	c.setMemoryRow(rows[0])
+	return &c, nil
+}
+
+// memoryRow returns the fields of the [Customer] by column.
+func (c *Customer) memoryRow() memoryRow {
+	return memoryRow{
+		"customer_id":   c.CustomerID,
+		"customer_name": c.CustomerName,
+		"email":         c.Email,
+		"phone":         c.Phone,
+		"email_lower":   c.EmailLower,
+		"created_at":    c.CreatedAt,
+		"updated_at":    c.UpdatedAt,
+	}
+}
+
+// setMemoryRow sets the fields of the [Customer] from a row.
+func (c *Customer) setMemoryRow(row memoryRow) {
+	c.CustomerID = row["customer_id"].(int)
+	c.CustomerName = row["customer_name"].(string)
+	c.Email = row["email"].(string)
+	c.Phone = row["phone"].(sql.NullString)
+	c.EmailLower = row["email_lower"].(sql.NullString)
+	c.CreatedAt = row["created_at"].(time.Time)
+	c.UpdatedAt = row["updated_at"].(time.Time)
+}
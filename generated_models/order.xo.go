@@ -4,10 +4,15 @@ package generated_models
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"strings"
 	"time"
+
+	"github.com/imran31415/proto-db-translator/user"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Order represents a row from 'Orders'.
@@ -15,7 +20,7 @@ type Order struct {
 	OrderID     int       `json:"order_id"`     // order_id
 	CustomerID  int       `json:"customer_id"`  // customer_id
 	OrderDate   time.Time `json:"order_date"`   // order_date
-	TotalAmount float32   `json:"total_amount"` // total_amount
+	TotalAmount float64   `json:"total_amount"` // total_amount
 	Status      string    `json:"status"`       // status
 	// xo fields
 	_exists, _deleted bool
@@ -229,10 +234,10 @@ func OrderKeysetPage(ctx context.Context, db DB, column string, key interface{},
 	return results, lastItem, nil
 }
 
-// OrderByOrderDateStatus retrieves a row from 'Orders' as a [Order].
+// OrdersByOrderDateStatus retrieves a row from 'Orders' as a [Order].
 //
 // Generated from index 'Orders_order_date_status_idx'.
-func OrderByOrderDateStatus(ctx context.Context, db DB, orderDate time.Time, status string) (*Order, error) {
+func OrdersByOrderDateStatus(ctx context.Context, db DB, orderDate time.Time, status string) ([]*Order, error) {
 	// query
 	const sqlstr = `SELECT ` +
 		`order_id, customer_id, order_date, total_amount, status ` +
@@ -240,13 +245,27 @@ func OrderByOrderDateStatus(ctx context.Context, db DB, orderDate time.Time, sta
 		`WHERE order_date = ? AND status = ?`
 	// run
 	logf(sqlstr, orderDate, status)
-	o := Order{
-		_exists: true,
+	rows, err := db.QueryContext(ctx, sqlstr, orderDate, status)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Order
+	for rows.Next() {
+		o := Order{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&o.OrderID, &o.CustomerID, &o.OrderDate, &o.TotalAmount, &o.Status); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &o)
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, orderDate, status).Scan(&o.OrderID, &o.CustomerID, &o.OrderDate, &o.TotalAmount, &o.Status); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, logerror(err)
 	}
-	return &o, nil
+	return res, nil
 }
 
 // OrderByOrderID retrieves a row from 'Orders' as a [Order].
@@ -269,18 +288,18 @@ func OrderByOrderID(ctx context.Context, db DB, orderID int) (*Order, error) {
 	return &o, nil
 }
 
-// OrdersByCustomerID retrieves a row from 'Orders' as a [Order].
+// OrdersByCustomerIDOrderDate retrieves a row from 'Orders' as a [Order].
 //
-// Generated from index 'customer_id'.
-func OrdersByCustomerID(ctx context.Context, db DB, customerID int) ([]*Order, error) {
+// Generated from index 'orders_customer_recent_idx'.
+func OrdersByCustomerIDOrderDate(ctx context.Context, db DB, customerID int, orderDate time.Time) ([]*Order, error) {
 	// query
 	const sqlstr = `SELECT ` +
 		`order_id, customer_id, order_date, total_amount, status ` +
 		`FROM Orders ` +
-		`WHERE customer_id = ?`
+		`WHERE customer_id = ? AND order_date = ?`
 	// run
-	logf(sqlstr, customerID)
-	rows, err := db.QueryContext(ctx, sqlstr, customerID)
+	logf(sqlstr, customerID, orderDate)
+	rows, err := db.QueryContext(ctx, sqlstr, customerID, orderDate)
 	if err != nil {
 		return nil, logerror(err)
 	}
@@ -309,3 +328,195 @@ func OrdersByCustomerID(ctx context.Context, db DB, customerID int) ([]*Order, e
 func (o *Order) Customer(ctx context.Context, db DB) (*Customer, error) {
 	return CustomerByCustomerID(ctx, db, o.CustomerID)
 }
+
+// ToProto converts the [Order] to a [user.Orders].
+func (o *Order) ToProto() (*user.Orders, error) {
+	m := &user.Orders{}
+	m.OrderId = int32(o.OrderID)
+	m.CustomerId = int32(o.CustomerID)
+	m.OrderDate = timestamppb.New(o.OrderDate)
+	m.TotalAmount = o.TotalAmount
+	m.Status = o.Status
+	return m, nil
+}
+
+// FromProto sets the [Order] from a [user.Orders].
+func (o *Order) FromProto(m *user.Orders) error {
+	o.OrderID = int(m.OrderId)
+	o.CustomerID = int(m.CustomerId)
+	if m.OrderDate != nil {
+		o.OrderDate = m.OrderDate.AsTime()
+	} else {
+		o.OrderDate = time.Time{}
+	}
+	o.TotalAmount = m.TotalAmount
+	o.Status = m.Status
+	return nil
+}
+
+// OrderRepository reads and writes [Order] rows, see
+// [NewSQLOrderRepository] and [NewMemoryOrderRepository].
+type OrderRepository interface {
+	Insert(ctx context.Context, o *Order) error
+	Update(ctx context.Context, o *Order) error
+	Upsert(ctx context.Context, o *Order) error
+	Delete(ctx context.Context, o *Order) error
+	OrdersByOrderDateStatus(ctx context.Context, orderDate time.Time, status string) ([]*Order, error)
+	OrdersByCustomerIDOrderDate(ctx context.Context, customerID int, orderDate time.Time) ([]*Order, error)
+	OrderByOrderID(ctx context.Context, orderID int) (*Order, error)
+}
+
+// NewSQLOrderRepository returns a [OrderRepository] running the
+// queries of the [Order] funcs on db.
+func NewSQLOrderRepository(db DB) OrderRepository {
+	return sqlOrderRepository{db: db}
+}
+
+type sqlOrderRepository struct {
+	db DB
+}
+
+func (repo sqlOrderRepository) Insert(ctx context.Context, o *Order) error {
+	return o.Insert(ctx, repo.db)
+}
+
+func (repo sqlOrderRepository) Update(ctx context.Context, o *Order) error {
+	return o.Update(ctx, repo.db)
+}
+
+func (repo sqlOrderRepository) Upsert(ctx context.Context, o *Order) error {
+	return o.Upsert(ctx, repo.db)
+}
+
+func (repo sqlOrderRepository) Delete(ctx context.Context, o *Order) error {
+	return o.Delete(ctx, repo.db)
+}
+
+func (repo sqlOrderRepository) OrdersByOrderDateStatus(ctx context.Context, orderDate time.Time, status string) ([]*Order, error) {
+	return OrdersByOrderDateStatus(ctx, repo.db, orderDate, status)
+}
+
+func (repo sqlOrderRepository) OrdersByCustomerIDOrderDate(ctx context.Context, customerID int, orderDate time.Time) ([]*Order, error) {
+	return OrdersByCustomerIDOrderDate(ctx, repo.db, customerID, orderDate)
+}
+
+func (repo sqlOrderRepository) OrderByOrderID(ctx context.Context, orderID int) (*Order, error) {
+	return OrderByOrderID(ctx, repo.db, orderID)
+}
+
+// NewMemoryOrderRepository returns a [OrderRepository] storing the
+// rows in memdb.
+func NewMemoryOrderRepository(memdb *MemoryDB) OrderRepository {
+	return memoryOrderRepository{memdb: memdb}
+}
+
+type memoryOrderRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryOrderRepository) Insert(ctx context.Context, o *Order) error {
+	switch {
+	case o._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case o._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("Orders", o.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	o.setMemoryRow(row)
+	o._exists = true
+	return nil
+}
+
+func (repo memoryOrderRepository) Update(ctx context.Context, o *Order) error {
+	switch {
+	case !o._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case o._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("Orders", o.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryOrderRepository) Upsert(ctx context.Context, o *Order) error {
+	switch {
+	case o._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("Orders", o.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	o._exists = true
+	return nil
+}
+
+func (repo memoryOrderRepository) Delete(ctx context.Context, o *Order) error {
+	switch {
+	case !o._exists: // doesn't exist
+		return nil
+	case o._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("Orders", o.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	o._deleted = true
+	return nil
+}
+
+func (repo memoryOrderRepository) OrdersByOrderDateStatus(ctx context.Context, orderDate time.Time, status string) ([]*Order, error) {
+	rows := repo.memdb.find("Orders", []string{"order_date", "status"}, orderDate, status)
+	var res []*Order
+	for _, row := range rows {
+		o := Order{_exists: true}
+		o.setMemoryRow(row)
+		res = append(res, &o)
+	}
+	return res, nil
+}
+
+func (repo memoryOrderRepository) OrdersByCustomerIDOrderDate(ctx context.Context, customerID int, orderDate time.Time) ([]*Order, error) {
+	rows := repo.memdb.find("Orders", []string{"customer_id", "order_date"}, customerID, orderDate)
+	var res []*Order
+	for _, row := range rows {
+		o := Order{_exists: true}
+		o.setMemoryRow(row)
+		res = append(res, &o)
+	}
+	return res, nil
+}
+
+func (repo memoryOrderRepository) OrderByOrderID(ctx context.Context, orderID int) (*Order, error) {
+	rows := repo.memdb.find("Orders", []string{"order_id"}, orderID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	o := Order{_exists: true}
+	o.setMemoryRow(rows[0])
+	return &o, nil
+}
+
+// memoryRow returns the fields of the [Order] by column.
+func (o *Order) memoryRow() memoryRow {
+	return memoryRow{
+		"order_id":     o.OrderID,
+		"customer_id":  o.CustomerID,
+		"order_date":   o.OrderDate,
+		"total_amount": o.TotalAmount,
+		"status":       o.Status,
+	}
+}
+
+// setMemoryRow sets the fields of the [Order] from a row.
+func (o *Order) setMemoryRow(row memoryRow) {
+	o.OrderID = row["order_id"].(int)
+	o.CustomerID = row["customer_id"].(int)
+	o.OrderDate = row["order_date"].(time.Time)
+	o.TotalAmount = row["total_amount"].(float64)
+	o.Status = row["status"].(string)
+}
@@ -4,10 +4,15 @@ package generated_models
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"strings"
 	"time"
+
+	"github.com/imran31415/proto-db-translator/user"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // OrderDetail represents a row from 'OrderDetails'.
@@ -229,10 +234,10 @@ func OrderDetailKeysetPage(ctx context.Context, db DB, column string, key interf
 	return results, lastItem, nil
 }
 
-// OrderDetailByCreatedAtOrderID retrieves a row from 'OrderDetails' as a [OrderDetail].
+// OrderDetailsByCreatedAtOrderID retrieves a row from 'OrderDetails' as a [OrderDetail].
 //
 // Generated from index 'OrderDetails_created_at_order_id_idx'.
-func OrderDetailByCreatedAtOrderID(ctx context.Context, db DB, createdAt time.Time, orderID int) (*OrderDetail, error) {
+func OrderDetailsByCreatedAtOrderID(ctx context.Context, db DB, createdAt time.Time, orderID int) ([]*OrderDetail, error) {
 	// query
 	const sqlstr = `SELECT ` +
 		`order_id, product_id, quantity, created_at, updated_at ` +
@@ -240,13 +245,27 @@ func OrderDetailByCreatedAtOrderID(ctx context.Context, db DB, createdAt time.Ti
 		`WHERE created_at = ? AND order_id = ?`
 	// run
 	logf(sqlstr, createdAt, orderID)
-	od := OrderDetail{
-		_exists: true,
+	rows, err := db.QueryContext(ctx, sqlstr, createdAt, orderID)
+	if err != nil {
+		return nil, logerror(err)
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, createdAt, orderID).Scan(&od.OrderID, &od.ProductID, &od.Quantity, &od.CreatedAt, &od.UpdatedAt); err != nil {
+	defer rows.Close()
+	// process
+	var res []*OrderDetail
+	for rows.Next() {
+		od := OrderDetail{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&od.OrderID, &od.ProductID, &od.Quantity, &od.CreatedAt, &od.UpdatedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &od)
+	}
+	if err := rows.Err(); err != nil {
 		return nil, logerror(err)
 	}
-	return &od, nil
+	return res, nil
 }
 
 // OrderDetailByOrderIDProductID retrieves a row from 'OrderDetails' as a [OrderDetail].
@@ -269,10 +288,10 @@ func OrderDetailByOrderIDProductID(ctx context.Context, db DB, orderID, productI
 	return &od, nil
 }
 
-// OrderDetailByProductIDQuantity retrieves a row from 'OrderDetails' as a [OrderDetail].
+// OrderDetailsByProductIDQuantity retrieves a row from 'OrderDetails' as a [OrderDetail].
 //
 // Generated from index 'OrderDetails_product_id_quantity_idx'.
-func OrderDetailByProductIDQuantity(ctx context.Context, db DB, productID, quantity int) (*OrderDetail, error) {
+func OrderDetailsByProductIDQuantity(ctx context.Context, db DB, productID, quantity int) ([]*OrderDetail, error) {
 	// query
 	const sqlstr = `SELECT ` +
 		`order_id, product_id, quantity, created_at, updated_at ` +
@@ -280,11 +299,221 @@ func OrderDetailByProductIDQuantity(ctx context.Context, db DB, productID, quant
 		`WHERE product_id = ? AND quantity = ?`
 	// run
 	logf(sqlstr, productID, quantity)
-	od := OrderDetail{
-		_exists: true,
+	rows, err := db.QueryContext(ctx, sqlstr, productID, quantity)
+	if err != nil {
+		return nil, logerror(err)
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, productID, quantity).Scan(&od.OrderID, &od.ProductID, &od.Quantity, &od.CreatedAt, &od.UpdatedAt); err != nil {
+	defer rows.Close()
+	// process
+	var res []*OrderDetail
+	for rows.Next() {
+		od := OrderDetail{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&od.OrderID, &od.ProductID, &od.Quantity, &od.CreatedAt, &od.UpdatedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &od)
+	}
+	if err := rows.Err(); err != nil {
 		return nil, logerror(err)
 	}
+	return res, nil
+}
+
+// ToProto converts the [OrderDetail] to a [user.OrderDetails].
+func (od *OrderDetail) ToProto() (*user.OrderDetails, error) {
+	m := &user.OrderDetails{}
+	m.OrderId = int32(od.OrderID)
+	m.ProductId = int32(od.ProductID)
+	m.Quantity = int32(od.Quantity)
+	m.CreatedAt = timestamppb.New(od.CreatedAt)
+	m.UpdatedAt = timestamppb.New(od.UpdatedAt)
+	return m, nil
+}
+
+// FromProto sets the [OrderDetail] from a [user.OrderDetails].
+func (od *OrderDetail) FromProto(m *user.OrderDetails) error {
+	od.OrderID = int(m.OrderId)
+	od.ProductID = int(m.ProductId)
+	od.Quantity = int(m.Quantity)
+	if m.CreatedAt != nil {
+		od.CreatedAt = m.CreatedAt.AsTime()
+	} else {
+		od.CreatedAt = time.Time{}
+	}
+	if m.UpdatedAt != nil {
+		od.UpdatedAt = m.UpdatedAt.AsTime()
+	} else {
+		od.UpdatedAt = time.Time{}
+	}
+	return nil
+}
+
+// OrderDetailRepository reads and writes [OrderDetail] rows, see
+// [NewSQLOrderDetailRepository] and [NewMemoryOrderDetailRepository].
+type OrderDetailRepository interface {
+	Insert(ctx context.Context, od *OrderDetail) error
+	Update(ctx context.Context, od *OrderDetail) error
+	Upsert(ctx context.Context, od *OrderDetail) error
+	Delete(ctx context.Context, od *OrderDetail) error
+	OrderDetailsByCreatedAtOrderID(ctx context.Context, createdAt time.Time, orderID int) ([]*OrderDetail, error)
+	OrderDetailsByProductIDQuantity(ctx context.Context, productID, quantity int) ([]*OrderDetail, error)
+	OrderDetailByOrderIDProductID(ctx context.Context, orderID, productID int) (*OrderDetail, error)
+}
+
+// NewSQLOrderDetailRepository returns a [OrderDetailRepository] running the
+// queries of the [OrderDetail] funcs on db.
+func NewSQLOrderDetailRepository(db DB) OrderDetailRepository {
+	return sqlOrderDetailRepository{db: db}
+}
+
+type sqlOrderDetailRepository struct {
+	db DB
+}
+
+func (repo sqlOrderDetailRepository) Insert(ctx context.Context, od *OrderDetail) error {
+	return od.Insert(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailRepository) Update(ctx context.Context, od *OrderDetail) error {
+	return od.Update(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailRepository) Upsert(ctx context.Context, od *OrderDetail) error {
+	return od.Upsert(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailRepository) Delete(ctx context.Context, od *OrderDetail) error {
+	return od.Delete(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailRepository) OrderDetailsByCreatedAtOrderID(ctx context.Context, createdAt time.Time, orderID int) ([]*OrderDetail, error) {
+	return OrderDetailsByCreatedAtOrderID(ctx, repo.db, createdAt, orderID)
+}
+
+func (repo sqlOrderDetailRepository) OrderDetailsByProductIDQuantity(ctx context.Context, productID, quantity int) ([]*OrderDetail, error) {
+	return OrderDetailsByProductIDQuantity(ctx, repo.db, productID, quantity)
+}
+
+func (repo sqlOrderDetailRepository) OrderDetailByOrderIDProductID(ctx context.Context, orderID, productID int) (*OrderDetail, error) {
+	return OrderDetailByOrderIDProductID(ctx, repo.db, orderID, productID)
+}
+
+// NewMemoryOrderDetailRepository returns a [OrderDetailRepository] storing the
+// rows in memdb.
+func NewMemoryOrderDetailRepository(memdb *MemoryDB) OrderDetailRepository {
+	return memoryOrderDetailRepository{memdb: memdb}
+}
+
+type memoryOrderDetailRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryOrderDetailRepository) Insert(ctx context.Context, od *OrderDetail) error {
+	switch {
+	case od._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case od._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("OrderDetails", od.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	od.setMemoryRow(row)
+	od._exists = true
+	return nil
+}
+
+func (repo memoryOrderDetailRepository) Update(ctx context.Context, od *OrderDetail) error {
+	switch {
+	case !od._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case od._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("OrderDetails", od.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryOrderDetailRepository) Upsert(ctx context.Context, od *OrderDetail) error {
+	switch {
+	case od._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("OrderDetails", od.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	od._exists = true
+	return nil
+}
+
+func (repo memoryOrderDetailRepository) Delete(ctx context.Context, od *OrderDetail) error {
+	switch {
+	case !od._exists: // doesn't exist
+		return nil
+	case od._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("OrderDetails", od.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	od._deleted = true
+	return nil
+}
+
+func (repo memoryOrderDetailRepository) OrderDetailsByCreatedAtOrderID(ctx context.Context, createdAt time.Time, orderID int) ([]*OrderDetail, error) {
+	rows := repo.memdb.find("OrderDetails", []string{"created_at", "order_id"}, createdAt, orderID)
+	var res []*OrderDetail
+	for _, row := range rows {
+		od := OrderDetail{_exists: true}
+		od.setMemoryRow(row)
+		res = append(res, &od)
+	}
+	return res, nil
+}
+
+func (repo memoryOrderDetailRepository) OrderDetailsByProductIDQuantity(ctx context.Context, productID, quantity int) ([]*OrderDetail, error) {
+	rows := repo.memdb.find("OrderDetails", []string{"product_id", "quantity"}, productID, quantity)
+	var res []*OrderDetail
+	for _, row := range rows {
+		od := OrderDetail{_exists: true}
+		od.setMemoryRow(row)
+		res = append(res, &od)
+	}
+	return res, nil
+}
+
+func (repo memoryOrderDetailRepository) OrderDetailByOrderIDProductID(ctx context.Context, orderID, productID int) (*OrderDetail, error) {
+	rows := repo.memdb.find("OrderDetails", []string{"order_id", "product_id"}, orderID, productID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	od := OrderDetail{_exists: true}
+	od.setMemoryRow(rows[0])
 	return &od, nil
 }
+
+// memoryRow returns the fields of the [OrderDetail] by column.
+func (od *OrderDetail) memoryRow() memoryRow {
+	return memoryRow{
+		"order_id":   od.OrderID,
+		"product_id": od.ProductID,
+		"quantity":   od.Quantity,
+		"created_at": od.CreatedAt,
+		"updated_at": od.UpdatedAt,
+	}
+}
+
+// setMemoryRow sets the fields of the [OrderDetail] from a row.
+func (od *OrderDetail) setMemoryRow(row memoryRow) {
+	od.OrderID = row["order_id"].(int)
+	od.ProductID = row["product_id"].(int)
+	od.Quantity = row["quantity"].(int)
+	od.CreatedAt = row["created_at"].(time.Time)
+	od.UpdatedAt = row["updated_at"].(time.Time)
+}
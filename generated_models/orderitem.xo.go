@@ -4,9 +4,12 @@ package generated_models
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"strings"
+
+	"github.com/imran31415/proto-db-translator/user"
 )
 
 // OrderItem represents a row from 'OrderItems'.
@@ -15,7 +18,7 @@ type OrderItem struct {
 	OrderID      int     `json:"order_id"`       // order_id
 	ProductID    int     `json:"product_id"`     // product_id
 	Quantity     int     `json:"quantity"`       // quantity
-	PricePerUnit float32 `json:"price_per_unit"` // price_per_unit
+	PricePerUnit float64 `json:"price_per_unit"` // price_per_unit
 	// xo fields
 	_exists, _deleted bool
 }
@@ -322,3 +325,190 @@ func (oi *OrderItem) Order(ctx context.Context, db DB) (*Order, error) {
 func (oi *OrderItem) Product(ctx context.Context, db DB) (*Product, error) {
 	return ProductByProductID(ctx, db, oi.ProductID)
 }
+
+// ToProto converts the [OrderItem] to a [user.OrderItems].
+func (oi *OrderItem) ToProto() (*user.OrderItems, error) {
+	m := &user.OrderItems{}
+	m.OrderItemId = int32(oi.OrderItemID)
+	m.OrderId = int32(oi.OrderID)
+	m.ProductId = int32(oi.ProductID)
+	m.Quantity = int32(oi.Quantity)
+	m.PricePerUnit = oi.PricePerUnit
+	return m, nil
+}
+
+// FromProto sets the [OrderItem] from a [user.OrderItems].
+func (oi *OrderItem) FromProto(m *user.OrderItems) error {
+	oi.OrderItemID = int(m.OrderItemId)
+	oi.OrderID = int(m.OrderId)
+	oi.ProductID = int(m.ProductId)
+	oi.Quantity = int(m.Quantity)
+	oi.PricePerUnit = m.PricePerUnit
+	return nil
+}
+
+// OrderItemRepository reads and writes [OrderItem] rows, see
+// [NewSQLOrderItemRepository] and [NewMemoryOrderItemRepository].
+type OrderItemRepository interface {
+	Insert(ctx context.Context, oi *OrderItem) error
+	Update(ctx context.Context, oi *OrderItem) error
+	Upsert(ctx context.Context, oi *OrderItem) error
+	Delete(ctx context.Context, oi *OrderItem) error
+	OrderItemByOrderIDProductID(ctx context.Context, orderID, productID int) (*OrderItem, error)
+	OrderItemsByProductID(ctx context.Context, productID int) ([]*OrderItem, error)
+	OrderItemByOrderItemID(ctx context.Context, orderItemID int) (*OrderItem, error)
+}
+
+// NewSQLOrderItemRepository returns a [OrderItemRepository] running the
+// queries of the [OrderItem] funcs on db.
+func NewSQLOrderItemRepository(db DB) OrderItemRepository {
+	return sqlOrderItemRepository{db: db}
+}
+
+type sqlOrderItemRepository struct {
+	db DB
+}
+
+func (repo sqlOrderItemRepository) Insert(ctx context.Context, oi *OrderItem) error {
+	return oi.Insert(ctx, repo.db)
+}
+
+func (repo sqlOrderItemRepository) Update(ctx context.Context, oi *OrderItem) error {
+	return oi.Update(ctx, repo.db)
+}
+
+func (repo sqlOrderItemRepository) Upsert(ctx context.Context, oi *OrderItem) error {
+	return oi.Upsert(ctx, repo.db)
+}
+
+func (repo sqlOrderItemRepository) Delete(ctx context.Context, oi *OrderItem) error {
+	return oi.Delete(ctx, repo.db)
+}
+
+func (repo sqlOrderItemRepository) OrderItemByOrderIDProductID(ctx context.Context, orderID, productID int) (*OrderItem, error) {
+	return OrderItemByOrderIDProductID(ctx, repo.db, orderID, productID)
+}
+
+func (repo sqlOrderItemRepository) OrderItemsByProductID(ctx context.Context, productID int) ([]*OrderItem, error) {
+	return OrderItemsByProductID(ctx, repo.db, productID)
+}
+
+func (repo sqlOrderItemRepository) OrderItemByOrderItemID(ctx context.Context, orderItemID int) (*OrderItem, error) {
+	return OrderItemByOrderItemID(ctx, repo.db, orderItemID)
+}
+
+// NewMemoryOrderItemRepository returns a [OrderItemRepository] storing the
+// rows in memdb.
+func NewMemoryOrderItemRepository(memdb *MemoryDB) OrderItemRepository {
+	return memoryOrderItemRepository{memdb: memdb}
+}
+
+type memoryOrderItemRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryOrderItemRepository) Insert(ctx context.Context, oi *OrderItem) error {
+	switch {
+	case oi._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case oi._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("OrderItems", oi.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	oi.setMemoryRow(row)
+	oi._exists = true
+	return nil
+}
+
+func (repo memoryOrderItemRepository) Update(ctx context.Context, oi *OrderItem) error {
+	switch {
+	case !oi._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case oi._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("OrderItems", oi.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryOrderItemRepository) Upsert(ctx context.Context, oi *OrderItem) error {
+	switch {
+	case oi._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("OrderItems", oi.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	oi._exists = true
+	return nil
+}
+
+func (repo memoryOrderItemRepository) Delete(ctx context.Context, oi *OrderItem) error {
+	switch {
+	case !oi._exists: // doesn't exist
+		return nil
+	case oi._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("OrderItems", oi.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	oi._deleted = true
+	return nil
+}
+
+func (repo memoryOrderItemRepository) OrderItemByOrderIDProductID(ctx context.Context, orderID, productID int) (*OrderItem, error) {
+	rows := repo.memdb.find("OrderItems", []string{"order_id", "product_id"}, orderID, productID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	oi := OrderItem{_exists: true}
+	oi.setMemoryRow(rows[0])
+	return &oi, nil
+}
+
+func (repo memoryOrderItemRepository) OrderItemsByProductID(ctx context.Context, productID int) ([]*OrderItem, error) {
+	rows := repo.memdb.find("OrderItems", []string{"product_id"}, productID)
+	var res []*OrderItem
+	for _, row := range rows {
+		oi := OrderItem{_exists: true}
+		oi.setMemoryRow(row)
+		res = append(res, &oi)
+	}
+	return res, nil
+}
+
+func (repo memoryOrderItemRepository) OrderItemByOrderItemID(ctx context.Context, orderItemID int) (*OrderItem, error) {
+	rows := repo.memdb.find("OrderItems", []string{"order_item_id"}, orderItemID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	oi := OrderItem{_exists: true}
+	oi.setMemoryRow(rows[0])
+	return &oi, nil
+}
+
+// memoryRow returns the fields of the [OrderItem] by column.
+func (oi *OrderItem) memoryRow() memoryRow {
+	return memoryRow{
+		"order_item_id":  oi.OrderItemID,
+		"order_id":       oi.OrderID,
+		"product_id":     oi.ProductID,
+		"quantity":       oi.Quantity,
+		"price_per_unit": oi.PricePerUnit,
+	}
+}
+
+// setMemoryRow sets the fields of the [OrderItem] from a row.
+func (oi *OrderItem) setMemoryRow(row memoryRow) {
+	oi.OrderItemID = row["order_item_id"].(int)
+	oi.OrderID = row["order_id"].(int)
+	oi.ProductID = row["product_id"].(int)
+	oi.Quantity = row["quantity"].(int)
+	oi.PricePerUnit = row["price_per_unit"].(float64)
+}
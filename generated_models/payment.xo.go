@@ -0,0 +1,496 @@
+package generated_models
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/imran31415/proto-db-translator/user"
+)
+
+// Payment represents a row from 'Payment'.
+type Payment struct {
+	PaymentID  int            `json:"payment_id"`  // payment_id
+	OrderID    int            `json:"order_id"`    // order_id
+	MethodCase sql.NullString `json:"method_case"` // method_case
+	CardToken  sql.NullString `json:"card_token"`  // card_token
+	Iban       sql.NullString `json:"iban"`        // iban
+	VoucherID  sql.NullInt64  `json:"voucher_id"`  // voucher_id
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [Payment] exists in the database.
+func (p *Payment) Exists() bool {
+	return p._exists
+}
+
+// Deleted returns true when the [Payment] has been marked for deletion
+// from the database.
+func (p *Payment) Deleted() bool {
+	return p._deleted
+}
+
+// Insert inserts the [Payment] to the database.
+func (p *Payment) Insert(ctx context.Context, db DB) error {
+	switch {
+	case p._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case p._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// insert (manual)
+	const sqlstr = `INSERT INTO Payment (` +
+		`payment_id, order_id, method_case, card_token, iban, voucher_id` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?, ?` +
+		`)`
+	// run
+	logf(sqlstr, p.PaymentID, p.OrderID, p.MethodCase, p.CardToken, p.Iban, p.VoucherID)
+	if _, err := db.ExecContext(ctx, sqlstr, p.PaymentID, p.OrderID, p.MethodCase, p.CardToken, p.Iban, p.VoucherID); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	p._exists = true
+	return nil
+}
+
+// Update updates a [Payment] in the database.
+func (p *Payment) Update(ctx context.Context, db DB) error {
+	switch {
+	case !p._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case p._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// update with primary key
+	const sqlstr = `UPDATE Payment SET ` +
+		`order_id = ?, method_case = ?, card_token = ?, iban = ?, voucher_id = ? ` +
+		`WHERE payment_id = ?`
+	// run
+	logf(sqlstr, p.OrderID, p.MethodCase, p.CardToken, p.Iban, p.VoucherID, p.PaymentID)
+	if _, err := db.ExecContext(ctx, sqlstr, p.OrderID, p.MethodCase, p.CardToken, p.Iban, p.VoucherID, p.PaymentID); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// Save saves the [Payment] to the database.
+func (p *Payment) Save(ctx context.Context, db DB) error {
+	if p.Exists() {
+		return p.Update(ctx, db)
+	}
+	return p.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [Payment].
+func (p *Payment) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case p._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// upsert
+	const sqlstr = `INSERT INTO Payment (` +
+		`payment_id, order_id, method_case, card_token, iban, voucher_id` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?, ?` +
+		`)` +
+		` ON DUPLICATE KEY UPDATE ` +
+		`payment_id = VALUES(payment_id), order_id = VALUES(order_id), method_case = VALUES(method_case), card_token = VALUES(card_token), iban = VALUES(iban), voucher_id = VALUES(voucher_id)`
+	// run
+	logf(sqlstr, p.PaymentID, p.OrderID, p.MethodCase, p.CardToken, p.Iban, p.VoucherID)
+	if _, err := db.ExecContext(ctx, sqlstr, p.PaymentID, p.OrderID, p.MethodCase, p.CardToken, p.Iban, p.VoucherID); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	p._exists = true
+	return nil
+}
+
+// Delete deletes the [Payment] from the database.
+func (p *Payment) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !p._exists: // doesn't exist
+		return nil
+	case p._deleted: // deleted
+		return nil
+	}
+	// delete with single primary key
+	const sqlstr = `DELETE FROM Payment ` +
+		`WHERE payment_id = ?`
+	// run
+	logf(sqlstr, p.PaymentID)
+	if _, err := db.ExecContext(ctx, sqlstr, p.PaymentID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	p._deleted = true
+	return nil
+}
+
+// PaymentKeysetPage retrieves a page of [Payment] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
+func PaymentKeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*Payment, *Payment, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT * FROM Payment 
+         WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Dynamically add filters from the `filters` map to the query
+	for field, value := range filters {
+		switch v := value.(type) {
+		case []int:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		case []string:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		default:
+			// Handle NULL and NOT NULL checks
+			if value == nil {
+				query += fmt.Sprintf(" AND %s IS NULL", field)
+			} else if value == "NOT NULL" {
+				query += fmt.Sprintf(" AND %s IS NOT NULL", field)
+			} else {
+				query += fmt.Sprintf(" AND %s = ?", field)
+				args = append(args, value)
+			}
+		}
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Log the final query for debugging purposes
+	log.Printf("Executing query: %s with args: %v", query, args)
+
+	// Execute the query
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*Payment
+	var lastItem *Payment // Variable to store the last item
+
+	for rows.Next() {
+		p := Payment{
+			_exists: true,
+		}
+		if err := rows.Scan(
+			&p.PaymentID, &p.OrderID, &p.MethodCase, &p.CardToken, &p.Iban, &p.VoucherID,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &p)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// PaymentByPaymentID retrieves a row from 'Payment' as a [Payment].
+//
+// Generated from index 'Payment_payment_id_pkey'.
+func PaymentByPaymentID(ctx context.Context, db DB, paymentID int) (*Payment, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`payment_id, order_id, method_case, card_token, iban, voucher_id ` +
+		`FROM Payment ` +
+		`WHERE payment_id = ?`
+	// run
+	logf(sqlstr, paymentID)
+	p := Payment{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, paymentID).Scan(&p.PaymentID, &p.OrderID, &p.MethodCase, &p.CardToken, &p.Iban, &p.VoucherID); err != nil {
+		return nil, logerror(err)
+	}
+	return &p, nil
+}
+
+// PaymentByOrderID retrieves a row from 'Payment' as a [Payment].
+//
+// Generated from index 'order_id'.
+func PaymentByOrderID(ctx context.Context, db DB, orderID int) ([]*Payment, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`payment_id, order_id, method_case, card_token, iban, voucher_id ` +
+		`FROM Payment ` +
+		`WHERE order_id = ?`
+	// run
+	logf(sqlstr, orderID)
+	rows, err := db.QueryContext(ctx, sqlstr, orderID)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Payment
+	for rows.Next() {
+		p := Payment{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&p.PaymentID, &p.OrderID, &p.MethodCase, &p.CardToken, &p.Iban, &p.VoucherID); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// Order returns the Order associated with the [Payment]'s (OrderID).
+//
+// Generated from foreign key 'payment_ibfk_1'.
+func (p *Payment) Order(ctx context.Context, db DB) (*Order, error) {
+	return OrderByOrderID(ctx, db, p.OrderID)
+}
+
+// ToProto converts the [Payment] to a [user.Payment].
+func (p *Payment) ToProto() (*user.Payment, error) {
+	m := &user.Payment{}
+	m.PaymentId = int32(p.PaymentID)
+	m.OrderId = int32(p.OrderID)
+	if p.MethodCase.String == "card_token" && p.CardToken.Valid {
+		m.Method = &user.Payment_CardToken{CardToken: p.CardToken.String}
+	}
+	if p.MethodCase.String == "iban" && p.Iban.Valid {
+		m.Method = &user.Payment_Iban{Iban: p.Iban.String}
+	}
+	if p.MethodCase.String == "voucher_id" && p.VoucherID.Valid {
+		m.Method = &user.Payment_VoucherId{VoucherId: int32(p.VoucherID.Int64)}
+	}
+	return m, nil
+}
+
+// FromProto sets the [Payment] from a [user.Payment].
+func (p *Payment) FromProto(m *user.Payment) error {
+	p.PaymentID = int(m.PaymentId)
+	p.OrderID = int(m.OrderId)
+	switch m.Method.(type) {
+	case *user.Payment_CardToken:
+		p.MethodCase = sql.NullString{String: "card_token", Valid: true}
+	case *user.Payment_Iban:
+		p.MethodCase = sql.NullString{String: "iban", Valid: true}
+	case *user.Payment_VoucherId:
+		p.MethodCase = sql.NullString{String: "voucher_id", Valid: true}
+	default:
+		p.MethodCase = sql.NullString{}
+	}
+	if variant, ok := m.Method.(*user.Payment_CardToken); ok {
+		p.CardToken = sql.NullString{String: variant.CardToken, Valid: true}
+	} else {
+		p.CardToken = sql.NullString{}
+	}
+	if variant, ok := m.Method.(*user.Payment_Iban); ok {
+		p.Iban = sql.NullString{String: variant.Iban, Valid: true}
+	} else {
+		p.Iban = sql.NullString{}
+	}
+	if variant, ok := m.Method.(*user.Payment_VoucherId); ok {
+		p.VoucherID = sql.NullInt64{Int64: int64(variant.VoucherId), Valid: true}
+	} else {
+		p.VoucherID = sql.NullInt64{}
+	}
+	return nil
+}
+
+// PaymentRepository reads and writes [Payment] rows, see
+// [NewSQLPaymentRepository] and [NewMemoryPaymentRepository].
+type PaymentRepository interface {
+	Insert(ctx context.Context, p *Payment) error
+	Update(ctx context.Context, p *Payment) error
+	Upsert(ctx context.Context, p *Payment) error
+	Delete(ctx context.Context, p *Payment) error
+	PaymentByOrderID(ctx context.Context, orderID int) ([]*Payment, error)
+	PaymentByPaymentID(ctx context.Context, paymentID int) (*Payment, error)
+}
+
+// NewSQLPaymentRepository returns a [PaymentRepository] running the
+// queries of the [Payment] funcs on db.
+func NewSQLPaymentRepository(db DB) PaymentRepository {
+	return sqlPaymentRepository{db: db}
+}
+
+type sqlPaymentRepository struct {
+	db DB
+}
+
+func (repo sqlPaymentRepository) Insert(ctx context.Context, p *Payment) error {
+	return p.Insert(ctx, repo.db)
+}
+
+func (repo sqlPaymentRepository) Update(ctx context.Context, p *Payment) error {
+	return p.Update(ctx, repo.db)
+}
+
+func (repo sqlPaymentRepository) Upsert(ctx context.Context, p *Payment) error {
+	return p.Upsert(ctx, repo.db)
+}
+
+func (repo sqlPaymentRepository) Delete(ctx context.Context, p *Payment) error {
+	return p.Delete(ctx, repo.db)
+}
+
+func (repo sqlPaymentRepository) PaymentByOrderID(ctx context.Context, orderID int) ([]*Payment, error) {
+	return PaymentByOrderID(ctx, repo.db, orderID)
+}
+
+func (repo sqlPaymentRepository) PaymentByPaymentID(ctx context.Context, paymentID int) (*Payment, error) {
+	return PaymentByPaymentID(ctx, repo.db, paymentID)
+}
+
+// NewMemoryPaymentRepository returns a [PaymentRepository] storing the
+// rows in memdb.
+func NewMemoryPaymentRepository(memdb *MemoryDB) PaymentRepository {
+	return memoryPaymentRepository{memdb: memdb}
+}
+
+type memoryPaymentRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryPaymentRepository) Insert(ctx context.Context, p *Payment) error {
+	switch {
+	case p._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case p._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("Payment", p.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	p.setMemoryRow(row)
+	p._exists = true
+	return nil
+}
+
+func (repo memoryPaymentRepository) Update(ctx context.Context, p *Payment) error {
+	switch {
+	case !p._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case p._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("Payment", p.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryPaymentRepository) Upsert(ctx context.Context, p *Payment) error {
+	switch {
+	case p._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("Payment", p.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	p._exists = true
+	return nil
+}
+
+func (repo memoryPaymentRepository) Delete(ctx context.Context, p *Payment) error {
+	switch {
+	case !p._exists: // doesn't exist
+		return nil
+	case p._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("Payment", p.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	p._deleted = true
+	return nil
+}
+
+func (repo memoryPaymentRepository) PaymentByOrderID(ctx context.Context, orderID int) ([]*Payment, error) {
+	rows := repo.memdb.find("Payment", []string{"order_id"}, orderID)
+	var res []*Payment
+	for _, row := range rows {
+		p := Payment{_exists: true}
+		p.setMemoryRow(row)
+		res = append(res, &p)
+	}
+	return res, nil
+}
+
+func (repo memoryPaymentRepository) PaymentByPaymentID(ctx context.Context, paymentID int) (*Payment, error) {
+	rows := repo.memdb.find("Payment", []string{"payment_id"}, paymentID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	p := Payment{_exists: true}
+	p.setMemoryRow(rows[0])
+	return &p, nil
+}
+
+// memoryRow returns the fields of the [Payment] by column.
+func (p *Payment) memoryRow() memoryRow {
+	return memoryRow{
+		"payment_id":  p.PaymentID,
+		"order_id":    p.OrderID,
+		"method_case": p.MethodCase,
+		"card_token":  p.CardToken,
+		"iban":        p.Iban,
+		"voucher_id":  p.VoucherID,
+	}
+}
+
+// setMemoryRow sets the fields of the [Payment] from a row.
+func (p *Payment) setMemoryRow(row memoryRow) {
+	p.PaymentID = row["payment_id"].(int)
+	p.OrderID = row["order_id"].(int)
+	p.MethodCase = row["method_case"].(sql.NullString)
+	p.CardToken = row["card_token"].(sql.NullString)
+	p.Iban = row["iban"].(sql.NullString)
+	p.VoucherID = row["voucher_id"].(sql.NullInt64)
+}
@@ -9,6 +9,10 @@ import (
 	"log"
 	"strings"
 	"time"
+
+	"github.com/imran31415/proto-db-translator/user"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Product represents a row from 'Product'.
@@ -16,8 +20,8 @@ type Product struct {
 	ProductID     int            `json:"product_id"`     // product_id
 	Name          string         `json:"name"`           // name
 	Description   sql.NullString `json:"description"`    // description
-	Price         float32        `json:"price"`          // price
-	StockQuantity int            `json:"stock_quantity"` // stock_quantity
+	Price         float64        `json:"price"`          // price
+	StockQuantity uint           `json:"stock_quantity"` // stock_quantity
 	CreatedAt     time.Time      `json:"created_at"`     // created_at
 	UpdatedAt     time.Time      `json:"updated_at"`     // updated_at
 	// xo fields
@@ -239,6 +243,40 @@ func ProductKeysetPage(ctx context.Context, db DB, column string, key interface{
 	return results, lastItem, nil
 }
 
+// ProductByDescription retrieves a row from 'Product' as a [Product].
+//
+// Generated from index 'Product_description_idx'.
+func ProductByDescription(ctx context.Context, db DB, description sql.NullString) ([]*Product, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`product_id, name, description, price, stock_quantity, created_at, updated_at ` +
+		`FROM Product ` +
+		`WHERE description = ?`
+	// run
+	logf(sqlstr, description)
+	rows, err := db.QueryContext(ctx, sqlstr, description)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Product
+	for rows.Next() {
+		p := Product{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&p.ProductID, &p.Name, &p.Description, &p.Price, &p.StockQuantity, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
 // ProductByProductID retrieves a row from 'Product' as a [Product].
 //
 // Generated from index 'Product_product_id_pkey'.
@@ -278,3 +316,212 @@ func ProductByName(ctx context.Context, db DB, name string) (*Product, error) {
 	}
 	return &p, nil
 }
+
+// ToProto converts the [Product] to a [user.Product].
+func (p *Product) ToProto() (*user.Product, error) {
+	m := &user.Product{}
+	m.ProductId = int32(p.ProductID)
+	m.Name = p.Name
+	if p.Description.Valid {
+		m.Description = p.Description.String
+	}
+	m.Price = float32(p.Price)
+	m.StockQuantity = int32(p.StockQuantity)
+	m.CreatedAt = timestamppb.New(p.CreatedAt)
+	m.UpdatedAt = timestamppb.New(p.UpdatedAt)
+	return m, nil
+}
+
+// FromProto sets the [Product] from a [user.Product].
+func (p *Product) FromProto(m *user.Product) error {
+	p.ProductID = int(m.ProductId)
+	p.Name = m.Name
+	if m.Description != "" {
+		p.Description = sql.NullString{String: m.Description, Valid: true}
+	} else {
+		p.Description = sql.NullString{}
+	}
+	p.Price = float64(m.Price)
+	p.StockQuantity = uint(m.StockQuantity)
+	if m.CreatedAt != nil {
+		p.CreatedAt = m.CreatedAt.AsTime()
+	} else {
+		p.CreatedAt = time.Time{}
+	}
+	if m.UpdatedAt != nil {
+		p.UpdatedAt = m.UpdatedAt.AsTime()
+	} else {
+		p.UpdatedAt = time.Time{}
+	}
+	return nil
+}
+
+// ProductRepository reads and writes [Product] rows, see
+// [NewSQLProductRepository] and [NewMemoryProductRepository].
+type ProductRepository interface {
+	Insert(ctx context.Context, p *Product) error
+	Update(ctx context.Context, p *Product) error
+	Upsert(ctx context.Context, p *Product) error
+	Delete(ctx context.Context, p *Product) error
+	ProductByDescription(ctx context.Context, description sql.NullString) ([]*Product, error)
+	ProductByName(ctx context.Context, name string) (*Product, error)
+	ProductByProductID(ctx context.Context, productID int) (*Product, error)
+}
+
+// NewSQLProductRepository returns a [ProductRepository] running the
+// queries of the [Product] funcs on db.
+func NewSQLProductRepository(db DB) ProductRepository {
+	return sqlProductRepository{db: db}
+}
+
+type sqlProductRepository struct {
+	db DB
+}
+
+func (repo sqlProductRepository) Insert(ctx context.Context, p *Product) error {
+	return p.Insert(ctx, repo.db)
+}
+
+func (repo sqlProductRepository) Update(ctx context.Context, p *Product) error {
+	return p.Update(ctx, repo.db)
+}
+
+func (repo sqlProductRepository) Upsert(ctx context.Context, p *Product) error {
+	return p.Upsert(ctx, repo.db)
+}
+
+func (repo sqlProductRepository) Delete(ctx context.Context, p *Product) error {
+	return p.Delete(ctx, repo.db)
+}
+
+func (repo sqlProductRepository) ProductByDescription(ctx context.Context, description sql.NullString) ([]*Product, error) {
+	return ProductByDescription(ctx, repo.db, description)
+}
+
+func (repo sqlProductRepository) ProductByName(ctx context.Context, name string) (*Product, error) {
+	return ProductByName(ctx, repo.db, name)
+}
+
+func (repo sqlProductRepository) ProductByProductID(ctx context.Context, productID int) (*Product, error) {
+	return ProductByProductID(ctx, repo.db, productID)
+}
+
+// NewMemoryProductRepository returns a [ProductRepository] storing the
+// rows in memdb.
+func NewMemoryProductRepository(memdb *MemoryDB) ProductRepository {
+	return memoryProductRepository{memdb: memdb}
+}
+
+type memoryProductRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryProductRepository) Insert(ctx context.Context, p *Product) error {
+	switch {
+	case p._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case p._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("Product", p.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	p.setMemoryRow(row)
+	p._exists = true
+	return nil
+}
+
+func (repo memoryProductRepository) Update(ctx context.Context, p *Product) error {
+	switch {
+	case !p._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case p._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("Product", p.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryProductRepository) Upsert(ctx context.Context, p *Product) error {
+	switch {
+	case p._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("Product", p.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	p._exists = true
+	return nil
+}
+
+func (repo memoryProductRepository) Delete(ctx context.Context, p *Product) error {
+	switch {
+	case !p._exists: // doesn't exist
+		return nil
+	case p._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("Product", p.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	p._deleted = true
+	return nil
+}
+
+func (repo memoryProductRepository) ProductByDescription(ctx context.Context, description sql.NullString) ([]*Product, error) {
+	rows := repo.memdb.find("Product", []string{"description"}, description)
+	var res []*Product
+	for _, row := range rows {
+		p := Product{_exists: true}
+		p.setMemoryRow(row)
+		res = append(res, &p)
+	}
+	return res, nil
+}
+
+func (repo memoryProductRepository) ProductByName(ctx context.Context, name string) (*Product, error) {
+	rows := repo.memdb.find("Product", []string{"name"}, name)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	p := Product{_exists: true}
+	p.setMemoryRow(rows[0])
+	return &p, nil
+}
+
+func (repo memoryProductRepository) ProductByProductID(ctx context.Context, productID int) (*Product, error) {
+	rows := repo.memdb.find("Product", []string{"product_id"}, productID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	p := Product{_exists: true}
+	p.setMemoryRow(rows[0])
+	return &p, nil
+}
+
+// memoryRow returns the fields of the [Product] by column.
+func (p *Product) memoryRow() memoryRow {
+	return memoryRow{
+		"product_id":     p.ProductID,
+		"name":           p.Name,
+		"description":    p.Description,
+		"price":          p.Price,
+		"stock_quantity": p.StockQuantity,
+		"created_at":     p.CreatedAt,
+		"updated_at":     p.UpdatedAt,
+	}
+}
+
+// setMemoryRow sets the fields of the [Product] from a row.
+func (p *Product) setMemoryRow(row memoryRow) {
+	p.ProductID = row["product_id"].(int)
+	p.Name = row["name"].(string)
+	p.Description = row["description"].(sql.NullString)
+	p.Price = row["price"].(float64)
+	p.StockQuantity = row["stock_quantity"].(uint)
+	p.CreatedAt = row["created_at"].(time.Time)
+	p.UpdatedAt = row["updated_at"].(time.Time)
+}
@@ -9,6 +9,10 @@ import (
 	"log"
 	"strings"
 	"time"
+
+	"github.com/imran31415/proto-db-translator/user"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Role represents a row from 'Role'.
@@ -318,3 +322,214 @@ func RoleByRoleName(ctx context.Context, db DB, roleName string) (*Role, error)
 func (r *Role) Role(ctx context.Context, db DB) (*Role, error) {
 	return RoleByRoleID(ctx, db, int(r.ParentRoleID.Int64))
 }
+
+// ToProto converts the [Role] to a [user.Role].
+func (r *Role) ToProto() (*user.Role, error) {
+	m := &user.Role{}
+	m.RoleId = int32(r.RoleID)
+	m.RoleName = r.RoleName
+	m.CreatedAt = timestamppb.New(r.CreatedAt)
+	m.UpdatedAt = timestamppb.New(r.UpdatedAt)
+	if r.ParentRoleID.Valid {
+		m.ParentRoleId = int32(r.ParentRoleID.Int64)
+	}
+	if r.Description.Valid {
+		m.Description = r.Description.String
+	}
+	return m, nil
+}
+
+// FromProto sets the [Role] from a [user.Role].
+func (r *Role) FromProto(m *user.Role) error {
+	r.RoleID = int(m.RoleId)
+	r.RoleName = m.RoleName
+	if m.CreatedAt != nil {
+		r.CreatedAt = m.CreatedAt.AsTime()
+	} else {
+		r.CreatedAt = time.Time{}
+	}
+	if m.UpdatedAt != nil {
+		r.UpdatedAt = m.UpdatedAt.AsTime()
+	} else {
+		r.UpdatedAt = time.Time{}
+	}
+	if m.ParentRoleId != 0 {
+		r.ParentRoleID = sql.NullInt64{Int64: int64(m.ParentRoleId), Valid: true}
+	} else {
+		r.ParentRoleID = sql.NullInt64{}
+	}
+	if m.Description != "" {
+		r.Description = sql.NullString{String: m.Description, Valid: true}
+	} else {
+		r.Description = sql.NullString{}
+	}
+	return nil
+}
+
+// RoleRepository reads and writes [Role] rows, see
+// [NewSQLRoleRepository] and [NewMemoryRoleRepository].
+type RoleRepository interface {
+	Insert(ctx context.Context, r *Role) error
+	Update(ctx context.Context, r *Role) error
+	Upsert(ctx context.Context, r *Role) error
+	Delete(ctx context.Context, r *Role) error
+	RoleByParentRoleID(ctx context.Context, parentRoleID sql.NullInt64) ([]*Role, error)
+	RoleByRoleName(ctx context.Context, roleName string) (*Role, error)
+	RoleByRoleID(ctx context.Context, roleID int) (*Role, error)
+}
+
+// NewSQLRoleRepository returns a [RoleRepository] running the
+// queries of the [Role] funcs on db.
+func NewSQLRoleRepository(db DB) RoleRepository {
+	return sqlRoleRepository{db: db}
+}
+
+type sqlRoleRepository struct {
+	db DB
+}
+
+func (repo sqlRoleRepository) Insert(ctx context.Context, r *Role) error {
+	return r.Insert(ctx, repo.db)
+}
+
+func (repo sqlRoleRepository) Update(ctx context.Context, r *Role) error {
+	return r.Update(ctx, repo.db)
+}
+
+func (repo sqlRoleRepository) Upsert(ctx context.Context, r *Role) error {
+	return r.Upsert(ctx, repo.db)
+}
+
+func (repo sqlRoleRepository) Delete(ctx context.Context, r *Role) error {
+	return r.Delete(ctx, repo.db)
+}
+
+func (repo sqlRoleRepository) RoleByParentRoleID(ctx context.Context, parentRoleID sql.NullInt64) ([]*Role, error) {
+	return RoleByParentRoleID(ctx, repo.db, parentRoleID)
+}
+
+func (repo sqlRoleRepository) RoleByRoleName(ctx context.Context, roleName string) (*Role, error) {
+	return RoleByRoleName(ctx, repo.db, roleName)
+}
+
+func (repo sqlRoleRepository) RoleByRoleID(ctx context.Context, roleID int) (*Role, error) {
+	return RoleByRoleID(ctx, repo.db, roleID)
+}
+
+// NewMemoryRoleRepository returns a [RoleRepository] storing the
+// rows in memdb.
+func NewMemoryRoleRepository(memdb *MemoryDB) RoleRepository {
+	return memoryRoleRepository{memdb: memdb}
+}
+
+type memoryRoleRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryRoleRepository) Insert(ctx context.Context, r *Role) error {
+	switch {
+	case r._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case r._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("Role", r.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	r.setMemoryRow(row)
+	r._exists = true
+	return nil
+}
+
+func (repo memoryRoleRepository) Update(ctx context.Context, r *Role) error {
+	switch {
+	case !r._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case r._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("Role", r.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryRoleRepository) Upsert(ctx context.Context, r *Role) error {
+	switch {
+	case r._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("Role", r.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	r._exists = true
+	return nil
+}
+
+func (repo memoryRoleRepository) Delete(ctx context.Context, r *Role) error {
+	switch {
+	case !r._exists: // doesn't exist
+		return nil
+	case r._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("Role", r.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	r._deleted = true
+	return nil
+}
+
+func (repo memoryRoleRepository) RoleByParentRoleID(ctx context.Context, parentRoleID sql.NullInt64) ([]*Role, error) {
+	rows := repo.memdb.find("Role", []string{"parent_role_id"}, parentRoleID)
+	var res []*Role
+	for _, row := range rows {
+		r := Role{_exists: true}
+		r.setMemoryRow(row)
+		res = append(res, &r)
+	}
+	return res, nil
+}
+
+func (repo memoryRoleRepository) RoleByRoleName(ctx context.Context, roleName string) (*Role, error) {
+	rows := repo.memdb.find("Role", []string{"role_name"}, roleName)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	r := Role{_exists: true}
+	r.setMemoryRow(rows[0])
+	return &r, nil
+}
+
+func (repo memoryRoleRepository) RoleByRoleID(ctx context.Context, roleID int) (*Role, error) {
+	rows := repo.memdb.find("Role", []string{"role_id"}, roleID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	r := Role{_exists: true}
+	r.setMemoryRow(rows[0])
+	return &r, nil
+}
+
+// memoryRow returns the fields of the [Role] by column.
+func (r *Role) memoryRow() memoryRow {
+	return memoryRow{
+		"role_id":        r.RoleID,
+		"role_name":      r.RoleName,
+		"created_at":     r.CreatedAt,
+		"updated_at":     r.UpdatedAt,
+		"parent_role_id": r.ParentRoleID,
+		"description":    r.Description,
+	}
+}
+
+// setMemoryRow sets the fields of the [Role] from a row.
+func (r *Role) setMemoryRow(row memoryRow) {
+	r.RoleID = row["role_id"].(int)
+	r.RoleName = row["role_name"].(string)
+	r.CreatedAt = row["created_at"].(time.Time)
+	r.UpdatedAt = row["updated_at"].(time.Time)
+	r.ParentRoleID = row["parent_role_id"].(sql.NullInt64)
+	r.Description = row["description"].(sql.NullString)
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"log"
 	"strings"
+
+	"github.com/imran31415/proto-db-translator/user"
 )
 
 // RoleHierarchy represents a row from 'RoleHierarchy'.
@@ -112,3 +114,18 @@ func RoleHierarchyKeysetPage(ctx context.Context, db DB, column string, key inte
 
 	return results, lastItem, nil
 }
+
+// ToProto converts the [RoleHierarchy] to a [user.RoleHierarchy].
+func (rh *RoleHierarchy) ToProto() (*user.RoleHierarchy, error) {
+	m := &user.RoleHierarchy{}
+	m.ChildRoleId = int32(rh.ChildRoleID)
+	m.ParentRoleId = int32(rh.ParentRoleID)
+	return m, nil
+}
+
+// FromProto sets the [RoleHierarchy] from a [user.RoleHierarchy].
+func (rh *RoleHierarchy) FromProto(m *user.RoleHierarchy) error {
+	rh.ChildRoleID = int(m.ChildRoleId)
+	rh.ParentRoleID = int(m.ParentRoleId)
+	return nil
+}
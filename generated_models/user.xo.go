@@ -9,6 +9,10 @@ import (
 	"log"
 	"strings"
 	"time"
+
+	"github.com/imran31415/proto-db-translator/user"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // User represents a row from 'User'.
@@ -292,3 +296,215 @@ func UserByUsername(ctx context.Context, db DB, username string) (*User, error)
 	}
 	return &u, nil
 }
+
+// ToProto converts the [User] to a [user.User].
+func (u *User) ToProto() (*user.User, error) {
+	m := &user.User{}
+	m.Id = int32(u.ID)
+	m.Username = u.Username
+	m.Email = u.Email
+	m.HashedPassword = u.HashedPassword
+	m.Is_2FaEnabled = u.Is2faEnabled
+	if u.TwoFactorSecret.Valid {
+		m.TwoFactorSecret = u.TwoFactorSecret.String
+	}
+	m.CreatedAt = timestamppb.New(u.CreatedAt)
+	m.UpdatedAt = timestamppb.New(u.UpdatedAt)
+	return m, nil
+}
+
+// FromProto sets the [User] from a [user.User].
+func (u *User) FromProto(m *user.User) error {
+	u.ID = int(m.Id)
+	u.Username = m.Username
+	u.Email = m.Email
+	u.HashedPassword = m.HashedPassword
+	u.Is2faEnabled = m.Is_2FaEnabled
+	if m.TwoFactorSecret != "" {
+		u.TwoFactorSecret = sql.NullString{String: m.TwoFactorSecret, Valid: true}
+	} else {
+		u.TwoFactorSecret = sql.NullString{}
+	}
+	if m.CreatedAt != nil {
+		u.CreatedAt = m.CreatedAt.AsTime()
+	} else {
+		u.CreatedAt = time.Time{}
+	}
+	if m.UpdatedAt != nil {
+		u.UpdatedAt = m.UpdatedAt.AsTime()
+	} else {
+		u.UpdatedAt = time.Time{}
+	}
+	return nil
+}
+
+// UserRepository reads and writes [User] rows, see
+// [NewSQLUserRepository] and [NewMemoryUserRepository].
+type UserRepository interface {
+	Insert(ctx context.Context, u *User) error
+	Update(ctx context.Context, u *User) error
+	Upsert(ctx context.Context, u *User) error
+	Delete(ctx context.Context, u *User) error
+	UserByEmail(ctx context.Context, email string) (*User, error)
+	UserByUsername(ctx context.Context, username string) (*User, error)
+	UserByID(ctx context.Context, id int) (*User, error)
+}
+
+// NewSQLUserRepository returns a [UserRepository] running the
+// queries of the [User] funcs on db.
+func NewSQLUserRepository(db DB) UserRepository {
+	return sqlUserRepository{db: db}
+}
+
+type sqlUserRepository struct {
+	db DB
+}
+
+func (repo sqlUserRepository) Insert(ctx context.Context, u *User) error {
+	return u.Insert(ctx, repo.db)
+}
+
+func (repo sqlUserRepository) Update(ctx context.Context, u *User) error {
+	return u.Update(ctx, repo.db)
+}
+
+func (repo sqlUserRepository) Upsert(ctx context.Context, u *User) error {
+	return u.Upsert(ctx, repo.db)
+}
+
+func (repo sqlUserRepository) Delete(ctx context.Context, u *User) error {
+	return u.Delete(ctx, repo.db)
+}
+
+func (repo sqlUserRepository) UserByEmail(ctx context.Context, email string) (*User, error) {
+	return UserByEmail(ctx, repo.db, email)
+}
+
+func (repo sqlUserRepository) UserByUsername(ctx context.Context, username string) (*User, error) {
+	return UserByUsername(ctx, repo.db, username)
+}
+
+func (repo sqlUserRepository) UserByID(ctx context.Context, id int) (*User, error) {
+	return UserByID(ctx, repo.db, id)
+}
+
+// NewMemoryUserRepository returns a [UserRepository] storing the
+// rows in memdb.
+func NewMemoryUserRepository(memdb *MemoryDB) UserRepository {
+	return memoryUserRepository{memdb: memdb}
+}
+
+type memoryUserRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryUserRepository) Insert(ctx context.Context, u *User) error {
+	switch {
+	case u._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case u._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("User", u.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	u.setMemoryRow(row)
+	u._exists = true
+	return nil
+}
+
+func (repo memoryUserRepository) Update(ctx context.Context, u *User) error {
+	switch {
+	case !u._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case u._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("User", u.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryUserRepository) Upsert(ctx context.Context, u *User) error {
+	switch {
+	case u._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("User", u.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	u._exists = true
+	return nil
+}
+
+func (repo memoryUserRepository) Delete(ctx context.Context, u *User) error {
+	switch {
+	case !u._exists: // doesn't exist
+		return nil
+	case u._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("User", u.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	u._deleted = true
+	return nil
+}
+
+func (repo memoryUserRepository) UserByEmail(ctx context.Context, email string) (*User, error) {
+	rows := repo.memdb.find("User", []string{"email"}, email)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	u := User{_exists: true}
+	u.setMemoryRow(rows[0])
+	return &u, nil
+}
+
+func (repo memoryUserRepository) UserByUsername(ctx context.Context, username string) (*User, error) {
+	rows := repo.memdb.find("User", []string{"username"}, username)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	u := User{_exists: true}
+	u.setMemoryRow(rows[0])
+	return &u, nil
+}
+
+func (repo memoryUserRepository) UserByID(ctx context.Context, id int) (*User, error) {
+	rows := repo.memdb.find("User", []string{"id"}, id)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	u := User{_exists: true}
+	u.setMemoryRow(rows[0])
+	return &u, nil
+}
+
+// memoryRow returns the fields of the [User] by column.
+func (u *User) memoryRow() memoryRow {
+	return memoryRow{
+		"id":                u.ID,
+		"username":          u.Username,
+		"email":             u.Email,
+		"hashed_password":   u.HashedPassword,
+		"is_2fa_enabled":    u.Is2faEnabled,
+		"two_factor_secret": u.TwoFactorSecret,
+		"created_at":        u.CreatedAt,
+		"updated_at":        u.UpdatedAt,
+	}
+}
+
+// setMemoryRow sets the fields of the [User] from a row.
+func (u *User) setMemoryRow(row memoryRow) {
+	u.ID = row["id"].(int)
+	u.Username = row["username"].(string)
+	u.Email = row["email"].(string)
+	u.HashedPassword = row["hashed_password"].(string)
+	u.Is2faEnabled = row["is_2fa_enabled"].(bool)
+	u.TwoFactorSecret = row["two_factor_secret"].(sql.NullString)
+	u.CreatedAt = row["created_at"].(time.Time)
+	u.UpdatedAt = row["updated_at"].(time.Time)
+}
@@ -0,0 +1,522 @@
+// Package generated_models_sqlite contains generated code for schema ”.
+package generated_models_sqlite
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/imran31415/proto-db-translator/user"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Customer represents a row from 'Customer'.
+type Customer struct {
+	CustomerID   int            `json:"customer_id"`   // customer_id
+	CustomerName string         `json:"customer_name"` // customer_name
+	Email        string         `json:"email"`         // email
+	Phone        sql.NullString `json:"phone"`         // phone
+	EmailLower   sql.NullString `json:"email_lower"`   // email_lower
+	CreatedAt    Time           `json:"created_at"`    // created_at
+	UpdatedAt    Time           `json:"updated_at"`    // updated_at
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [Customer] exists in the database.
+func (c *Customer) Exists() bool {
+	return c._exists
+}
+
+// Deleted returns true when the [Customer] has been marked for deletion
+// from the database.
+func (c *Customer) Deleted() bool {
+	return c._deleted
+}
+
+// Insert inserts the [Customer] to the database.
+func (c *Customer) Insert(ctx context.Context, db DB) error {
+	switch {
+	case c._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case c._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// insert (manual)
+	const sqlstr = `INSERT INTO Customer (` +
+		`customer_id, customer_name, email, phone, created_at, updated_at` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?, ?` +
+		`)`
+	// run
+	logf(sqlstr, c.CustomerID, c.CustomerName, c.Email, c.Phone, c.CreatedAt, c.UpdatedAt)
+	if _, err := db.ExecContext(ctx, sqlstr, c.CustomerID, c.CustomerName, c.Email, c.Phone, c.CreatedAt, c.UpdatedAt); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	c._exists = true
+	return nil
+}
+
+// Update updates a [Customer] in the database.
+func (c *Customer) Update(ctx context.Context, db DB) error {
+	switch {
+	case !c._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case c._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// update with primary key
+	const sqlstr = `UPDATE Customer SET ` +
+		`customer_name = ?, email = ?, phone = ?, created_at = ?, updated_at = ? ` +
+		`WHERE customer_id = ?`
+	// run
+	logf(sqlstr, c.CustomerName, c.Email, c.Phone, c.CreatedAt, c.UpdatedAt, c.CustomerID)
+	if _, err := db.ExecContext(ctx, sqlstr, c.CustomerName, c.Email, c.Phone, c.CreatedAt, c.UpdatedAt, c.CustomerID); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// Save saves the [Customer] to the database.
+func (c *Customer) Save(ctx context.Context, db DB) error {
+	if c.Exists() {
+		return c.Update(ctx, db)
+	}
+	return c.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [Customer].
+func (c *Customer) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case c._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// upsert
+	const sqlstr = `INSERT INTO Customer (` +
+		`customer_id, customer_name, email, phone, created_at, updated_at` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?, ?` +
+		`)` +
+		` ON CONFLICT (customer_id) DO ` +
+		`UPDATE SET ` +
+		`customer_name = EXCLUDED.customer_name, email = EXCLUDED.email, phone = EXCLUDED.phone, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at `
+	// run
+	logf(sqlstr, c.CustomerID, c.CustomerName, c.Email, c.Phone, c.CreatedAt, c.UpdatedAt)
+	if _, err := db.ExecContext(ctx, sqlstr, c.CustomerID, c.CustomerName, c.Email, c.Phone, c.CreatedAt, c.UpdatedAt); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	c._exists = true
+	return nil
+}
+
+// Delete deletes the [Customer] from the database.
+func (c *Customer) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !c._exists: // doesn't exist
+		return nil
+	case c._deleted: // deleted
+		return nil
+	}
+	// delete with single primary key
+	const sqlstr = `DELETE FROM Customer ` +
+		`WHERE customer_id = ?`
+	// run
+	logf(sqlstr, c.CustomerID)
+	if _, err := db.ExecContext(ctx, sqlstr, c.CustomerID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	c._deleted = true
+	return nil
+}
+
+// CustomerKeysetPage retrieves a page of [Customer] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
+func CustomerKeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*Customer, *Customer, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT * FROM Customer 
+         WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Dynamically add filters from the `filters` map to the query
+	for field, value := range filters {
+		switch v := value.(type) {
+		case []int:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		case []string:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		default:
+			// Handle NULL and NOT NULL checks
+			if value == nil {
+				query += fmt.Sprintf(" AND %s IS NULL", field)
+			} else if value == "NOT NULL" {
+				query += fmt.Sprintf(" AND %s IS NOT NULL", field)
+			} else {
+				query += fmt.Sprintf(" AND %s = ?", field)
+				args = append(args, value)
+			}
+		}
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Log the final query for debugging purposes
+	log.Printf("Executing query: %s with args: %v", query, args)
+
+	// Execute the query
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*Customer
+	var lastItem *Customer // Variable to store the last item
+
+	for rows.Next() {
+		c := Customer{
+			_exists: true,
+		}
+		if err := rows.Scan(
+			&c.CustomerID, &c.CustomerName, &c.Email, &c.Phone, &c.EmailLower, &c.CreatedAt, &c.UpdatedAt,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &c)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// CustomerByCustomerName retrieves a row from 'Customer' as a [Customer].
+//
+// Generated from index 'Customer_customer_name_idx'.
+func CustomerByCustomerName(ctx context.Context, db DB, customerName string) ([]*Customer, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`customer_id, customer_name, email, phone, email_lower, created_at, updated_at ` +
+		`FROM Customer ` +
+		`WHERE customer_name = ?`
+	// run
+	logf(sqlstr, customerName)
+	rows, err := db.QueryContext(ctx, sqlstr, customerName)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Customer
+	for rows.Next() {
+		c := Customer{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&c.CustomerID, &c.CustomerName, &c.Email, &c.Phone, &c.EmailLower, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// CustomerByCustomerID retrieves a row from 'Customer' as a [Customer].
+//
+// Generated from index 'sqlite_autoindex_Customer_1'.
+func CustomerByCustomerID(ctx context.Context, db DB, customerID int) (*Customer, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`customer_id, customer_name, email, phone, email_lower, created_at, updated_at ` +
+		`FROM Customer ` +
+		`WHERE customer_id = ?`
+	// run
+	logf(sqlstr, customerID)
+	c := Customer{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, customerID).Scan(&c.CustomerID, &c.CustomerName, &c.Email, &c.Phone, &c.EmailLower, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &c, nil
+}
+
+// CustomerByEmail retrieves a row from 'Customer' as a [Customer].
+//
+// Generated from index 'sqlite_autoindex_Customer_2'.
+func CustomerByEmail(ctx context.Context, db DB, email string) (*Customer, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`customer_id, customer_name, email, phone, email_lower, created_at, updated_at ` +
+		`FROM Customer ` +
+		`WHERE email = ?`
+	// run
+	logf(sqlstr, email)
+	c := Customer{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, email).Scan(&c.CustomerID, &c.CustomerName, &c.Email, &c.Phone, &c.EmailLower, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &c, nil
+}
+
+// ToProto converts the [Customer] to a [user.Customer].
+func (c *Customer) ToProto() (*user.Customer, error) {
+	m := &user.Customer{}
+	m.CustomerId = int32(c.CustomerID)
+	m.CustomerName = c.CustomerName
+	m.Email = c.Email
+	if c.Phone.Valid {
+		m.Phone = c.Phone.String
+	}
+	if c.EmailLower.Valid {
+		m.EmailLower = c.EmailLower.String
+	}
+	m.CreatedAt = timestamppb.New(c.CreatedAt.Time())
+	m.UpdatedAt = timestamppb.New(c.UpdatedAt.Time())
+	return m, nil
+}
+
+// FromProto sets the [Customer] from a [user.Customer].
+func (c *Customer) FromProto(m *user.Customer) error {
+	c.CustomerID = int(m.CustomerId)
+	c.CustomerName = m.CustomerName
+	c.Email = m.Email
+	if m.Phone != "" {
+		c.Phone = sql.NullString{String: m.Phone, Valid: true}
+	} else {
+		c.Phone = sql.NullString{}
+	}
+	if m.CreatedAt != nil {
+		c.CreatedAt = NewTime(m.CreatedAt.AsTime())
+	} else {
+		c.CreatedAt = Time{}
+	}
+	if m.UpdatedAt != nil {
+		c.UpdatedAt = NewTime(m.UpdatedAt.AsTime())
+	} else {
+		c.UpdatedAt = Time{}
+	}
+	return nil
+}
+
+// CustomerRepository reads and writes [Customer] rows, see
+// [NewSQLCustomerRepository] and [NewMemoryCustomerRepository].
+type CustomerRepository interface {
+	Insert(ctx context.Context, c *Customer) error
+	Update(ctx context.Context, c *Customer) error
+	Upsert(ctx context.Context, c *Customer) error
+	Delete(ctx context.Context, c *Customer) error
+	CustomerByCustomerName(ctx context.Context, customerName string) ([]*Customer, error)
+	CustomerByCustomerID(ctx context.Context, customerID int) (*Customer, error)
+	CustomerByEmail(ctx context.Context, email string) (*Customer, error)
+}
+
+// NewSQLCustomerRepository returns a [CustomerRepository] running the
+// queries of the [Customer] funcs on db.
+func NewSQLCustomerRepository(db DB) CustomerRepository {
+	return sqlCustomerRepository{db: db}
+}
+
+type sqlCustomerRepository struct {
+	db DB
+}
+
+func (repo sqlCustomerRepository) Insert(ctx context.Context, c *Customer) error {
+	return c.Insert(ctx, repo.db)
+}
+
+func (repo sqlCustomerRepository) Update(ctx context.Context, c *Customer) error {
+	return c.Update(ctx, repo.db)
+}
+
+func (repo sqlCustomerRepository) Upsert(ctx context.Context, c *Customer) error {
+	return c.Upsert(ctx, repo.db)
+}
+
+func (repo sqlCustomerRepository) Delete(ctx context.Context, c *Customer) error {
+	return c.Delete(ctx, repo.db)
+}
+
+func (repo sqlCustomerRepository) CustomerByCustomerName(ctx context.Context, customerName string) ([]*Customer, error) {
+	return CustomerByCustomerName(ctx, repo.db, customerName)
+}
+
+func (repo sqlCustomerRepository) CustomerByCustomerID(ctx context.Context, customerID int) (*Customer, error) {
+	return CustomerByCustomerID(ctx, repo.db, customerID)
+}
+
+func (repo sqlCustomerRepository) CustomerByEmail(ctx context.Context, email string) (*Customer, error) {
+	return CustomerByEmail(ctx, repo.db, email)
+}
+
+// NewMemoryCustomerRepository returns a [CustomerRepository] storing the
+// rows in memdb.
+func NewMemoryCustomerRepository(memdb *MemoryDB) CustomerRepository {
+	return memoryCustomerRepository{memdb: memdb}
+}
+
+type memoryCustomerRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryCustomerRepository) Insert(ctx context.Context, c *Customer) error {
+	switch {
+	case c._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case c._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("Customer", c.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	c.setMemoryRow(row)
+	c._exists = true
+	return nil
+}
+
+func (repo memoryCustomerRepository) Update(ctx context.Context, c *Customer) error {
+	switch {
+	case !c._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case c._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("Customer", c.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryCustomerRepository) Upsert(ctx context.Context, c *Customer) error {
+	switch {
+	case c._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("Customer", c.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	c._exists = true
+	return nil
+}
+
+func (repo memoryCustomerRepository) Delete(ctx context.Context, c *Customer) error {
+	switch {
+	case !c._exists: // doesn't exist
+		return nil
+	case c._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("Customer", c.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	c._deleted = true
+	return nil
+}
+
+func (repo memoryCustomerRepository) CustomerByCustomerName(ctx context.Context, customerName string) ([]*Customer, error) {
+	rows := repo.memdb.find("Customer", []string{"customer_name"}, customerName)
+	var res []*Customer
+	for _, row := range rows {
+		c := Customer{_exists: true}
+		c.setMemoryRow(row)
+		res = append(res, &c)
+	}
+	return res, nil
+}
+
+func (repo memoryCustomerRepository) CustomerByCustomerID(ctx context.Context, customerID int) (*Customer, error) {
+	rows := repo.memdb.find("Customer", []string{"customer_id"}, customerID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	c := Customer{_exists: true}
+	c.setMemoryRow(rows[0])
+	return &c, nil
+}
+
+func (repo memoryCustomerRepository) CustomerByEmail(ctx context.Context, email string) (*Customer, error) {
+	rows := repo.memdb.find("Customer", []string{"email"}, email)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	c := Customer{_exists: true}
+	c.setMemoryRow(rows[0])
+	return &c, nil
+}
+
+// memoryRow returns the fields of the [Customer] by column.
+func (c *Customer) memoryRow() memoryRow {
+	return memoryRow{
+		"customer_id":   c.CustomerID,
+		"customer_name": c.CustomerName,
+		"email":         c.Email,
+		"phone":         c.Phone,
+		"email_lower":   c.EmailLower,
+		"created_at":    c.CreatedAt,
+		"updated_at":    c.UpdatedAt,
+	}
+}
+
+// setMemoryRow sets the fields of the [Customer] from a row.
+func (c *Customer) setMemoryRow(row memoryRow) {
+	c.CustomerID = row["customer_id"].(int)
+	c.CustomerName = row["customer_name"].(string)
+	c.Email = row["email"].(string)
+	c.Phone = row["phone"].(sql.NullString)
+	c.EmailLower = row["email_lower"].(sql.NullString)
+	c.CreatedAt = row["created_at"].(Time)
+	c.UpdatedAt = row["updated_at"].(Time)
+}
@@ -0,0 +1,522 @@
+package generated_models_sqlite
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/imran31415/proto-db-translator/user"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Order represents a row from 'Orders'.
+type Order struct {
+	OrderID     int     `json:"order_id"`     // order_id
+	CustomerID  int     `json:"customer_id"`  // customer_id
+	OrderDate   Time    `json:"order_date"`   // order_date
+	TotalAmount float64 `json:"total_amount"` // total_amount
+	Status      string  `json:"status"`       // status
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [Order] exists in the database.
+func (o *Order) Exists() bool {
+	return o._exists
+}
+
+// Deleted returns true when the [Order] has been marked for deletion
+// from the database.
+func (o *Order) Deleted() bool {
+	return o._deleted
+}
+
+// Insert inserts the [Order] to the database.
+func (o *Order) Insert(ctx context.Context, db DB) error {
+	switch {
+	case o._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case o._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// insert (manual)
+	const sqlstr = `INSERT INTO Orders (` +
+		`order_id, customer_id, order_date, total_amount, status` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?` +
+		`)`
+	// run
+	logf(sqlstr, o.OrderID, o.CustomerID, o.OrderDate, o.TotalAmount, o.Status)
+	if _, err := db.ExecContext(ctx, sqlstr, o.OrderID, o.CustomerID, o.OrderDate, o.TotalAmount, o.Status); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	o._exists = true
+	return nil
+}
+
+// Update updates a [Order] in the database.
+func (o *Order) Update(ctx context.Context, db DB) error {
+	switch {
+	case !o._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case o._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// update with primary key
+	const sqlstr = `UPDATE Orders SET ` +
+		`customer_id = ?, order_date = ?, total_amount = ?, status = ? ` +
+		`WHERE order_id = ?`
+	// run
+	logf(sqlstr, o.CustomerID, o.OrderDate, o.TotalAmount, o.Status, o.OrderID)
+	if _, err := db.ExecContext(ctx, sqlstr, o.CustomerID, o.OrderDate, o.TotalAmount, o.Status, o.OrderID); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// Save saves the [Order] to the database.
+func (o *Order) Save(ctx context.Context, db DB) error {
+	if o.Exists() {
+		return o.Update(ctx, db)
+	}
+	return o.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [Order].
+func (o *Order) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case o._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// upsert
+	const sqlstr = `INSERT INTO Orders (` +
+		`order_id, customer_id, order_date, total_amount, status` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?` +
+		`)` +
+		` ON CONFLICT (order_id) DO ` +
+		`UPDATE SET ` +
+		`customer_id = EXCLUDED.customer_id, order_date = EXCLUDED.order_date, total_amount = EXCLUDED.total_amount, status = EXCLUDED.status `
+	// run
+	logf(sqlstr, o.OrderID, o.CustomerID, o.OrderDate, o.TotalAmount, o.Status)
+	if _, err := db.ExecContext(ctx, sqlstr, o.OrderID, o.CustomerID, o.OrderDate, o.TotalAmount, o.Status); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	o._exists = true
+	return nil
+}
+
+// Delete deletes the [Order] from the database.
+func (o *Order) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !o._exists: // doesn't exist
+		return nil
+	case o._deleted: // deleted
+		return nil
+	}
+	// delete with single primary key
+	const sqlstr = `DELETE FROM Orders ` +
+		`WHERE order_id = ?`
+	// run
+	logf(sqlstr, o.OrderID)
+	if _, err := db.ExecContext(ctx, sqlstr, o.OrderID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	o._deleted = true
+	return nil
+}
+
+// OrderKeysetPage retrieves a page of [Order] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
+func OrderKeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*Order, *Order, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT * FROM Orders 
+         WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Dynamically add filters from the `filters` map to the query
+	for field, value := range filters {
+		switch v := value.(type) {
+		case []int:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		case []string:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		default:
+			// Handle NULL and NOT NULL checks
+			if value == nil {
+				query += fmt.Sprintf(" AND %s IS NULL", field)
+			} else if value == "NOT NULL" {
+				query += fmt.Sprintf(" AND %s IS NOT NULL", field)
+			} else {
+				query += fmt.Sprintf(" AND %s = ?", field)
+				args = append(args, value)
+			}
+		}
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Log the final query for debugging purposes
+	log.Printf("Executing query: %s with args: %v", query, args)
+
+	// Execute the query
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*Order
+	var lastItem *Order // Variable to store the last item
+
+	for rows.Next() {
+		o := Order{
+			_exists: true,
+		}
+		if err := rows.Scan(
+			&o.OrderID, &o.CustomerID, &o.OrderDate, &o.TotalAmount, &o.Status,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &o)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// OrdersByOrderDateStatus retrieves a row from 'Orders' as a [Order].
+//
+// Generated from index 'Orders_order_date_status_idx'.
+func OrdersByOrderDateStatus(ctx context.Context, db DB, orderDate Time, status string) ([]*Order, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`order_id, customer_id, order_date, total_amount, status ` +
+		`FROM Orders ` +
+		`WHERE order_date = ? AND status = ?`
+	// run
+	logf(sqlstr, orderDate, status)
+	rows, err := db.QueryContext(ctx, sqlstr, orderDate, status)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Order
+	for rows.Next() {
+		o := Order{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&o.OrderID, &o.CustomerID, &o.OrderDate, &o.TotalAmount, &o.Status); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// OrdersByCustomerIDOrderDate retrieves a row from 'Orders' as a [Order].
+//
+// Generated from index 'orders_customer_recent_idx'.
+func OrdersByCustomerIDOrderDate(ctx context.Context, db DB, customerID int, orderDate Time) ([]*Order, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`order_id, customer_id, order_date, total_amount, status ` +
+		`FROM Orders ` +
+		`WHERE customer_id = ? AND order_date = ?`
+	// run
+	logf(sqlstr, customerID, orderDate)
+	rows, err := db.QueryContext(ctx, sqlstr, customerID, orderDate)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Order
+	for rows.Next() {
+		o := Order{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&o.OrderID, &o.CustomerID, &o.OrderDate, &o.TotalAmount, &o.Status); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// OrderByOrderID retrieves a row from 'Orders' as a [Order].
+//
+// Generated from index 'sqlite_autoindex_Orders_1'.
+func OrderByOrderID(ctx context.Context, db DB, orderID int) (*Order, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`order_id, customer_id, order_date, total_amount, status ` +
+		`FROM Orders ` +
+		`WHERE order_id = ?`
+	// run
+	logf(sqlstr, orderID)
+	o := Order{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, orderID).Scan(&o.OrderID, &o.CustomerID, &o.OrderDate, &o.TotalAmount, &o.Status); err != nil {
+		return nil, logerror(err)
+	}
+	return &o, nil
+}
+
+// Customer returns the Customer associated with the [Order]'s (CustomerID).
+//
+// Generated from foreign key 'Orders_customer_id_fkey'.
+func (o *Order) Customer(ctx context.Context, db DB) (*Customer, error) {
+	return CustomerByCustomerID(ctx, db, o.CustomerID)
+}
+
+// ToProto converts the [Order] to a [user.Orders].
+func (o *Order) ToProto() (*user.Orders, error) {
+	m := &user.Orders{}
+	m.OrderId = int32(o.OrderID)
+	m.CustomerId = int32(o.CustomerID)
+	m.OrderDate = timestamppb.New(o.OrderDate.Time())
+	m.TotalAmount = o.TotalAmount
+	m.Status = o.Status
+	return m, nil
+}
+
+// FromProto sets the [Order] from a [user.Orders].
+func (o *Order) FromProto(m *user.Orders) error {
+	o.OrderID = int(m.OrderId)
+	o.CustomerID = int(m.CustomerId)
+	if m.OrderDate != nil {
+		o.OrderDate = NewTime(m.OrderDate.AsTime())
+	} else {
+		o.OrderDate = Time{}
+	}
+	o.TotalAmount = m.TotalAmount
+	o.Status = m.Status
+	return nil
+}
+
+// OrderRepository reads and writes [Order] rows, see
+// [NewSQLOrderRepository] and [NewMemoryOrderRepository].
+type OrderRepository interface {
+	Insert(ctx context.Context, o *Order) error
+	Update(ctx context.Context, o *Order) error
+	Upsert(ctx context.Context, o *Order) error
+	Delete(ctx context.Context, o *Order) error
+	OrdersByOrderDateStatus(ctx context.Context, orderDate Time, status string) ([]*Order, error)
+	OrdersByCustomerIDOrderDate(ctx context.Context, customerID int, orderDate Time) ([]*Order, error)
+	OrderByOrderID(ctx context.Context, orderID int) (*Order, error)
+}
+
+// NewSQLOrderRepository returns a [OrderRepository] running the
+// queries of the [Order] funcs on db.
+func NewSQLOrderRepository(db DB) OrderRepository {
+	return sqlOrderRepository{db: db}
+}
+
+type sqlOrderRepository struct {
+	db DB
+}
+
+func (repo sqlOrderRepository) Insert(ctx context.Context, o *Order) error {
+	return o.Insert(ctx, repo.db)
+}
+
+func (repo sqlOrderRepository) Update(ctx context.Context, o *Order) error {
+	return o.Update(ctx, repo.db)
+}
+
+func (repo sqlOrderRepository) Upsert(ctx context.Context, o *Order) error {
+	return o.Upsert(ctx, repo.db)
+}
+
+func (repo sqlOrderRepository) Delete(ctx context.Context, o *Order) error {
+	return o.Delete(ctx, repo.db)
+}
+
+func (repo sqlOrderRepository) OrdersByOrderDateStatus(ctx context.Context, orderDate Time, status string) ([]*Order, error) {
+	return OrdersByOrderDateStatus(ctx, repo.db, orderDate, status)
+}
+
+func (repo sqlOrderRepository) OrdersByCustomerIDOrderDate(ctx context.Context, customerID int, orderDate Time) ([]*Order, error) {
+	return OrdersByCustomerIDOrderDate(ctx, repo.db, customerID, orderDate)
+}
+
+func (repo sqlOrderRepository) OrderByOrderID(ctx context.Context, orderID int) (*Order, error) {
+	return OrderByOrderID(ctx, repo.db, orderID)
+}
+
+// NewMemoryOrderRepository returns a [OrderRepository] storing the
+// rows in memdb.
+func NewMemoryOrderRepository(memdb *MemoryDB) OrderRepository {
+	return memoryOrderRepository{memdb: memdb}
+}
+
+type memoryOrderRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryOrderRepository) Insert(ctx context.Context, o *Order) error {
+	switch {
+	case o._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case o._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("Orders", o.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	o.setMemoryRow(row)
+	o._exists = true
+	return nil
+}
+
+func (repo memoryOrderRepository) Update(ctx context.Context, o *Order) error {
+	switch {
+	case !o._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case o._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("Orders", o.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryOrderRepository) Upsert(ctx context.Context, o *Order) error {
+	switch {
+	case o._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("Orders", o.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	o._exists = true
+	return nil
+}
+
+func (repo memoryOrderRepository) Delete(ctx context.Context, o *Order) error {
+	switch {
+	case !o._exists: // doesn't exist
+		return nil
+	case o._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("Orders", o.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	o._deleted = true
+	return nil
+}
+
+func (repo memoryOrderRepository) OrdersByOrderDateStatus(ctx context.Context, orderDate Time, status string) ([]*Order, error) {
+	rows := repo.memdb.find("Orders", []string{"order_date", "status"}, orderDate, status)
+	var res []*Order
+	for _, row := range rows {
+		o := Order{_exists: true}
+		o.setMemoryRow(row)
+		res = append(res, &o)
+	}
+	return res, nil
+}
+
+func (repo memoryOrderRepository) OrdersByCustomerIDOrderDate(ctx context.Context, customerID int, orderDate Time) ([]*Order, error) {
+	rows := repo.memdb.find("Orders", []string{"customer_id", "order_date"}, customerID, orderDate)
+	var res []*Order
+	for _, row := range rows {
+		o := Order{_exists: true}
+		o.setMemoryRow(row)
+		res = append(res, &o)
+	}
+	return res, nil
+}
+
+func (repo memoryOrderRepository) OrderByOrderID(ctx context.Context, orderID int) (*Order, error) {
+	rows := repo.memdb.find("Orders", []string{"order_id"}, orderID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	o := Order{_exists: true}
+	o.setMemoryRow(rows[0])
+	return &o, nil
+}
+
+// memoryRow returns the fields of the [Order] by column.
+func (o *Order) memoryRow() memoryRow {
+	return memoryRow{
+		"order_id":     o.OrderID,
+		"customer_id":  o.CustomerID,
+		"order_date":   o.OrderDate,
+		"total_amount": o.TotalAmount,
+		"status":       o.Status,
+	}
+}
+
+// setMemoryRow sets the fields of the [Order] from a row.
+func (o *Order) setMemoryRow(row memoryRow) {
+	o.OrderID = row["order_id"].(int)
+	o.CustomerID = row["customer_id"].(int)
+	o.OrderDate = row["order_date"].(Time)
+	o.TotalAmount = row["total_amount"].(float64)
+	o.Status = row["status"].(string)
+}
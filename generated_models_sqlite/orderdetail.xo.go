@@ -0,0 +1,519 @@
+package generated_models_sqlite
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/imran31415/proto-db-translator/user"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// OrderDetail represents a row from 'OrderDetails'.
+type OrderDetail struct {
+	OrderID   int  `json:"order_id"`   // order_id
+	ProductID int  `json:"product_id"` // product_id
+	Quantity  int  `json:"quantity"`   // quantity
+	CreatedAt Time `json:"created_at"` // created_at
+	UpdatedAt Time `json:"updated_at"` // updated_at
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [OrderDetail] exists in the database.
+func (od *OrderDetail) Exists() bool {
+	return od._exists
+}
+
+// Deleted returns true when the [OrderDetail] has been marked for deletion
+// from the database.
+func (od *OrderDetail) Deleted() bool {
+	return od._deleted
+}
+
+// Insert inserts the [OrderDetail] to the database.
+func (od *OrderDetail) Insert(ctx context.Context, db DB) error {
+	switch {
+	case od._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case od._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// insert (manual)
+	const sqlstr = `INSERT INTO OrderDetails (` +
+		`order_id, product_id, quantity, created_at, updated_at` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?` +
+		`)`
+	// run
+	logf(sqlstr, od.OrderID, od.ProductID, od.Quantity, od.CreatedAt, od.UpdatedAt)
+	if _, err := db.ExecContext(ctx, sqlstr, od.OrderID, od.ProductID, od.Quantity, od.CreatedAt, od.UpdatedAt); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	od._exists = true
+	return nil
+}
+
+// Update updates a [OrderDetail] in the database.
+func (od *OrderDetail) Update(ctx context.Context, db DB) error {
+	switch {
+	case !od._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case od._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// update with primary key
+	const sqlstr = `UPDATE OrderDetails SET ` +
+		`quantity = ?, created_at = ?, updated_at = ? ` +
+		`WHERE order_id = ? AND product_id = ?`
+	// run
+	logf(sqlstr, od.Quantity, od.CreatedAt, od.UpdatedAt, od.OrderID, od.ProductID)
+	if _, err := db.ExecContext(ctx, sqlstr, od.Quantity, od.CreatedAt, od.UpdatedAt, od.OrderID, od.ProductID); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// Save saves the [OrderDetail] to the database.
+func (od *OrderDetail) Save(ctx context.Context, db DB) error {
+	if od.Exists() {
+		return od.Update(ctx, db)
+	}
+	return od.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [OrderDetail].
+func (od *OrderDetail) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case od._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// upsert
+	const sqlstr = `INSERT INTO OrderDetails (` +
+		`order_id, product_id, quantity, created_at, updated_at` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?` +
+		`)` +
+		` ON CONFLICT (order_id, product_id) DO ` +
+		`UPDATE SET ` +
+		`quantity = EXCLUDED.quantity, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at `
+	// run
+	logf(sqlstr, od.OrderID, od.ProductID, od.Quantity, od.CreatedAt, od.UpdatedAt)
+	if _, err := db.ExecContext(ctx, sqlstr, od.OrderID, od.ProductID, od.Quantity, od.CreatedAt, od.UpdatedAt); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	od._exists = true
+	return nil
+}
+
+// Delete deletes the [OrderDetail] from the database.
+func (od *OrderDetail) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !od._exists: // doesn't exist
+		return nil
+	case od._deleted: // deleted
+		return nil
+	}
+	// delete with composite primary key
+	const sqlstr = `DELETE FROM OrderDetails ` +
+		`WHERE order_id = ? AND product_id = ?`
+	// run
+	logf(sqlstr, od.OrderID, od.ProductID)
+	if _, err := db.ExecContext(ctx, sqlstr, od.OrderID, od.ProductID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	od._deleted = true
+	return nil
+}
+
+// OrderDetailKeysetPage retrieves a page of [OrderDetail] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
+func OrderDetailKeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*OrderDetail, *OrderDetail, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT * FROM OrderDetails 
+         WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Dynamically add filters from the `filters` map to the query
+	for field, value := range filters {
+		switch v := value.(type) {
+		case []int:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		case []string:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		default:
+			// Handle NULL and NOT NULL checks
+			if value == nil {
+				query += fmt.Sprintf(" AND %s IS NULL", field)
+			} else if value == "NOT NULL" {
+				query += fmt.Sprintf(" AND %s IS NOT NULL", field)
+			} else {
+				query += fmt.Sprintf(" AND %s = ?", field)
+				args = append(args, value)
+			}
+		}
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Log the final query for debugging purposes
+	log.Printf("Executing query: %s with args: %v", query, args)
+
+	// Execute the query
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*OrderDetail
+	var lastItem *OrderDetail // Variable to store the last item
+
+	for rows.Next() {
+		od := OrderDetail{
+			_exists: true,
+		}
+		if err := rows.Scan(
+			&od.OrderID, &od.ProductID, &od.Quantity, &od.CreatedAt, &od.UpdatedAt,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &od)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// OrderDetailsByCreatedAtOrderID retrieves a row from 'OrderDetails' as a [OrderDetail].
+//
+// Generated from index 'OrderDetails_created_at_order_id_idx'.
+func OrderDetailsByCreatedAtOrderID(ctx context.Context, db DB, createdAt Time, orderID int) ([]*OrderDetail, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`order_id, product_id, quantity, created_at, updated_at ` +
+		`FROM OrderDetails ` +
+		`WHERE created_at = ? AND order_id = ?`
+	// run
+	logf(sqlstr, createdAt, orderID)
+	rows, err := db.QueryContext(ctx, sqlstr, createdAt, orderID)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*OrderDetail
+	for rows.Next() {
+		od := OrderDetail{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&od.OrderID, &od.ProductID, &od.Quantity, &od.CreatedAt, &od.UpdatedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &od)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// OrderDetailsByProductIDQuantity retrieves a row from 'OrderDetails' as a [OrderDetail].
+//
+// Generated from index 'OrderDetails_product_id_quantity_idx'.
+func OrderDetailsByProductIDQuantity(ctx context.Context, db DB, productID, quantity int) ([]*OrderDetail, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`order_id, product_id, quantity, created_at, updated_at ` +
+		`FROM OrderDetails ` +
+		`WHERE product_id = ? AND quantity = ?`
+	// run
+	logf(sqlstr, productID, quantity)
+	rows, err := db.QueryContext(ctx, sqlstr, productID, quantity)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*OrderDetail
+	for rows.Next() {
+		od := OrderDetail{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&od.OrderID, &od.ProductID, &od.Quantity, &od.CreatedAt, &od.UpdatedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &od)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// OrderDetailByOrderIDProductID retrieves a row from 'OrderDetails' as a [OrderDetail].
+//
+// Generated from index 'sqlite_autoindex_OrderDetails_1'.
+func OrderDetailByOrderIDProductID(ctx context.Context, db DB, orderID, productID int) (*OrderDetail, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`order_id, product_id, quantity, created_at, updated_at ` +
+		`FROM OrderDetails ` +
+		`WHERE order_id = ? AND product_id = ?`
+	// run
+	logf(sqlstr, orderID, productID)
+	od := OrderDetail{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, orderID, productID).Scan(&od.OrderID, &od.ProductID, &od.Quantity, &od.CreatedAt, &od.UpdatedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &od, nil
+}
+
+// ToProto converts the [OrderDetail] to a [user.OrderDetails].
+func (od *OrderDetail) ToProto() (*user.OrderDetails, error) {
+	m := &user.OrderDetails{}
+	m.OrderId = int32(od.OrderID)
+	m.ProductId = int32(od.ProductID)
+	m.Quantity = int32(od.Quantity)
+	m.CreatedAt = timestamppb.New(od.CreatedAt.Time())
+	m.UpdatedAt = timestamppb.New(od.UpdatedAt.Time())
+	return m, nil
+}
+
+// FromProto sets the [OrderDetail] from a [user.OrderDetails].
+func (od *OrderDetail) FromProto(m *user.OrderDetails) error {
+	od.OrderID = int(m.OrderId)
+	od.ProductID = int(m.ProductId)
+	od.Quantity = int(m.Quantity)
+	if m.CreatedAt != nil {
+		od.CreatedAt = NewTime(m.CreatedAt.AsTime())
+	} else {
+		od.CreatedAt = Time{}
+	}
+	if m.UpdatedAt != nil {
+		od.UpdatedAt = NewTime(m.UpdatedAt.AsTime())
+	} else {
+		od.UpdatedAt = Time{}
+	}
+	return nil
+}
+
+// OrderDetailRepository reads and writes [OrderDetail] rows, see
+// [NewSQLOrderDetailRepository] and [NewMemoryOrderDetailRepository].
+type OrderDetailRepository interface {
+	Insert(ctx context.Context, od *OrderDetail) error
+	Update(ctx context.Context, od *OrderDetail) error
+	Upsert(ctx context.Context, od *OrderDetail) error
+	Delete(ctx context.Context, od *OrderDetail) error
+	OrderDetailsByCreatedAtOrderID(ctx context.Context, createdAt Time, orderID int) ([]*OrderDetail, error)
+	OrderDetailsByProductIDQuantity(ctx context.Context, productID, quantity int) ([]*OrderDetail, error)
+	OrderDetailByOrderIDProductID(ctx context.Context, orderID, productID int) (*OrderDetail, error)
+}
+
+// NewSQLOrderDetailRepository returns a [OrderDetailRepository] running the
+// queries of the [OrderDetail] funcs on db.
+func NewSQLOrderDetailRepository(db DB) OrderDetailRepository {
+	return sqlOrderDetailRepository{db: db}
+}
+
+type sqlOrderDetailRepository struct {
+	db DB
+}
+
+func (repo sqlOrderDetailRepository) Insert(ctx context.Context, od *OrderDetail) error {
+	return od.Insert(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailRepository) Update(ctx context.Context, od *OrderDetail) error {
+	return od.Update(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailRepository) Upsert(ctx context.Context, od *OrderDetail) error {
+	return od.Upsert(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailRepository) Delete(ctx context.Context, od *OrderDetail) error {
+	return od.Delete(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailRepository) OrderDetailsByCreatedAtOrderID(ctx context.Context, createdAt Time, orderID int) ([]*OrderDetail, error) {
+	return OrderDetailsByCreatedAtOrderID(ctx, repo.db, createdAt, orderID)
+}
+
+func (repo sqlOrderDetailRepository) OrderDetailsByProductIDQuantity(ctx context.Context, productID, quantity int) ([]*OrderDetail, error) {
+	return OrderDetailsByProductIDQuantity(ctx, repo.db, productID, quantity)
+}
+
+func (repo sqlOrderDetailRepository) OrderDetailByOrderIDProductID(ctx context.Context, orderID, productID int) (*OrderDetail, error) {
+	return OrderDetailByOrderIDProductID(ctx, repo.db, orderID, productID)
+}
+
+// NewMemoryOrderDetailRepository returns a [OrderDetailRepository] storing the
+// rows in memdb.
+func NewMemoryOrderDetailRepository(memdb *MemoryDB) OrderDetailRepository {
+	return memoryOrderDetailRepository{memdb: memdb}
+}
+
+type memoryOrderDetailRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryOrderDetailRepository) Insert(ctx context.Context, od *OrderDetail) error {
+	switch {
+	case od._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case od._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("OrderDetails", od.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	od.setMemoryRow(row)
+	od._exists = true
+	return nil
+}
+
+func (repo memoryOrderDetailRepository) Update(ctx context.Context, od *OrderDetail) error {
+	switch {
+	case !od._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case od._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("OrderDetails", od.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryOrderDetailRepository) Upsert(ctx context.Context, od *OrderDetail) error {
+	switch {
+	case od._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("OrderDetails", od.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	od._exists = true
+	return nil
+}
+
+func (repo memoryOrderDetailRepository) Delete(ctx context.Context, od *OrderDetail) error {
+	switch {
+	case !od._exists: // doesn't exist
+		return nil
+	case od._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("OrderDetails", od.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	od._deleted = true
+	return nil
+}
+
+func (repo memoryOrderDetailRepository) OrderDetailsByCreatedAtOrderID(ctx context.Context, createdAt Time, orderID int) ([]*OrderDetail, error) {
+	rows := repo.memdb.find("OrderDetails", []string{"created_at", "order_id"}, createdAt, orderID)
+	var res []*OrderDetail
+	for _, row := range rows {
+		od := OrderDetail{_exists: true}
+		od.setMemoryRow(row)
+		res = append(res, &od)
+	}
+	return res, nil
+}
+
+func (repo memoryOrderDetailRepository) OrderDetailsByProductIDQuantity(ctx context.Context, productID, quantity int) ([]*OrderDetail, error) {
+	rows := repo.memdb.find("OrderDetails", []string{"product_id", "quantity"}, productID, quantity)
+	var res []*OrderDetail
+	for _, row := range rows {
+		od := OrderDetail{_exists: true}
+		od.setMemoryRow(row)
+		res = append(res, &od)
+	}
+	return res, nil
+}
+
+func (repo memoryOrderDetailRepository) OrderDetailByOrderIDProductID(ctx context.Context, orderID, productID int) (*OrderDetail, error) {
+	rows := repo.memdb.find("OrderDetails", []string{"order_id", "product_id"}, orderID, productID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	od := OrderDetail{_exists: true}
+	od.setMemoryRow(rows[0])
+	return &od, nil
+}
+
+// memoryRow returns the fields of the [OrderDetail] by column.
+func (od *OrderDetail) memoryRow() memoryRow {
+	return memoryRow{
+		"order_id":   od.OrderID,
+		"product_id": od.ProductID,
+		"quantity":   od.Quantity,
+		"created_at": od.CreatedAt,
+		"updated_at": od.UpdatedAt,
+	}
+}
+
+// setMemoryRow sets the fields of the [OrderDetail] from a row.
+func (od *OrderDetail) setMemoryRow(row memoryRow) {
+	od.OrderID = row["order_id"].(int)
+	od.ProductID = row["product_id"].(int)
+	od.Quantity = row["quantity"].(int)
+	od.CreatedAt = row["created_at"].(Time)
+	od.UpdatedAt = row["updated_at"].(Time)
+}
@@ -0,0 +1,411 @@
+package generated_models_sqlite
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/imran31415/proto-db-translator/user"
+)
+
+// OrderDetailShipment represents a row from 'OrderDetailShipments'.
+type OrderDetailShipment struct {
+	ShipmentID int `json:"shipment_id"` // shipment_id
+	OrderID    int `json:"order_id"`    // order_id
+	ProductID  int `json:"product_id"`  // product_id
+	Quantity   int `json:"quantity"`    // quantity
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [OrderDetailShipment] exists in the database.
+func (ods *OrderDetailShipment) Exists() bool {
+	return ods._exists
+}
+
+// Deleted returns true when the [OrderDetailShipment] has been marked for deletion
+// from the database.
+func (ods *OrderDetailShipment) Deleted() bool {
+	return ods._deleted
+}
+
+// Insert inserts the [OrderDetailShipment] to the database.
+func (ods *OrderDetailShipment) Insert(ctx context.Context, db DB) error {
+	switch {
+	case ods._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case ods._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// insert (manual)
+	const sqlstr = `INSERT INTO OrderDetailShipments (` +
+		`shipment_id, order_id, product_id, quantity` +
+		`) VALUES (` +
+		`?, ?, ?, ?` +
+		`)`
+	// run
+	logf(sqlstr, ods.ShipmentID, ods.OrderID, ods.ProductID, ods.Quantity)
+	if _, err := db.ExecContext(ctx, sqlstr, ods.ShipmentID, ods.OrderID, ods.ProductID, ods.Quantity); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	ods._exists = true
+	return nil
+}
+
+// Update updates a [OrderDetailShipment] in the database.
+func (ods *OrderDetailShipment) Update(ctx context.Context, db DB) error {
+	switch {
+	case !ods._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case ods._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// update with primary key
+	const sqlstr = `UPDATE OrderDetailShipments SET ` +
+		`order_id = ?, product_id = ?, quantity = ? ` +
+		`WHERE shipment_id = ?`
+	// run
+	logf(sqlstr, ods.OrderID, ods.ProductID, ods.Quantity, ods.ShipmentID)
+	if _, err := db.ExecContext(ctx, sqlstr, ods.OrderID, ods.ProductID, ods.Quantity, ods.ShipmentID); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// Save saves the [OrderDetailShipment] to the database.
+func (ods *OrderDetailShipment) Save(ctx context.Context, db DB) error {
+	if ods.Exists() {
+		return ods.Update(ctx, db)
+	}
+	return ods.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [OrderDetailShipment].
+func (ods *OrderDetailShipment) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case ods._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// upsert
+	const sqlstr = `INSERT INTO OrderDetailShipments (` +
+		`shipment_id, order_id, product_id, quantity` +
+		`) VALUES (` +
+		`?, ?, ?, ?` +
+		`)` +
+		` ON CONFLICT (shipment_id) DO ` +
+		`UPDATE SET ` +
+		`order_id = EXCLUDED.order_id, product_id = EXCLUDED.product_id, quantity = EXCLUDED.quantity `
+	// run
+	logf(sqlstr, ods.ShipmentID, ods.OrderID, ods.ProductID, ods.Quantity)
+	if _, err := db.ExecContext(ctx, sqlstr, ods.ShipmentID, ods.OrderID, ods.ProductID, ods.Quantity); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	ods._exists = true
+	return nil
+}
+
+// Delete deletes the [OrderDetailShipment] from the database.
+func (ods *OrderDetailShipment) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !ods._exists: // doesn't exist
+		return nil
+	case ods._deleted: // deleted
+		return nil
+	}
+	// delete with single primary key
+	const sqlstr = `DELETE FROM OrderDetailShipments ` +
+		`WHERE shipment_id = ?`
+	// run
+	logf(sqlstr, ods.ShipmentID)
+	if _, err := db.ExecContext(ctx, sqlstr, ods.ShipmentID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	ods._deleted = true
+	return nil
+}
+
+// OrderDetailShipmentKeysetPage retrieves a page of [OrderDetailShipment] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
+func OrderDetailShipmentKeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*OrderDetailShipment, *OrderDetailShipment, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT * FROM OrderDetailShipments 
+         WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Dynamically add filters from the `filters` map to the query
+	for field, value := range filters {
+		switch v := value.(type) {
+		case []int:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		case []string:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		default:
+			// Handle NULL and NOT NULL checks
+			if value == nil {
+				query += fmt.Sprintf(" AND %s IS NULL", field)
+			} else if value == "NOT NULL" {
+				query += fmt.Sprintf(" AND %s IS NOT NULL", field)
+			} else {
+				query += fmt.Sprintf(" AND %s = ?", field)
+				args = append(args, value)
+			}
+		}
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Log the final query for debugging purposes
+	log.Printf("Executing query: %s with args: %v", query, args)
+
+	// Execute the query
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*OrderDetailShipment
+	var lastItem *OrderDetailShipment // Variable to store the last item
+
+	for rows.Next() {
+		ods := OrderDetailShipment{
+			_exists: true,
+		}
+		if err := rows.Scan(
+			&ods.ShipmentID, &ods.OrderID, &ods.ProductID, &ods.Quantity,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &ods)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// OrderDetailShipmentByShipmentID retrieves a row from 'OrderDetailShipments' as a [OrderDetailShipment].
+//
+// Generated from index 'sqlite_autoindex_OrderDetailShipments_1'.
+func OrderDetailShipmentByShipmentID(ctx context.Context, db DB, shipmentID int) (*OrderDetailShipment, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`shipment_id, order_id, product_id, quantity ` +
+		`FROM OrderDetailShipments ` +
+		`WHERE shipment_id = ?`
+	// run
+	logf(sqlstr, shipmentID)
+	ods := OrderDetailShipment{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, shipmentID).Scan(&ods.ShipmentID, &ods.OrderID, &ods.ProductID, &ods.Quantity); err != nil {
+		return nil, logerror(err)
+	}
+	return &ods, nil
+}
+
+// OrderDetail returns the OrderDetail associated with the [OrderDetailShipment]'s (OrderID, ProductID).
+//
+// Generated from foreign key 'OrderDetailShipments_order_id_product_id_fkey'.
+func (ods *OrderDetailShipment) OrderDetail(ctx context.Context, db DB) (*OrderDetail, error) {
+	return OrderDetailByOrderIDProductID(ctx, db, ods.OrderID, ods.ProductID)
+}
+
+// ToProto converts the [OrderDetailShipment] to a [user.OrderDetailShipments].
+func (ods *OrderDetailShipment) ToProto() (*user.OrderDetailShipments, error) {
+	m := &user.OrderDetailShipments{}
+	m.ShipmentId = int32(ods.ShipmentID)
+	m.OrderId = int32(ods.OrderID)
+	m.ProductId = int32(ods.ProductID)
+	m.Quantity = int32(ods.Quantity)
+	return m, nil
+}
+
+// FromProto sets the [OrderDetailShipment] from a [user.OrderDetailShipments].
+func (ods *OrderDetailShipment) FromProto(m *user.OrderDetailShipments) error {
+	ods.ShipmentID = int(m.ShipmentId)
+	ods.OrderID = int(m.OrderId)
+	ods.ProductID = int(m.ProductId)
+	ods.Quantity = int(m.Quantity)
+	return nil
+}
+
+// OrderDetailShipmentRepository reads and writes [OrderDetailShipment] rows, see
+// [NewSQLOrderDetailShipmentRepository] and [NewMemoryOrderDetailShipmentRepository].
+type OrderDetailShipmentRepository interface {
+	Insert(ctx context.Context, ods *OrderDetailShipment) error
+	Update(ctx context.Context, ods *OrderDetailShipment) error
+	Upsert(ctx context.Context, ods *OrderDetailShipment) error
+	Delete(ctx context.Context, ods *OrderDetailShipment) error
+	OrderDetailShipmentByShipmentID(ctx context.Context, shipmentID int) (*OrderDetailShipment, error)
+}
+
+// NewSQLOrderDetailShipmentRepository returns a [OrderDetailShipmentRepository] running the
+// queries of the [OrderDetailShipment] funcs on db.
+func NewSQLOrderDetailShipmentRepository(db DB) OrderDetailShipmentRepository {
+	return sqlOrderDetailShipmentRepository{db: db}
+}
+
+type sqlOrderDetailShipmentRepository struct {
+	db DB
+}
+
+func (repo sqlOrderDetailShipmentRepository) Insert(ctx context.Context, ods *OrderDetailShipment) error {
+	return ods.Insert(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailShipmentRepository) Update(ctx context.Context, ods *OrderDetailShipment) error {
+	return ods.Update(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailShipmentRepository) Upsert(ctx context.Context, ods *OrderDetailShipment) error {
+	return ods.Upsert(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailShipmentRepository) Delete(ctx context.Context, ods *OrderDetailShipment) error {
+	return ods.Delete(ctx, repo.db)
+}
+
+func (repo sqlOrderDetailShipmentRepository) OrderDetailShipmentByShipmentID(ctx context.Context, shipmentID int) (*OrderDetailShipment, error) {
+	return OrderDetailShipmentByShipmentID(ctx, repo.db, shipmentID)
+}
+
+// NewMemoryOrderDetailShipmentRepository returns a [OrderDetailShipmentRepository] storing the
+// rows in memdb.
+func NewMemoryOrderDetailShipmentRepository(memdb *MemoryDB) OrderDetailShipmentRepository {
+	return memoryOrderDetailShipmentRepository{memdb: memdb}
+}
+
+type memoryOrderDetailShipmentRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryOrderDetailShipmentRepository) Insert(ctx context.Context, ods *OrderDetailShipment) error {
+	switch {
+	case ods._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case ods._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("OrderDetailShipments", ods.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	ods.setMemoryRow(row)
+	ods._exists = true
+	return nil
+}
+
+func (repo memoryOrderDetailShipmentRepository) Update(ctx context.Context, ods *OrderDetailShipment) error {
+	switch {
+	case !ods._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case ods._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("OrderDetailShipments", ods.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryOrderDetailShipmentRepository) Upsert(ctx context.Context, ods *OrderDetailShipment) error {
+	switch {
+	case ods._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("OrderDetailShipments", ods.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	ods._exists = true
+	return nil
+}
+
+func (repo memoryOrderDetailShipmentRepository) Delete(ctx context.Context, ods *OrderDetailShipment) error {
+	switch {
+	case !ods._exists: // doesn't exist
+		return nil
+	case ods._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("OrderDetailShipments", ods.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	ods._deleted = true
+	return nil
+}
+
+func (repo memoryOrderDetailShipmentRepository) OrderDetailShipmentByShipmentID(ctx context.Context, shipmentID int) (*OrderDetailShipment, error) {
+	rows := repo.memdb.find("OrderDetailShipments", []string{"shipment_id"}, shipmentID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	ods := OrderDetailShipment{_exists: true}
+	ods.setMemoryRow(rows[0])
+	return &ods, nil
+}
+
+// memoryRow returns the fields of the [OrderDetailShipment] by column.
+func (ods *OrderDetailShipment) memoryRow() memoryRow {
+	return memoryRow{
+		"shipment_id": ods.ShipmentID,
+		"order_id":    ods.OrderID,
+		"product_id":  ods.ProductID,
+		"quantity":    ods.Quantity,
+	}
+}
+
+// setMemoryRow sets the fields of the [OrderDetailShipment] from a row.
+func (ods *OrderDetailShipment) setMemoryRow(row memoryRow) {
+	ods.ShipmentID = row["shipment_id"].(int)
+	ods.OrderID = row["order_id"].(int)
+	ods.ProductID = row["product_id"].(int)
+	ods.Quantity = row["quantity"].(int)
+}
@@ -0,0 +1,485 @@
+package generated_models_sqlite
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/imran31415/proto-db-translator/user"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Role represents a row from 'Role'.
+type Role struct {
+	RoleID       int            `json:"role_id"`        // role_id
+	RoleName     string         `json:"role_name"`      // role_name
+	CreatedAt    Time           `json:"created_at"`     // created_at
+	UpdatedAt    Time           `json:"updated_at"`     // updated_at
+	ParentRoleID sql.NullInt64  `json:"parent_role_id"` // parent_role_id
+	Description  sql.NullString `json:"description"`    // description
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [Role] exists in the database.
+func (r *Role) Exists() bool {
+	return r._exists
+}
+
+// Deleted returns true when the [Role] has been marked for deletion
+// from the database.
+func (r *Role) Deleted() bool {
+	return r._deleted
+}
+
+// Insert inserts the [Role] to the database.
+func (r *Role) Insert(ctx context.Context, db DB) error {
+	switch {
+	case r._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case r._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// insert (primary key generated and returned by database)
+	const sqlstr = `INSERT INTO Role (` +
+		`role_name, created_at, updated_at, parent_role_id, description` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?` +
+		`)`
+	// run
+	logf(sqlstr, r.RoleName, r.CreatedAt, r.UpdatedAt, r.ParentRoleID, r.Description)
+	res, err := db.ExecContext(ctx, sqlstr, r.RoleName, r.CreatedAt, r.UpdatedAt, r.ParentRoleID, r.Description)
+	if err != nil {
+		return logerror(err)
+	}
+	// retrieve id
+	id, err := res.LastInsertId()
+	if err != nil {
+		return logerror(err)
+	} // set primary key
+	r.RoleID = int(id)
+	// set exists
+	r._exists = true
+	return nil
+}
+
+// Update updates a [Role] in the database.
+func (r *Role) Update(ctx context.Context, db DB) error {
+	switch {
+	case !r._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case r._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// update with primary key
+	const sqlstr = `UPDATE Role SET ` +
+		`role_name = ?, created_at = ?, updated_at = ?, parent_role_id = ?, description = ? ` +
+		`WHERE role_id = ?`
+	// run
+	logf(sqlstr, r.RoleName, r.CreatedAt, r.UpdatedAt, r.ParentRoleID, r.Description, r.RoleID)
+	if _, err := db.ExecContext(ctx, sqlstr, r.RoleName, r.CreatedAt, r.UpdatedAt, r.ParentRoleID, r.Description, r.RoleID); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// Save saves the [Role] to the database.
+func (r *Role) Save(ctx context.Context, db DB) error {
+	if r.Exists() {
+		return r.Update(ctx, db)
+	}
+	return r.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [Role].
+func (r *Role) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case r._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// upsert
+	const sqlstr = `INSERT INTO Role (` +
+		`role_id, role_name, created_at, updated_at, parent_role_id, description` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?, ?` +
+		`)` +
+		` ON CONFLICT (role_id) DO ` +
+		`UPDATE SET ` +
+		`role_name = EXCLUDED.role_name, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at, parent_role_id = EXCLUDED.parent_role_id, description = EXCLUDED.description `
+	// run
+	logf(sqlstr, r.RoleID, r.RoleName, r.CreatedAt, r.UpdatedAt, r.ParentRoleID, r.Description)
+	if _, err := db.ExecContext(ctx, sqlstr, r.RoleID, r.RoleName, r.CreatedAt, r.UpdatedAt, r.ParentRoleID, r.Description); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	r._exists = true
+	return nil
+}
+
+// Delete deletes the [Role] from the database.
+func (r *Role) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !r._exists: // doesn't exist
+		return nil
+	case r._deleted: // deleted
+		return nil
+	}
+	// delete with single primary key
+	const sqlstr = `DELETE FROM Role ` +
+		`WHERE role_id = ?`
+	// run
+	logf(sqlstr, r.RoleID)
+	if _, err := db.ExecContext(ctx, sqlstr, r.RoleID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	r._deleted = true
+	return nil
+}
+
+// RoleKeysetPage retrieves a page of [Role] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
+func RoleKeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*Role, *Role, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT * FROM Role 
+         WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Dynamically add filters from the `filters` map to the query
+	for field, value := range filters {
+		switch v := value.(type) {
+		case []int:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		case []string:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		default:
+			// Handle NULL and NOT NULL checks
+			if value == nil {
+				query += fmt.Sprintf(" AND %s IS NULL", field)
+			} else if value == "NOT NULL" {
+				query += fmt.Sprintf(" AND %s IS NOT NULL", field)
+			} else {
+				query += fmt.Sprintf(" AND %s = ?", field)
+				args = append(args, value)
+			}
+		}
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Log the final query for debugging purposes
+	log.Printf("Executing query: %s with args: %v", query, args)
+
+	// Execute the query
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*Role
+	var lastItem *Role // Variable to store the last item
+
+	for rows.Next() {
+		r := Role{
+			_exists: true,
+		}
+		if err := rows.Scan(
+			&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt, &r.ParentRoleID, &r.Description,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &r)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// RoleByRoleID retrieves a row from 'Role' as a [Role].
+//
+// Generated from index 'Role_role_id_pkey'.
+func RoleByRoleID(ctx context.Context, db DB, roleID int) (*Role, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`role_id, role_name, created_at, updated_at, parent_role_id, description ` +
+		`FROM Role ` +
+		`WHERE role_id = ?`
+	// run
+	logf(sqlstr, roleID)
+	r := Role{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, roleID).Scan(&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt, &r.ParentRoleID, &r.Description); err != nil {
+		return nil, logerror(err)
+	}
+	return &r, nil
+}
+
+// RoleByRoleName retrieves a row from 'Role' as a [Role].
+//
+// Generated from index 'sqlite_autoindex_Role_1'.
+func RoleByRoleName(ctx context.Context, db DB, roleName string) (*Role, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`role_id, role_name, created_at, updated_at, parent_role_id, description ` +
+		`FROM Role ` +
+		`WHERE role_name = ?`
+	// run
+	logf(sqlstr, roleName)
+	r := Role{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, roleName).Scan(&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt, &r.ParentRoleID, &r.Description); err != nil {
+		return nil, logerror(err)
+	}
+	return &r, nil
+}
+
+// Role returns the Role associated with the [Role]'s (ParentRoleID).
+//
+// Generated from foreign key 'Role_parent_role_id_fkey'.
+func (r *Role) Role(ctx context.Context, db DB) (*Role, error) {
+	return RoleByRoleID(ctx, db, int(r.ParentRoleID.Int64))
+}
+
+// ToProto converts the [Role] to a [user.Role].
+func (r *Role) ToProto() (*user.Role, error) {
+	m := &user.Role{}
+	m.RoleId = int32(r.RoleID)
+	m.RoleName = r.RoleName
+	m.CreatedAt = timestamppb.New(r.CreatedAt.Time())
+	m.UpdatedAt = timestamppb.New(r.UpdatedAt.Time())
+	if r.ParentRoleID.Valid {
+		m.ParentRoleId = int32(r.ParentRoleID.Int64)
+	}
+	if r.Description.Valid {
+		m.Description = r.Description.String
+	}
+	return m, nil
+}
+
+// FromProto sets the [Role] from a [user.Role].
+func (r *Role) FromProto(m *user.Role) error {
+	r.RoleID = int(m.RoleId)
+	r.RoleName = m.RoleName
+	if m.CreatedAt != nil {
+		r.CreatedAt = NewTime(m.CreatedAt.AsTime())
+	} else {
+		r.CreatedAt = Time{}
+	}
+	if m.UpdatedAt != nil {
+		r.UpdatedAt = NewTime(m.UpdatedAt.AsTime())
+	} else {
+		r.UpdatedAt = Time{}
+	}
+	if m.ParentRoleId != 0 {
+		r.ParentRoleID = sql.NullInt64{Int64: int64(m.ParentRoleId), Valid: true}
+	} else {
+		r.ParentRoleID = sql.NullInt64{}
+	}
+	if m.Description != "" {
+		r.Description = sql.NullString{String: m.Description, Valid: true}
+	} else {
+		r.Description = sql.NullString{}
+	}
+	return nil
+}
+
+// RoleRepository reads and writes [Role] rows, see
+// [NewSQLRoleRepository] and [NewMemoryRoleRepository].
+type RoleRepository interface {
+	Insert(ctx context.Context, r *Role) error
+	Update(ctx context.Context, r *Role) error
+	Upsert(ctx context.Context, r *Role) error
+	Delete(ctx context.Context, r *Role) error
+	RoleByRoleName(ctx context.Context, roleName string) (*Role, error)
+	RoleByRoleID(ctx context.Context, roleID int) (*Role, error)
+}
+
+// NewSQLRoleRepository returns a [RoleRepository] running the
+// queries of the [Role] funcs on db.
+func NewSQLRoleRepository(db DB) RoleRepository {
+	return sqlRoleRepository{db: db}
+}
+
+type sqlRoleRepository struct {
+	db DB
+}
+
+func (repo sqlRoleRepository) Insert(ctx context.Context, r *Role) error {
+	return r.Insert(ctx, repo.db)
+}
+
+func (repo sqlRoleRepository) Update(ctx context.Context, r *Role) error {
+	return r.Update(ctx, repo.db)
+}
+
+func (repo sqlRoleRepository) Upsert(ctx context.Context, r *Role) error {
+	return r.Upsert(ctx, repo.db)
+}
+
+func (repo sqlRoleRepository) Delete(ctx context.Context, r *Role) error {
+	return r.Delete(ctx, repo.db)
+}
+
+func (repo sqlRoleRepository) RoleByRoleName(ctx context.Context, roleName string) (*Role, error) {
+	return RoleByRoleName(ctx, repo.db, roleName)
+}
+
+func (repo sqlRoleRepository) RoleByRoleID(ctx context.Context, roleID int) (*Role, error) {
+	return RoleByRoleID(ctx, repo.db, roleID)
+}
+
+// NewMemoryRoleRepository returns a [RoleRepository] storing the
+// rows in memdb.
+func NewMemoryRoleRepository(memdb *MemoryDB) RoleRepository {
+	return memoryRoleRepository{memdb: memdb}
+}
+
+type memoryRoleRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryRoleRepository) Insert(ctx context.Context, r *Role) error {
+	switch {
+	case r._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case r._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("Role", r.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	r.setMemoryRow(row)
+	r._exists = true
+	return nil
+}
+
+func (repo memoryRoleRepository) Update(ctx context.Context, r *Role) error {
+	switch {
+	case !r._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case r._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("Role", r.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryRoleRepository) Upsert(ctx context.Context, r *Role) error {
+	switch {
+	case r._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("Role", r.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	r._exists = true
+	return nil
+}
+
+func (repo memoryRoleRepository) Delete(ctx context.Context, r *Role) error {
+	switch {
+	case !r._exists: // doesn't exist
+		return nil
+	case r._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("Role", r.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	r._deleted = true
+	return nil
+}
+
+func (repo memoryRoleRepository) RoleByRoleName(ctx context.Context, roleName string) (*Role, error) {
+	rows := repo.memdb.find("Role", []string{"role_name"}, roleName)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	r := Role{_exists: true}
+	r.setMemoryRow(rows[0])
+	return &r, nil
+}
+
+func (repo memoryRoleRepository) RoleByRoleID(ctx context.Context, roleID int) (*Role, error) {
+	rows := repo.memdb.find("Role", []string{"role_id"}, roleID)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	r := Role{_exists: true}
+	r.setMemoryRow(rows[0])
+	return &r, nil
+}
+
+// memoryRow returns the fields of the [Role] by column.
+func (r *Role) memoryRow() memoryRow {
+	return memoryRow{
+		"role_id":        r.RoleID,
+		"role_name":      r.RoleName,
+		"created_at":     r.CreatedAt,
+		"updated_at":     r.UpdatedAt,
+		"parent_role_id": r.ParentRoleID,
+		"description":    r.Description,
+	}
+}
+
+// setMemoryRow sets the fields of the [Role] from a row.
+func (r *Role) setMemoryRow(row memoryRow) {
+	r.RoleID = row["role_id"].(int)
+	r.RoleName = row["role_name"].(string)
+	r.CreatedAt = row["created_at"].(Time)
+	r.UpdatedAt = row["updated_at"].(Time)
+	r.ParentRoleID = row["parent_role_id"].(sql.NullInt64)
+	r.Description = row["description"].(sql.NullString)
+}
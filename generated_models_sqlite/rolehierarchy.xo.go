@@ -0,0 +1,131 @@
+package generated_models_sqlite
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/imran31415/proto-db-translator/user"
+)
+
+// RoleHierarchy represents a row from 'RoleHierarchy'.
+type RoleHierarchy struct {
+	ChildRoleID  int `json:"child_role_id"`  // child_role_id
+	ParentRoleID int `json:"parent_role_id"` // parent_role_id
+}
+
+// RoleHierarchyKeysetPage retrieves a page of [RoleHierarchy] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
+func RoleHierarchyKeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*RoleHierarchy, *RoleHierarchy, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT * FROM RoleHierarchy 
+         WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Dynamically add filters from the `filters` map to the query
+	for field, value := range filters {
+		switch v := value.(type) {
+		case []int:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		case []string:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		default:
+			// Handle NULL and NOT NULL checks
+			if value == nil {
+				query += fmt.Sprintf(" AND %s IS NULL", field)
+			} else if value == "NOT NULL" {
+				query += fmt.Sprintf(" AND %s IS NOT NULL", field)
+			} else {
+				query += fmt.Sprintf(" AND %s = ?", field)
+				args = append(args, value)
+			}
+		}
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Log the final query for debugging purposes
+	log.Printf("Executing query: %s with args: %v", query, args)
+
+	// Execute the query
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*RoleHierarchy
+	var lastItem *RoleHierarchy // Variable to store the last item
+
+	for rows.Next() {
+		rh := RoleHierarchy{}
+		if err := rows.Scan(
+			&rh.ChildRoleID, &rh.ParentRoleID,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &rh)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// ToProto converts the [RoleHierarchy] to a [user.RoleHierarchy].
+func (rh *RoleHierarchy) ToProto() (*user.RoleHierarchy, error) {
+	m := &user.RoleHierarchy{}
+	m.ChildRoleId = int32(rh.ChildRoleID)
+	m.ParentRoleId = int32(rh.ParentRoleID)
+	return m, nil
+}
+
+// FromProto sets the [RoleHierarchy] from a [user.RoleHierarchy].
+func (rh *RoleHierarchy) FromProto(m *user.RoleHierarchy) error {
+	rh.ChildRoleID = int(m.ChildRoleId)
+	rh.ParentRoleID = int(m.ParentRoleId)
+	return nil
+}
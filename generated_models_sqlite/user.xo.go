@@ -0,0 +1,510 @@
+package generated_models_sqlite
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/imran31415/proto-db-translator/user"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// User represents a row from 'User'.
+type User struct {
+	ID              int            `json:"id"`                // id
+	Username        string         `json:"username"`          // username
+	Email           string         `json:"email"`             // email
+	HashedPassword  string         `json:"hashed_password"`   // hashed_password
+	Is2faEnabled    bool           `json:"is_2fa_enabled"`    // is_2fa_enabled
+	TwoFactorSecret sql.NullString `json:"two_factor_secret"` // two_factor_secret
+	CreatedAt       Time           `json:"created_at"`        // created_at
+	UpdatedAt       Time           `json:"updated_at"`        // updated_at
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [User] exists in the database.
+func (u *User) Exists() bool {
+	return u._exists
+}
+
+// Deleted returns true when the [User] has been marked for deletion
+// from the database.
+func (u *User) Deleted() bool {
+	return u._deleted
+}
+
+// Insert inserts the [User] to the database.
+func (u *User) Insert(ctx context.Context, db DB) error {
+	switch {
+	case u._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case u._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// insert (manual)
+	const sqlstr = `INSERT INTO User (` +
+		`id, username, email, hashed_password, is_2fa_enabled, two_factor_secret, created_at, updated_at` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?, ?, ?, ?` +
+		`)`
+	// run
+	logf(sqlstr, u.ID, u.Username, u.Email, u.HashedPassword, u.Is2faEnabled, u.TwoFactorSecret, u.CreatedAt, u.UpdatedAt)
+	if _, err := db.ExecContext(ctx, sqlstr, u.ID, u.Username, u.Email, u.HashedPassword, u.Is2faEnabled, u.TwoFactorSecret, u.CreatedAt, u.UpdatedAt); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	u._exists = true
+	return nil
+}
+
+// Update updates a [User] in the database.
+func (u *User) Update(ctx context.Context, db DB) error {
+	switch {
+	case !u._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case u._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// update with primary key
+	const sqlstr = `UPDATE User SET ` +
+		`username = ?, email = ?, hashed_password = ?, is_2fa_enabled = ?, two_factor_secret = ?, created_at = ?, updated_at = ? ` +
+		`WHERE id = ?`
+	// run
+	logf(sqlstr, u.Username, u.Email, u.HashedPassword, u.Is2faEnabled, u.TwoFactorSecret, u.CreatedAt, u.UpdatedAt, u.ID)
+	if _, err := db.ExecContext(ctx, sqlstr, u.Username, u.Email, u.HashedPassword, u.Is2faEnabled, u.TwoFactorSecret, u.CreatedAt, u.UpdatedAt, u.ID); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// Save saves the [User] to the database.
+func (u *User) Save(ctx context.Context, db DB) error {
+	if u.Exists() {
+		return u.Update(ctx, db)
+	}
+	return u.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [User].
+func (u *User) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case u._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// upsert
+	const sqlstr = `INSERT INTO User (` +
+		`id, username, email, hashed_password, is_2fa_enabled, two_factor_secret, created_at, updated_at` +
+		`) VALUES (` +
+		`?, ?, ?, ?, ?, ?, ?, ?` +
+		`)` +
+		` ON CONFLICT (id) DO ` +
+		`UPDATE SET ` +
+		`username = EXCLUDED.username, email = EXCLUDED.email, hashed_password = EXCLUDED.hashed_password, is_2fa_enabled = EXCLUDED.is_2fa_enabled, two_factor_secret = EXCLUDED.two_factor_secret, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at `
+	// run
+	logf(sqlstr, u.ID, u.Username, u.Email, u.HashedPassword, u.Is2faEnabled, u.TwoFactorSecret, u.CreatedAt, u.UpdatedAt)
+	if _, err := db.ExecContext(ctx, sqlstr, u.ID, u.Username, u.Email, u.HashedPassword, u.Is2faEnabled, u.TwoFactorSecret, u.CreatedAt, u.UpdatedAt); err != nil {
+		return logerror(err)
+	}
+	// set exists
+	u._exists = true
+	return nil
+}
+
+// Delete deletes the [User] from the database.
+func (u *User) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !u._exists: // doesn't exist
+		return nil
+	case u._deleted: // deleted
+		return nil
+	}
+	// delete with single primary key
+	const sqlstr = `DELETE FROM User ` +
+		`WHERE id = ?`
+	// run
+	logf(sqlstr, u.ID)
+	if _, err := db.ExecContext(ctx, sqlstr, u.ID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	u._deleted = true
+	return nil
+}
+
+// UserKeysetPage retrieves a page of [User] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
+func UserKeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*User, *User, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT * FROM User 
+         WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Dynamically add filters from the `filters` map to the query
+	for field, value := range filters {
+		switch v := value.(type) {
+		case []int:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		case []string:
+			if len(v) > 0 {
+				placeholders := make([]string, len(v))
+				for i := range v {
+					placeholders[i] = "?"
+					args = append(args, v[i])
+				}
+				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
+			}
+		default:
+			// Handle NULL and NOT NULL checks
+			if value == nil {
+				query += fmt.Sprintf(" AND %s IS NULL", field)
+			} else if value == "NOT NULL" {
+				query += fmt.Sprintf(" AND %s IS NOT NULL", field)
+			} else {
+				query += fmt.Sprintf(" AND %s = ?", field)
+				args = append(args, value)
+			}
+		}
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Log the final query for debugging purposes
+	log.Printf("Executing query: %s with args: %v", query, args)
+
+	// Execute the query
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*User
+	var lastItem *User // Variable to store the last item
+
+	for rows.Next() {
+		u := User{
+			_exists: true,
+		}
+		if err := rows.Scan(
+			&u.ID, &u.Username, &u.Email, &u.HashedPassword, &u.Is2faEnabled, &u.TwoFactorSecret, &u.CreatedAt, &u.UpdatedAt,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &u)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// UserByID retrieves a row from 'User' as a [User].
+//
+// Generated from index 'sqlite_autoindex_User_1'.
+func UserByID(ctx context.Context, db DB, id int) (*User, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`id, username, email, hashed_password, is_2fa_enabled, two_factor_secret, created_at, updated_at ` +
+		`FROM User ` +
+		`WHERE id = ?`
+	// run
+	logf(sqlstr, id)
+	u := User{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, id).Scan(&u.ID, &u.Username, &u.Email, &u.HashedPassword, &u.Is2faEnabled, &u.TwoFactorSecret, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &u, nil
+}
+
+// UserByUsername retrieves a row from 'User' as a [User].
+//
+// Generated from index 'sqlite_autoindex_User_2'.
+func UserByUsername(ctx context.Context, db DB, username string) (*User, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`id, username, email, hashed_password, is_2fa_enabled, two_factor_secret, created_at, updated_at ` +
+		`FROM User ` +
+		`WHERE username = ?`
+	// run
+	logf(sqlstr, username)
+	u := User{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, username).Scan(&u.ID, &u.Username, &u.Email, &u.HashedPassword, &u.Is2faEnabled, &u.TwoFactorSecret, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &u, nil
+}
+
+// UserByEmail retrieves a row from 'User' as a [User].
+//
+// Generated from index 'sqlite_autoindex_User_3'.
+func UserByEmail(ctx context.Context, db DB, email string) (*User, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`id, username, email, hashed_password, is_2fa_enabled, two_factor_secret, created_at, updated_at ` +
+		`FROM User ` +
+		`WHERE email = ?`
+	// run
+	logf(sqlstr, email)
+	u := User{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, email).Scan(&u.ID, &u.Username, &u.Email, &u.HashedPassword, &u.Is2faEnabled, &u.TwoFactorSecret, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &u, nil
+}
+
+// ToProto converts the [User] to a [user.User].
+func (u *User) ToProto() (*user.User, error) {
+	m := &user.User{}
+	m.Id = int32(u.ID)
+	m.Username = u.Username
+	m.Email = u.Email
+	m.HashedPassword = u.HashedPassword
+	m.Is_2FaEnabled = u.Is2faEnabled
+	if u.TwoFactorSecret.Valid {
+		m.TwoFactorSecret = u.TwoFactorSecret.String
+	}
+	m.CreatedAt = timestamppb.New(u.CreatedAt.Time())
+	m.UpdatedAt = timestamppb.New(u.UpdatedAt.Time())
+	return m, nil
+}
+
+// FromProto sets the [User] from a [user.User].
+func (u *User) FromProto(m *user.User) error {
+	u.ID = int(m.Id)
+	u.Username = m.Username
+	u.Email = m.Email
+	u.HashedPassword = m.HashedPassword
+	u.Is2faEnabled = m.Is_2FaEnabled
+	if m.TwoFactorSecret != "" {
+		u.TwoFactorSecret = sql.NullString{String: m.TwoFactorSecret, Valid: true}
+	} else {
+		u.TwoFactorSecret = sql.NullString{}
+	}
+	if m.CreatedAt != nil {
+		u.CreatedAt = NewTime(m.CreatedAt.AsTime())
+	} else {
+		u.CreatedAt = Time{}
+	}
+	if m.UpdatedAt != nil {
+		u.UpdatedAt = NewTime(m.UpdatedAt.AsTime())
+	} else {
+		u.UpdatedAt = Time{}
+	}
+	return nil
+}
+
+// UserRepository reads and writes [User] rows, see
+// [NewSQLUserRepository] and [NewMemoryUserRepository].
+type UserRepository interface {
+	Insert(ctx context.Context, u *User) error
+	Update(ctx context.Context, u *User) error
+	Upsert(ctx context.Context, u *User) error
+	Delete(ctx context.Context, u *User) error
+	UserByID(ctx context.Context, id int) (*User, error)
+	UserByUsername(ctx context.Context, username string) (*User, error)
+	UserByEmail(ctx context.Context, email string) (*User, error)
+}
+
+// NewSQLUserRepository returns a [UserRepository] running the
+// queries of the [User] funcs on db.
+func NewSQLUserRepository(db DB) UserRepository {
+	return sqlUserRepository{db: db}
+}
+
+type sqlUserRepository struct {
+	db DB
+}
+
+func (repo sqlUserRepository) Insert(ctx context.Context, u *User) error {
+	return u.Insert(ctx, repo.db)
+}
+
+func (repo sqlUserRepository) Update(ctx context.Context, u *User) error {
+	return u.Update(ctx, repo.db)
+}
+
+func (repo sqlUserRepository) Upsert(ctx context.Context, u *User) error {
+	return u.Upsert(ctx, repo.db)
+}
+
+func (repo sqlUserRepository) Delete(ctx context.Context, u *User) error {
+	return u.Delete(ctx, repo.db)
+}
+
+func (repo sqlUserRepository) UserByID(ctx context.Context, id int) (*User, error) {
+	return UserByID(ctx, repo.db, id)
+}
+
+func (repo sqlUserRepository) UserByUsername(ctx context.Context, username string) (*User, error) {
+	return UserByUsername(ctx, repo.db, username)
+}
+
+func (repo sqlUserRepository) UserByEmail(ctx context.Context, email string) (*User, error) {
+	return UserByEmail(ctx, repo.db, email)
+}
+
+// NewMemoryUserRepository returns a [UserRepository] storing the
+// rows in memdb.
+func NewMemoryUserRepository(memdb *MemoryDB) UserRepository {
+	return memoryUserRepository{memdb: memdb}
+}
+
+type memoryUserRepository struct {
+	memdb *MemoryDB
+}
+
+func (repo memoryUserRepository) Insert(ctx context.Context, u *User) error {
+	switch {
+	case u._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case u._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("User", u.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	u.setMemoryRow(row)
+	u._exists = true
+	return nil
+}
+
+func (repo memoryUserRepository) Update(ctx context.Context, u *User) error {
+	switch {
+	case !u._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case u._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("User", u.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memoryUserRepository) Upsert(ctx context.Context, u *User) error {
+	switch {
+	case u._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("User", u.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	u._exists = true
+	return nil
+}
+
+func (repo memoryUserRepository) Delete(ctx context.Context, u *User) error {
+	switch {
+	case !u._exists: // doesn't exist
+		return nil
+	case u._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("User", u.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	u._deleted = true
+	return nil
+}
+
+func (repo memoryUserRepository) UserByID(ctx context.Context, id int) (*User, error) {
+	rows := repo.memdb.find("User", []string{"id"}, id)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	u := User{_exists: true}
+	u.setMemoryRow(rows[0])
+	return &u, nil
+}
+
+func (repo memoryUserRepository) UserByUsername(ctx context.Context, username string) (*User, error) {
+	rows := repo.memdb.find("User", []string{"username"}, username)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	u := User{_exists: true}
+	u.setMemoryRow(rows[0])
+	return &u, nil
+}
+
+func (repo memoryUserRepository) UserByEmail(ctx context.Context, email string) (*User, error) {
+	rows := repo.memdb.find("User", []string{"email"}, email)
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	u := User{_exists: true}
+	u.setMemoryRow(rows[0])
+	return &u, nil
+}
+
+// memoryRow returns the fields of the [User] by column.
+func (u *User) memoryRow() memoryRow {
+	return memoryRow{
+		"id":                u.ID,
+		"username":          u.Username,
+		"email":             u.Email,
+		"hashed_password":   u.HashedPassword,
+		"is_2fa_enabled":    u.Is2faEnabled,
+		"two_factor_secret": u.TwoFactorSecret,
+		"created_at":        u.CreatedAt,
+		"updated_at":        u.UpdatedAt,
+	}
+}
+
+// setMemoryRow sets the fields of the [User] from a row.
+func (u *User) setMemoryRow(row memoryRow) {
+	u.ID = row["id"].(int)
+	u.Username = row["username"].(string)
+	u.Email = row["email"].(string)
+	u.HashedPassword = row["hashed_password"].(string)
+	u.Is2faEnabled = row["is_2fa_enabled"].(bool)
+	u.TwoFactorSecret = row["two_factor_secret"].(sql.NullString)
+	u.CreatedAt = row["created_at"].(Time)
+	u.UpdatedAt = row["updated_at"].(Time)
+}
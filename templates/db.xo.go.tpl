@@ -146,6 +146,175 @@ func (err *ErrUpsertFailed) Unwrap() error {
 	return err.Err
 }
 
+// TxOptions are the options of a transaction run by [WithTx].
+type TxOptions struct {
+	// Isolation is the isolation level, the default of the database when zero.
+	Isolation sql.IsolationLevel
+	// ReadOnly runs a read-only transaction.
+	ReadOnly bool
+	// Retry is the retry policy of the transaction.
+	Retry RetryPolicy
+}
+
+// RetryPolicy is when and how often [WithTx] runs a transaction again after
+// it failed.
+type RetryPolicy struct {
+	// MaxRetries is the number of times the transaction is run again, none
+	// when zero.
+	MaxRetries int
+	// Backoff returns the delay before retry n, starting at 1. When nil, the
+	// delay doubles from 10ms.
+	Backoff func(n int) time.Duration
+	// Retryable reports whether the transaction is run again after err,
+	// [IsRetryable] when nil.
+	Retryable func(err error) bool
+}
+
+// DefaultTxOptions are the options of [WithTx] when none are passed: the
+// isolation level of the database and 3 retries.
+var DefaultTxOptions = TxOptions{
+	Retry: RetryPolicy{MaxRetries: 3},
+}
+
+// Beginner is a database beginning transactions, such as [database/sql.DB]
+// and [database/sql.Conn].
+type Beginner interface {
+	BeginTx(context.Context, *sql.TxOptions) (*sql.Tx, error)
+}
+
+// Tx is a transaction run by [WithTx], which commits or rolls it back: the
+// func must not call Commit or Rollback. A Tx is a [DB] for the models and the
+// NewSQL*Repository funcs, and must not be used concurrently with WithTx.
+type Tx struct {
+	*sql.Tx
+	savepoints int
+}
+
+// WithTx runs fn in a transaction of db, committed when fn returns nil and
+// rolled back when it returns an error or panics.
+//
+// When db is a [Beginner], a transaction is begun with opts, or
+// [DefaultTxOptions] when nil. The transaction is run again, from a new
+// transaction, while fn or the commit fail with an error retryable by the retry
+// policy, such as a deadlock, so fn must not have other side effects.
+//
+// When db is already a [Tx] or a [database/sql.Tx], as in a nested call, fn
+// runs within a savepoint of the transaction which is rolled back to on error
+// or panic, and opts is ignored. Errors are then retried by the outermost
+// WithTx.
+func WithTx(ctx context.Context, db DB, opts *TxOptions, fn func(tx *Tx) error) error {
+	switch x := db.(type) {
+	case *Tx:
+		return x.savepoint(ctx, fn)
+	case *sql.Tx:
+		return (&Tx{Tx: x}).savepoint(ctx, fn)
+	case Beginner:
+		if opts == nil {
+			opts = &DefaultTxOptions
+		}
+		retryable := opts.Retry.Retryable
+		if retryable == nil {
+			retryable = IsRetryable
+		}
+		backoff := opts.Retry.Backoff
+		if backoff == nil {
+			backoff = func(n int) time.Duration {
+				return 10 * time.Millisecond << (n - 1)
+			}
+		}
+		for n := 1; ; n++ {
+			err := runTx(ctx, x, opts, fn)
+			if err == nil || n > opts.Retry.MaxRetries || !retryable(err) {
+				return err
+			}
+			logf("WithTx: retry %d: %v", n, err)
+			select {
+			case <-ctx.Done():
+				return logerror(ctx.Err())
+			case <-time.After(backoff(n)):
+			}
+		}
+	}
+	return logerror(fmt.Errorf("WithTx: %T doesn't begin transactions", db))
+}
+
+// runTx runs fn in a new transaction of db.
+func runTx(ctx context.Context, db Beginner, opts *TxOptions, fn func(tx *Tx) error) error {
+	sqlTx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: opts.Isolation, ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return logerror(err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = sqlTx.Rollback()
+			panic(p)
+		}
+	}()
+	if err := fn(&Tx{Tx: sqlTx}); err != nil {
+		if rbErr := sqlTx.Rollback(); rbErr != nil {
+			return logerror(errors.Join(err, rbErr))
+		}
+		return err
+	}
+	if err := sqlTx.Commit(); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// savepoint runs fn within a savepoint of the transaction.
+func (tx *Tx) savepoint(ctx context.Context, fn func(tx *Tx) error) error {
+	tx.savepoints++
+	defer func() { tx.savepoints-- }()
+	name := fmt.Sprintf("xo_savepoint_%d", tx.savepoints)
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return logerror(err)
+	}
+	// The savepoint is released after rolling back to it, which keeps it
+	rollback := func() error {
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = rollback()
+			panic(p)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rbErr := rollback(); rbErr != nil {
+			return logerror(errors.Join(err, rbErr))
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// IsRetryable reports whether err is a transient error after which a
+// transaction can succeed when run again:
+{{- if driver "mysql" }} a deadlock (error 1213).
+func IsRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1213
+}
+{{- else if driver "sqlite3" }} the database being busy
+// (SQLITE_BUSY).
+func IsRetryable(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrBusy
+}
+{{- else }} none are known for this database.
+func IsRetryable(err error) bool {
+	return false
+}
+{{- end }}
+
 {{ if driver "sqlite3" -}}
 // ErrInvalidTime is the invalid Time error.
 type ErrInvalidTime string
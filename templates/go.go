@@ -1,5 +1,3 @@
-//go:build xotpl
-
 package gotpl
 
 import (
@@ -205,6 +203,22 @@ func Init(ctx context.Context, f func(xo.TemplateType)) error {
 				Desc:       "enables legacy v1 template funcs",
 				Default:    "false",
 			},
+			{
+				ContextKey: GeneratedKey,
+				Type:       "[]string",
+				Desc:       "generated (read-only) columns, as <table>.<column>",
+			},
+			{
+				ContextKey: OnDeleteKey,
+				Type:       "[]string",
+				Desc:       "foreign key ON DELETE actions, as <table>.<constraint>=<action>",
+			},
+			{
+				ContextKey: UnqualifiedKey,
+				Type:       "bool",
+				Desc:       "leave table names in queries unqualified by the schema name",
+				Default:    "false",
+			},
 			{
 				ContextKey: OracleTypeKey,
 				Type:       "string",
@@ -229,12 +243,12 @@ func Init(ctx context.Context, f func(xo.TemplateType)) error {
 			return ctx
 		},
 		Order: func(ctx context.Context, mode string) []string {
-			base := []string{"header", "db"}
+			base := []string{"header", "db", "memory"}
 			switch mode {
 			case "query":
 				return append(base, "typedef", "query")
 			case "schema":
-				return append(base, "enum", "proc", "typedef", "query", "index", "foreignkey")
+				return append(base, "enum", "proc", "typedef", "query", "index", "foreignkey", "proto", "repository")
 			}
 			return nil
 		},
@@ -257,6 +271,16 @@ func Init(ctx context.Context, f func(xo.TemplateType)) error {
 				if xo.Single(ctx) == "" {
 					files["db.xo.go"] = true
 				}
+				if mode == "schema" {
+					emit(xo.Template{
+						Partial: "memory",
+						Dest:    "memory.xo.go",
+						Data:    convertMemoryTables(ctx, set),
+					})
+					if xo.Single(ctx) == "" {
+						files["memory.xo.go"] = true
+					}
+				}
 			}
 			if Append(ctx) {
 				for filename := range files {
@@ -487,8 +511,10 @@ func buildQueryName(query xo.Query) string {
 // emitSchema emits the xo schema for the template set.
 func emitSchema(ctx context.Context, schema xo.Schema, emit func(xo.Template)) error {
 	// emit enums
+	enums := make(map[string]bool)
 	for _, e := range schema.Enums {
 		enum := convertEnum(e)
+		enums[enum.GoName] = true
 		emit(xo.Template{
 			Partial:  "enum",
 			Dest:     strings.ToLower(enum.GoName) + ext,
@@ -566,6 +592,34 @@ func emitSchema(ctx context.Context, schema xo.Schema, emit func(xo.Template)) e
 				Data:     fkey,
 			})
 		}
+		// emit proto converters
+		if proto, ok := Protos(ctx)[t.Name]; ok {
+			converter, err := convertProto(table, proto, enums)
+			if err != nil {
+				return err
+			}
+			emit(xo.Template{
+				Dest:     strings.ToLower(table.GoName) + ext,
+				Partial:  "proto",
+				SortType: table.Type,
+				SortName: table.GoName,
+				Data:     converter,
+			})
+		}
+		// emit repository
+		if t.Type == "table" && len(table.PrimaryKeys) != 0 {
+			repository, err := convertRepository(ctx, table, t)
+			if err != nil {
+				return err
+			}
+			emit(xo.Template{
+				Dest:     strings.ToLower(table.GoName) + ext,
+				Partial:  "repository",
+				SortType: table.Type,
+				SortName: table.GoName,
+				Data:     repository,
+			})
+		}
 	}
 	return nil
 }
@@ -650,6 +704,7 @@ func convertTable(ctx context.Context, t xo.Table) (Table, error) {
 		if err != nil {
 			return Table{}, err
 		}
+		f.IsGenerated = contains(Generated(ctx), t.Name+"."+z.Name)
 		cols = append(cols, f)
 		if z.IsPrimary {
 			pkCols = append(pkCols, f)
@@ -795,22 +850,23 @@ const ext = ".xo.go"
 
 // Funcs is a set of template funcs.
 type Funcs struct {
-	driver     string
-	schema     string
-	nth        func(int) string
-	first      bool
-	pkg        string
-	tags       []string
-	imports    []string
-	conflict   string
-	custom     string
-	escSchema  bool
-	escTable   bool
-	escColumn  bool
-	fieldtag   *template.Template
-	context    string
-	inject     string
-	oracleType string
+	driver      string
+	schema      string
+	unqualified bool
+	nth         func(int) string
+	first       bool
+	pkg         string
+	tags        []string
+	imports     []string
+	conflict    string
+	custom      string
+	escSchema   bool
+	escTable    bool
+	escColumn   bool
+	fieldtag    *template.Template
+	context     string
+	inject      string
+	oracleType  string
 	// knownTypes is the collection of known Go types.
 	knownTypes map[string]bool
 	// shorts is the collection of Go style short names for types, mainly
@@ -818,6 +874,17 @@ type Funcs struct {
 	shorts map[string]string
 }
 
+// nthParam returns the func rendering the nth query parameter. SQLite queries use positional ? parameters
+// like MySQL, so they don't depend on the numbered parameters of the driver.
+func nthParam(ctx context.Context) (func(int) string, error) {
+	if driver, _, _ := xo.DriverDbSchema(ctx); driver == "sqlite3" {
+		return func(int) string {
+			return "?"
+		}, nil
+	}
+	return loader.NthParam(ctx)
+}
+
 // NewFuncs creates custom template funcs for the context.
 func NewFuncs(ctx context.Context) (template.FuncMap, error) {
 	first := !NotFirst(ctx)
@@ -836,29 +903,30 @@ func NewFuncs(ctx context.Context) (template.FuncMap, error) {
 		inject = string(buf)
 	}
 	driver, _, schema := xo.DriverDbSchema(ctx)
-	nth, err := loader.NthParam(ctx)
+	nth, err := nthParam(ctx)
 	if err != nil {
 		return nil, err
 	}
 	funcs := &Funcs{
-		first:      first,
-		driver:     driver,
-		schema:     schema,
-		nth:        nth,
-		pkg:        Pkg(ctx),
-		tags:       Tags(ctx),
-		imports:    Imports(ctx),
-		conflict:   Conflict(ctx),
-		custom:     Custom(ctx),
-		escSchema:  Esc(ctx, "schema"),
-		escTable:   Esc(ctx, "table"),
-		escColumn:  Esc(ctx, "column"),
-		fieldtag:   fieldtag,
-		context:    Context(ctx),
-		inject:     inject,
-		oracleType: OracleType(ctx),
-		knownTypes: KnownTypes(ctx),
-		shorts:     Shorts(ctx),
+		first:       first,
+		driver:      driver,
+		schema:      schema,
+		unqualified: Unqualified(ctx),
+		nth:         nth,
+		pkg:         Pkg(ctx),
+		tags:        Tags(ctx),
+		imports:     Imports(ctx),
+		conflict:    Conflict(ctx),
+		custom:      Custom(ctx),
+		escSchema:   Esc(ctx, "schema"),
+		escTable:    Esc(ctx, "table"),
+		escColumn:   Esc(ctx, "column"),
+		fieldtag:    fieldtag,
+		context:     Context(ctx),
+		inject:      inject,
+		oracleType:  OracleType(ctx),
+		knownTypes:  KnownTypes(ctx),
+		shorts:      Shorts(ctx),
 	}
 	return funcs.FuncMap(), nil
 }
@@ -896,14 +964,18 @@ func (f *Funcs) FuncMap() template.FuncMap {
 		"logf_pkeys":          f.logf_pkeys,
 		"logf_update":         f.logf_update,
 		// type
-		"names":        f.names,
-		"names_all":    f.names_all,
-		"names_ignore": f.names_ignore,
-		"params":       f.params,
-		"zero":         f.zero,
-		"type":         f.typefn,
-		"field":        f.field,
-		"short":        f.short,
+		"names":             f.names,
+		"names_all":         f.names_all,
+		"names_ignore":      f.names_ignore,
+		"params":            f.params,
+		"zero":              f.zero,
+		"type":              f.typefn,
+		"field":             f.field,
+		"short":             f.short,
+		"proto_to":          f.proto_to,
+		"proto_from":        f.proto_from,
+		"repository_writes": f.repository_writes,
+		"sql_names":         f.sql_names,
 		// sqlstr funcs
 		"querystr": f.querystr,
 		"sqlstr":   f.sqlstr,
@@ -942,6 +1014,8 @@ func (f *Funcs) schemafn(names ...string) string {
 	}
 	n := strings.Join(names, ".")
 	switch {
+	case f.unqualified && n != "":
+		return n
 	case s == "" && n == "":
 		return ""
 	case f.driver == "sqlite3" && n == "":
@@ -1205,6 +1279,8 @@ func (f *Funcs) db_prefix(name string, skip bool, vs ...interface{}) string {
 			params = append(params, x)
 		case Table:
 			prefix = f.short(x.GoName) + "."
+			// generated columns are computed by the database
+			ignore = generatedNames(x)
 			// skip primary keys
 			if skip {
 				for _, field := range x.Fields {
@@ -1233,6 +1309,7 @@ func (f *Funcs) db_update(name string, v interface{}) string {
 	switch x := v.(type) {
 	case Table:
 		prefix := f.short(x.GoName) + "."
+		ignore = generatedNames(x)
 		for _, pk := range x.PrimaryKeys {
 			ignore = append(ignore, pk.GoName)
 		}
@@ -1300,6 +1377,7 @@ func (f *Funcs) logf(v interface{}, ignore ...interface{}) string {
 	// add fields
 	switch x := v.(type) {
 	case Table:
+		ignoreNames = append(ignoreNames, generatedNames(x)...)
 		p = append(p, f.names_ignore(f.short(x.GoName)+".", x, ignoreNames...))
 	default:
 		return fmt.Sprintf("[[ UNSUPPORTED TYPE 12: %T ]]", v)
@@ -1313,6 +1391,7 @@ func (f *Funcs) logf_update(v interface{}) string {
 	switch x := v.(type) {
 	case Table:
 		prefix := f.short(x.GoName) + "."
+		ignore = generatedNames(x)
 		for _, pk := range x.PrimaryKeys {
 			ignore = append(ignore, pk.GoName)
 		}
@@ -1323,6 +1402,18 @@ func (f *Funcs) logf_update(v interface{}) string {
 	return fmt.Sprintf("logf(%s)", strings.Join(p, ", "))
 }
 
+// generatedNames returns the Go names of the generated columns of a table,
+// which are computed by the database and never written.
+func generatedNames(t Table) []string {
+	var names []string
+	for _, field := range t.Fields {
+		if field.IsGenerated {
+			names = append(names, field.GoName)
+		}
+	}
+	return names
+}
+
 // names generates a list of names.
 func (f *Funcs) namesfn(all bool, prefix string, z ...interface{}) string {
 	var names []string
@@ -1453,7 +1544,7 @@ func (f *Funcs) sqlstr(typ string, v interface{}) string {
 }
 
 // sqlstr_insert_base builds an INSERT query
-// If not all, sequence columns are skipped.
+// If not all, sequence columns are skipped. Generated columns are always skipped.
 func (f *Funcs) sqlstr_insert_base(all bool, v interface{}) []string {
 	switch x := v.(type) {
 	case Table:
@@ -1461,7 +1552,7 @@ func (f *Funcs) sqlstr_insert_base(all bool, v interface{}) []string {
 		var n int
 		var fields, vals []string
 		for _, z := range x.Fields {
-			if z.IsSequence && !all {
+			if (z.IsSequence && !all) || z.IsGenerated {
 				continue
 			}
 			fields, vals = append(fields, f.colname(z)), append(vals, f.nth(n))
@@ -1493,7 +1584,7 @@ func (f *Funcs) sqlstr_insert(v interface{}) []string {
 		for _, field := range x.Fields {
 			if field.IsSequence {
 				seq = field
-			} else {
+			} else if !field.IsGenerated {
 				count++
 			}
 		}
@@ -1534,7 +1625,7 @@ func (f *Funcs) sqlstr_update_base(prefix string, v interface{}) (int, []string)
 		var n int
 		var list []string
 		for _, z := range x.Fields {
-			if z.IsPrimary {
+			if z.IsPrimary || z.IsGenerated {
 				continue
 			}
 			name, param := f.colname(z), f.nth(n)
@@ -1617,7 +1708,7 @@ func (f *Funcs) sqlstr_upsert_mysql(v interface{}) []string {
 		var list []string
 		i := len(x.Fields)
 		for _, z := range x.Fields {
-			if z.IsSequence {
+			if z.IsSequence || z.IsGenerated {
 				continue
 			}
 			name := f.colname(z)
@@ -2090,27 +2181,30 @@ func nameContext(context bool, name string) string {
 
 // Context keys.
 var (
-	AppendKey     xo.ContextKey = "append"
-	KnownTypesKey xo.ContextKey = "known-types"
-	ShortsKey     xo.ContextKey = "shorts"
-	NotFirstKey   xo.ContextKey = "not-first"
-	Int32Key      xo.ContextKey = "int32"
-	Uint32Key     xo.ContextKey = "uint32"
-	ArrayModeKey  xo.ContextKey = "array-mode"
-	PkgKey        xo.ContextKey = "pkg"
-	TagKey        xo.ContextKey = "tag"
-	ImportKey     xo.ContextKey = "import"
-	UUIDKey       xo.ContextKey = "uuid"
-	CustomKey     xo.ContextKey = "custom"
-	ConflictKey   xo.ContextKey = "conflict"
-	InitialismKey xo.ContextKey = "initialism"
-	EscKey        xo.ContextKey = "esc"
-	FieldTagKey   xo.ContextKey = "field-tag"
-	ContextKey    xo.ContextKey = "context"
-	InjectKey     xo.ContextKey = "inject"
-	InjectFileKey xo.ContextKey = "inject-file"
-	LegacyKey     xo.ContextKey = "legacy"
-	OracleTypeKey xo.ContextKey = "oracle-type"
+	AppendKey      xo.ContextKey = "append"
+	KnownTypesKey  xo.ContextKey = "known-types"
+	ShortsKey      xo.ContextKey = "shorts"
+	NotFirstKey    xo.ContextKey = "not-first"
+	Int32Key       xo.ContextKey = "int32"
+	Uint32Key      xo.ContextKey = "uint32"
+	ArrayModeKey   xo.ContextKey = "array-mode"
+	PkgKey         xo.ContextKey = "pkg"
+	TagKey         xo.ContextKey = "tag"
+	ImportKey      xo.ContextKey = "import"
+	UUIDKey        xo.ContextKey = "uuid"
+	CustomKey      xo.ContextKey = "custom"
+	ConflictKey    xo.ContextKey = "conflict"
+	InitialismKey  xo.ContextKey = "initialism"
+	EscKey         xo.ContextKey = "esc"
+	FieldTagKey    xo.ContextKey = "field-tag"
+	ContextKey     xo.ContextKey = "context"
+	InjectKey      xo.ContextKey = "inject"
+	InjectFileKey  xo.ContextKey = "inject-file"
+	LegacyKey      xo.ContextKey = "legacy"
+	OracleTypeKey  xo.ContextKey = "oracle-type"
+	GeneratedKey   xo.ContextKey = "generated"
+	OnDeleteKey    xo.ContextKey = "on-delete"
+	UnqualifiedKey xo.ContextKey = "unqualified"
 )
 
 // Append returns append from the context.
@@ -2248,6 +2342,24 @@ func OracleType(ctx context.Context) string {
 	return s
 }
 
+// Generated returns generated from the context.
+func Generated(ctx context.Context) []string {
+	v, _ := ctx.Value(GeneratedKey).([]string)
+	return v
+}
+
+// OnDelete returns on-delete from the context.
+func OnDelete(ctx context.Context) []string {
+	v, _ := ctx.Value(OnDeleteKey).([]string)
+	return v
+}
+
+// Unqualified returns unqualified from the context.
+func Unqualified(ctx context.Context) bool {
+	b, _ := ctx.Value(UnqualifiedKey).(bool)
+	return b
+}
+
 // addInitialisms adds snaker initialisms from the context.
 func addInitialisms(ctx context.Context) error {
 	z := ctx.Value(InitialismKey)
@@ -2344,13 +2456,14 @@ type Index struct {
 
 // Field is a field template.
 type Field struct {
-	GoName     string
-	SQLName    string
-	Type       string
-	Zero       string
-	IsPrimary  bool
-	IsSequence bool
-	Comment    string
+	GoName      string
+	SQLName     string
+	Type        string
+	Zero        string
+	IsPrimary   bool
+	IsSequence  bool
+	IsGenerated bool
+	Comment     string
 }
 
 // QueryParam is a custom query parameter template.
@@ -2394,7 +2507,7 @@ func (v PackageImport) String() string {
 
 // addLegacyFuncs adds the legacy template funcs.
 func addLegacyFuncs(ctx context.Context, funcs template.FuncMap) {
-	nth, err := loader.NthParam(ctx)
+	nth, err := nthParam(ctx)
 	if err != nil {
 		return
 	}
@@ -27,6 +27,10 @@ import (
 {{- if driver "postgres" }}
 	"github.com/lib/pq"
 	"github.com/lib/pq/hstore"
+{{ end }}{{- if driver "mysql" }}
+	"github.com/go-sql-driver/mysql"
+{{ end }}{{- if driver "sqlite3" }}
+	"github.com/mattn/go-sqlite3"
 {{ end }}{{ range imports }}
 	{{ with .Alias }}{{ . }} {{ end }}{{ .Pkg }}
 {{ end }}
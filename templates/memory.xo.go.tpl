@@ -0,0 +1,352 @@
+{{ define "memory" -}}
+{{- $tables := .Data -}}
+// MemoryDB stores the rows of the repositories returned by the NewMemory*Repository
+// funcs in memory, so code using the repositories can be tested without a
+// database. Like the database, it assigns auto-increment keys and enforces
+// primary keys, unique indexes and foreign keys, including their ON DELETE
+// actions. Generated columns are not computed and CHECK constraints are not
+// evaluated.
+//
+// A MemoryDB is safe for concurrent use.
+type MemoryDB struct {
+	mu   sync.RWMutex
+	rows map[string][]memoryRow
+	next map[string]int64
+}
+
+// NewMemoryDB creates an empty [MemoryDB].
+func NewMemoryDB() *MemoryDB {
+	return &MemoryDB{
+		rows: make(map[string][]memoryRow),
+		next: make(map[string]int64),
+	}
+}
+
+// Memory constraint error values.
+const (
+	// ErrDuplicateKey is the duplicate key error.
+	ErrDuplicateKey Error = "duplicate key"
+	// ErrForeignKey is the foreign key violation error.
+	ErrForeignKey Error = "foreign key violation"
+)
+
+// ErrConstraint is the error of a write to a [MemoryDB] violating a constraint
+// of a table, either [ErrDuplicateKey] or [ErrForeignKey].
+type ErrConstraint struct {
+	Table      string
+	Constraint string
+	Err        error
+}
+
+// Error satisfies the error interface.
+func (err *ErrConstraint) Error() string {
+	return fmt.Sprintf("%s: %s: %v", err.Table, err.Constraint, err.Err)
+}
+
+// Unwrap satisfies the unwrap interface.
+func (err *ErrConstraint) Unwrap() error {
+	return err.Err
+}
+
+// memoryRow is a row of a [MemoryDB], the values of the model fields by column.
+type memoryRow map[string]interface{}
+
+// memoryTable is the constraints of a table.
+type memoryTable struct {
+	primaryKey  []string
+	sequence    string
+	uniques     []memoryIndex
+	foreignKeys []memoryForeignKey
+}
+
+// memoryIndex is a unique index.
+type memoryIndex struct {
+	name    string
+	columns []string
+}
+
+// memoryForeignKey is a foreign key.
+type memoryForeignKey struct {
+	name       string
+	columns    []string
+	refTable   string
+	refColumns []string
+	onDelete   string
+}
+
+// memoryTables are the constraints of the tables by name.
+var memoryTables = map[string]memoryTable{
+{{- range $tables }}
+	"{{ .SQLName }}": {
+		primaryKey: {{ printf "%#v" .PrimaryKeys }},
+{{- if .Sequence }}
+		sequence:   "{{ .Sequence }}",
+{{- end }}
+{{- if .Uniques }}
+		uniques: []memoryIndex{
+{{- range .Uniques }}
+			{name: "{{ .SQLName }}", columns: {{ printf "%#v" .Columns }}},
+{{- end }}
+		},
+{{- end }}
+{{- if .ForeignKeys }}
+		foreignKeys: []memoryForeignKey{
+{{- range .ForeignKeys }}
+			{name: "{{ .SQLName }}", columns: {{ printf "%#v" .Columns }}, refTable: "{{ .RefTable }}", refColumns: {{ printf "%#v" .RefColumns }}{{ with .OnDelete }}, onDelete: "{{ . }}"{{ end }}},
+{{- end }}
+		},
+{{- end }}
+	},
+{{- end }}
+}
+
+// insert adds a row to the table and returns it, with the auto-increment key
+// assigned.
+func (db *MemoryDB) insert(table string, row memoryRow) (memoryRow, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	row = row.clone()
+	t := memoryTables[table]
+	if t.sequence != "" {
+		db.next[table]++
+		row[t.sequence] = reflect.ValueOf(db.next[table]).Convert(reflect.TypeOf(row[t.sequence])).Interface()
+	}
+	if err := db.check(table, row, -1); err != nil {
+		return nil, err
+	}
+	db.rows[table] = append(db.rows[table], row)
+	db.advance(table, row)
+	return row.clone(), nil
+}
+
+// update replaces the row of the table with the primary key of row, if any.
+func (db *MemoryDB) update(table string, row memoryRow) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	i := db.index(table, row)
+	if i < 0 {
+		return nil
+	}
+	return db.replace(table, i, row.clone())
+}
+
+// upsert replaces the row of the table with the primary key of row, or adds
+// row when there is none.
+func (db *MemoryDB) upsert(table string, row memoryRow) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	row = row.clone()
+	if i := db.index(table, row); i >= 0 {
+		return db.replace(table, i, row)
+	}
+	if err := db.check(table, row, -1); err != nil {
+		return err
+	}
+	db.rows[table] = append(db.rows[table], row)
+	db.advance(table, row)
+	return nil
+}
+
+// delete removes the row of the table with the primary key of row, if any,
+// and applies the ON DELETE actions of the foreign keys referencing it.
+func (db *MemoryDB) delete(table string, row memoryRow) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	i := db.index(table, row)
+	if i < 0 {
+		return nil
+	}
+	// The actions are applied to a copy, kept when none fails
+	rows := make(map[string][]memoryRow, len(db.rows))
+	for name, r := range db.rows {
+		rows[name] = append([]memoryRow(nil), r...)
+	}
+	if err := memoryDelete(rows, table, i); err != nil {
+		return err
+	}
+	db.rows = rows
+	return nil
+}
+
+// find returns the rows of the table with the values in the columns, NULL
+// matching no row.
+func (db *MemoryDB) find(table string, columns []string, values ...interface{}) []memoryRow {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	key := make(memoryRow, len(columns))
+	for i, column := range columns {
+		key[column] = values[i]
+	}
+	var res []memoryRow
+	for _, row := range db.rows[table] {
+		if memoryMatch(row, columns, key, columns) {
+			res = append(res, row.clone())
+		}
+	}
+	return res
+}
+
+// index returns the position of the row of the table with the primary key of
+// row, -1 when there is none.
+func (db *MemoryDB) index(table string, row memoryRow) int {
+	primaryKey := memoryTables[table].primaryKey
+	for i, r := range db.rows[table] {
+		if memoryMatch(r, primaryKey, row, primaryKey) {
+			return i
+		}
+	}
+	return -1
+}
+
+// replace replaces the row at position i of the table, failing when a row
+// references the values it changes.
+func (db *MemoryDB) replace(table string, i int, row memoryRow) error {
+	if err := db.check(table, row, i); err != nil {
+		return err
+	}
+	old := db.rows[table][i]
+	for name, t := range memoryTables {
+		for _, fk := range t.foreignKeys {
+			if fk.refTable != table || memoryMatch(old, fk.refColumns, row, fk.refColumns) {
+				continue
+			}
+			for _, r := range db.rows[name] {
+				if memoryMatch(r, fk.columns, old, fk.refColumns) {
+					return &ErrConstraint{Table: name, Constraint: fk.name, Err: ErrForeignKey}
+				}
+			}
+		}
+	}
+	db.rows[table][i] = row
+	db.advance(table, row)
+	return nil
+}
+
+// check checks the unique indexes of the table against the other rows than
+// the one at position skip, and the foreign keys of row.
+func (db *MemoryDB) check(table string, row memoryRow, skip int) error {
+	t := memoryTables[table]
+	for _, unique := range t.uniques {
+		for i, r := range db.rows[table] {
+			if i != skip && memoryMatch(r, unique.columns, row, unique.columns) {
+				return &ErrConstraint{Table: table, Constraint: unique.name, Err: ErrDuplicateKey}
+			}
+		}
+	}
+	for _, fk := range t.foreignKeys {
+		if memoryNull(row, fk.columns) {
+			continue
+		}
+		// A row may reference itself, the row it replaces is skipped
+		found := fk.refTable == table && memoryMatch(row, fk.refColumns, row, fk.columns)
+		for i, r := range db.rows[fk.refTable] {
+			if found {
+				break
+			}
+			found = (fk.refTable != table || i != skip) && memoryMatch(r, fk.refColumns, row, fk.columns)
+		}
+		if !found {
+			return &ErrConstraint{Table: table, Constraint: fk.name, Err: ErrForeignKey}
+		}
+	}
+	return nil
+}
+
+// advance moves the auto-increment key of the table past the key of row.
+func (db *MemoryDB) advance(table string, row memoryRow) {
+	t := memoryTables[table]
+	if t.sequence == "" {
+		return
+	}
+	if id, ok := memoryValue(row[t.sequence]).(int64); ok && id > db.next[table] {
+		db.next[table] = id
+	}
+}
+
+// memoryDelete removes the row at position i of the table from rows and
+// applies the ON DELETE actions of the foreign keys referencing it.
+func memoryDelete(rows map[string][]memoryRow, table string, i int) error {
+	row := rows[table][i]
+	rows[table] = append(rows[table][:i:i], rows[table][i+1:]...)
+	for name, t := range memoryTables {
+		for _, fk := range t.foreignKeys {
+			if fk.refTable != table {
+				continue
+			}
+			for j := 0; j < len(rows[name]); j++ {
+				r := rows[name][j]
+				if !memoryMatch(r, fk.columns, row, fk.refColumns) {
+					continue
+				}
+				switch fk.onDelete {
+				case "CASCADE":
+					if err := memoryDelete(rows, name, j); err != nil {
+						return err
+					}
+					// Rows before j may have been removed by the cascade
+					j = -1
+				case "SET NULL":
+					r = r.clone()
+					for _, column := range fk.columns {
+						r[column] = reflect.Zero(reflect.TypeOf(r[column])).Interface()
+					}
+					rows[name][j] = r
+				default:
+					return &ErrConstraint{Table: name, Constraint: fk.name, Err: ErrForeignKey}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// memoryMatch reports whether the values of the columns of a equal the values
+// of the other columns of b, none being NULL.
+func memoryMatch(a memoryRow, columns []string, b memoryRow, other []string) bool {
+	for i, column := range columns {
+		x, y := memoryValue(a[column]), memoryValue(b[other[i]])
+		if x == nil || y == nil || x != y {
+			return false
+		}
+	}
+	return true
+}
+
+// memoryNull reports whether a value of the columns of row is NULL.
+func memoryNull(row memoryRow, columns []string) bool {
+	for _, column := range columns {
+		if memoryValue(row[column]) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// memoryValue returns the value of a field the way the database compares it,
+// nil for NULL.
+func memoryValue(v interface{}) interface{} {
+	value, err := driver.DefaultParameterConverter.ConvertValue(v)
+	if err != nil {
+		return v
+	}
+	switch x := value.(type) {
+	case []byte:
+		return string(x)
+	case time.Time:
+		return x.UTC()
+	}
+	return value
+}
+
+// clone copies the row.
+func (row memoryRow) clone() memoryRow {
+	c := make(memoryRow, len(row))
+	for column, v := range row {
+		if b, ok := v.([]byte); ok {
+			v = append([]byte(nil), b...)
+		}
+		c[column] = v
+	}
+	return c
+}
+{{ end }}
@@ -0,0 +1,458 @@
+package gotpl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	xo "github.com/xo/xo/types"
+)
+
+// ProtoKey is the context key of the proto messages of the tables, a map[string]Proto by table name. The
+// models of these tables get ToProto and FromProto methods converting from and to the message.
+const ProtoKey xo.ContextKey = "proto"
+
+// Protos returns the proto messages of the tables from the context.
+func Protos(ctx context.Context) map[string]Proto {
+	v, _ := ctx.Value(ProtoKey).(map[string]Proto)
+	return v
+}
+
+// Proto describes the proto message a table is generated from. Go types are qualified with the import
+// alias of their package, e.g. user.Customer, the packages are passed with ImportKey.
+type Proto struct {
+	Type   string
+	Fields []ProtoField
+	Oneofs []ProtoOneof
+}
+
+// ProtoField is the message field of a column.
+type ProtoField struct {
+	Column string
+	// GoName is the name of the message field, or of the wrapper field for oneof variants.
+	GoName string
+	// Kind is the Go type of scalar fields (bool, int32, int64, uint32, uint64, float32, float64, string
+	// or bytes), enum, timestamp for google.protobuf.Timestamp, or message for messages stored as JSON.
+	Kind string
+	// Type is the Go type of enum and message fields.
+	Type string
+	// Optional is set for proto3 optional scalars, which are pointers.
+	Optional bool
+	// Oneof is the name of the oneof field of variants, Case the proto name of the variant and Wrapper the
+	// Go type wrapping it.
+	Oneof   string
+	Case    string
+	Wrapper string
+}
+
+// ProtoOneof is a oneof group stored as a discriminator column holding the proto name of the set variant.
+type ProtoOneof struct {
+	Column string
+	GoName string
+	Cases  []ProtoField
+}
+
+// ProtoTable is the proto converter template.
+type ProtoTable struct {
+	Table Table
+	Proto Proto
+	enums map[string]bool
+}
+
+// convertProto checks that every column of the table can be converted from and to its message field.
+func convertProto(table Table, proto Proto, enums map[string]bool) (ProtoTable, error) {
+	p := ProtoTable{Table: table, Proto: proto, enums: enums}
+	if _, err := p.toProto("x"); err != nil {
+		return ProtoTable{}, fmt.Errorf("table %s: %w", table.SQLName, err)
+	}
+	if _, err := p.fromProto("x"); err != nil {
+		return ProtoTable{}, fmt.Errorf("table %s: %w", table.SQLName, err)
+	}
+	return p, nil
+}
+
+// proto_to returns the statements of ToProto, setting the message m from the model.
+func (f *Funcs) proto_to(p ProtoTable) []string {
+	lines, err := p.toProto(f.short(p.Table))
+	if err != nil {
+		return []string{fmt.Sprintf("[[ %v ]]", err)}
+	}
+	return lines
+}
+
+// proto_from returns the statements of FromProto, setting the model from the message m.
+func (f *Funcs) proto_from(p ProtoTable) []string {
+	lines, err := p.fromProto(f.short(p.Table))
+	if err != nil {
+		return []string{fmt.Sprintf("[[ %v ]]", err)}
+	}
+	return lines
+}
+
+// modelType is the Go type of a model field, split into the type of the value and how NULL is represented
+type modelType struct {
+	base    string // Go type of the value
+	value   string // Field of the sql.Null* (or Null enum) type holding the value
+	pointer bool   // NULL is nil
+}
+
+func (t modelType) nullable() bool {
+	return t.value != "" || t.pointer
+}
+
+var sqlNullTypes = map[string]string{
+	"Bool":    "bool",
+	"Byte":    "byte",
+	"Float64": "float64",
+	"Int16":   "int16",
+	"Int32":   "int32",
+	"Int64":   "int64",
+	"String":  "string",
+	"Time":    "time.Time",
+}
+
+func (p ProtoTable) modelType(typ string) modelType {
+	switch {
+	case strings.HasPrefix(typ, "sql.Null") && sqlNullTypes[typ[len("sql.Null"):]] != "":
+		return modelType{base: sqlNullTypes[typ[len("sql.Null"):]], value: typ[len("sql.Null"):]}
+	case strings.HasPrefix(typ, "Null") && p.enums[typ[len("Null"):]]:
+		return modelType{base: typ[len("Null"):], value: typ[len("Null"):]}
+	case strings.HasPrefix(typ, "*"):
+		return modelType{base: typ[1:], pointer: true}
+	}
+	return modelType{base: typ}
+}
+
+// protoField returns the message field of a column
+func (p ProtoTable) protoField(column string) (ProtoField, bool) {
+	for _, field := range p.Proto.Fields {
+		if field.Column == column {
+			return field, true
+		}
+	}
+	return ProtoField{}, false
+}
+
+// protoOneof returns the oneof group of a discriminator column
+func (p ProtoTable) protoOneof(column string) (ProtoOneof, bool) {
+	for _, oneof := range p.Proto.Oneofs {
+		if oneof.Column == column {
+			return oneof, true
+		}
+	}
+	return ProtoOneof{}, false
+}
+
+// discriminator returns the expression of the value of the discriminator column of a oneof
+func (p ProtoTable) discriminator(recv, oneof string) (string, bool) {
+	for _, o := range p.Proto.Oneofs {
+		if o.GoName != oneof {
+			continue
+		}
+		for _, field := range p.Table.Fields {
+			if field.SQLName == o.Column {
+				return p.modelValue(recv, field), true
+			}
+		}
+	}
+	return "", false
+}
+
+// modelValue returns the expression of the value of a model field, valid when it isn't NULL
+func (p ProtoTable) modelValue(recv string, field Field) string {
+	typ := p.modelType(field.Type)
+	switch {
+	case typ.value != "":
+		return recv + "." + field.GoName + "." + typ.value
+	case typ.pointer:
+		return "(*" + recv + "." + field.GoName + ")"
+	}
+	return recv + "." + field.GoName
+}
+
+var (
+	// protoPointers are the helpers of the proto package returning a pointer to a scalar
+	protoPointers = map[string]string{
+		"bool": "Bool", "int32": "Int32", "int64": "Int64", "uint32": "Uint32", "uint64": "Uint64",
+		"float32": "Float32", "float64": "Float64", "string": "String",
+	}
+	protoIntegers = map[string]bool{"int32": true, "int64": true, "uint32": true, "uint64": true}
+	goNumbers     = map[string]bool{
+		"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+		"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+		"byte": true, "float32": true, "float64": true,
+	}
+)
+
+// toProto returns the statements setting the message m from the model recv
+func (p ProtoTable) toProto(recv string) ([]string, error) {
+	var lines []string
+	for _, field := range p.Table.Fields {
+		pf, ok := p.protoField(field.SQLName)
+		if !ok {
+			continue
+		}
+		typ := p.modelType(field.Type)
+		assign, err := p.protoAssign(pf, typ.base, p.modelValue(recv, field))
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", field.SQLName, err)
+		}
+		var conditions []string
+		if pf.Oneof != "" {
+			discriminator, ok := p.discriminator(recv, pf.Oneof)
+			if !ok {
+				return nil, fmt.Errorf("column %s: no discriminator column for oneof %s", field.SQLName, pf.Oneof)
+			}
+			conditions = append(conditions, fmt.Sprintf("%s == %q", discriminator, pf.Case))
+		}
+		switch {
+		case typ.value != "":
+			conditions = append(conditions, recv+"."+field.GoName+".Valid")
+		case typ.pointer:
+			conditions = append(conditions, recv+"."+field.GoName+" != nil")
+		}
+		if len(conditions) == 0 {
+			lines = append(lines, assign...)
+			continue
+		}
+		lines = append(lines, "if "+strings.Join(conditions, " && ")+" {")
+		lines = append(lines, assign...)
+		lines = append(lines, "}")
+	}
+	return lines, nil
+}
+
+// protoAssign returns the statements setting the message field from the value v of the model
+func (p ProtoTable) protoAssign(pf ProtoField, base, v string) ([]string, error) {
+	set := func(value string) string {
+		switch {
+		case pf.Oneof != "":
+			return fmt.Sprintf("m.%s = &%s{%s: %s}", pf.Oneof, pf.Wrapper, pf.GoName, value)
+		case pf.Optional && pf.Kind == "enum":
+			return fmt.Sprintf("m.%s = %s.Enum()", pf.GoName, value)
+		case pf.Optional && pf.Kind != "bytes":
+			return fmt.Sprintf("m.%s = proto.%s(%s)", pf.GoName, protoPointers[pf.Kind], value)
+		}
+		return fmt.Sprintf("m.%s = %s", pf.GoName, value)
+	}
+	unsupported := fmt.Errorf("cannot convert %s to proto %s", base, pf.Kind)
+	switch pf.Kind {
+	case "timestamp":
+		pkg := pf.Type[:strings.LastIndex(pf.Type, ".")]
+		switch base {
+		case "time.Time":
+			return []string{set(pkg + ".New(" + v + ")")}, nil
+		case "Time":
+			return []string{set(pkg + ".New(" + v + ".Time())")}, nil
+		}
+	case "message":
+		data := v
+		switch base {
+		case "string":
+			data = "[]byte(" + v + ")"
+		case "[]byte":
+		default:
+			return nil, unsupported
+		}
+		return []string{
+			"{",
+			"message := &" + pf.Type + "{}",
+			"if err := protojson.Unmarshal(" + data + ", message); err != nil {",
+			fmt.Sprintf("return nil, fmt.Errorf(\"%s: %%w\", err)", pf.Column),
+			"}",
+			set("message"),
+			"}",
+		}, nil
+	case "enum":
+		switch {
+		case goNumbers[base]:
+			return []string{set(pf.Type + "(" + v + ")")}, nil
+		case base == "string":
+			return []string{set(pf.Type + "(" + pf.Type + "_value[" + v + "])")}, nil
+		case p.enums[base]:
+			return []string{set(pf.Type + "(" + pf.Type + "_value[" + v + ".String()])")}, nil
+		}
+	case "bool":
+		switch {
+		case base == "bool":
+			return []string{set(v)}, nil
+		case goNumbers[base]:
+			return []string{set(v + " != 0")}, nil
+		}
+	case "string":
+		switch {
+		case base == "string":
+			return []string{set(v)}, nil
+		case base == "[]byte":
+			return []string{set("string(" + v + ")")}, nil
+		case p.enums[base]:
+			return []string{set(v + ".String()")}, nil
+		}
+	case "bytes":
+		switch base {
+		case "[]byte":
+			return []string{set(v)}, nil
+		case "string":
+			return []string{set("[]byte(" + v + ")")}, nil
+		}
+	default:
+		if goNumbers[base] && (protoIntegers[pf.Kind] || pf.Kind == "float32" || pf.Kind == "float64") {
+			if base == pf.Kind {
+				return []string{set(v)}, nil
+			}
+			return []string{set(pf.Kind + "(" + v + ")")}, nil
+		}
+	}
+	return nil, unsupported
+}
+
+// fromProto returns the statements setting the model recv from the message m
+func (p ProtoTable) fromProto(recv string) ([]string, error) {
+	var lines []string
+	for _, field := range p.Table.Fields {
+		target := recv + "." + field.GoName
+		typ := p.modelType(field.Type)
+		if oneof, ok := p.protoOneof(field.SQLName); ok {
+			lines = append(lines, "switch m."+oneof.GoName+".(type) {")
+			for _, c := range oneof.Cases {
+				value, err := p.modelAssign(target, typ, strconv.Quote(c.Case))
+				if err != nil {
+					return nil, fmt.Errorf("column %s: %w", field.SQLName, err)
+				}
+				lines = append(lines, "case *"+c.Wrapper+":")
+				lines = append(lines, value...)
+			}
+			lines = append(lines, "default:", target+" = "+field.Zero, "}")
+			continue
+		}
+		pf, ok := p.protoField(field.SQLName)
+		if !ok || field.IsGenerated {
+			continue
+		}
+		source, condition := "m."+pf.GoName, ""
+		switch {
+		case pf.Oneof != "":
+			source, condition = "variant."+pf.GoName, fmt.Sprintf("variant, ok := m.%s.(*%s); ok", pf.Oneof, pf.Wrapper)
+		case pf.Optional && pf.Kind != "bytes":
+			source, condition = "*m."+pf.GoName, "m."+pf.GoName+" != nil"
+		case pf.Kind == "timestamp" || pf.Kind == "message":
+			condition = "m." + pf.GoName + " != nil"
+		case !typ.nullable():
+		case pf.Kind == "bool":
+			condition = "m." + pf.GoName
+		case pf.Kind == "string":
+			condition = "m." + pf.GoName + ` != ""`
+		case pf.Kind == "bytes":
+			condition = "len(m." + pf.GoName + ") > 0"
+		default:
+			condition = "m." + pf.GoName + " != 0"
+		}
+		value, err := p.modelConvert(pf, typ.base, source)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", field.SQLName, err)
+		}
+		assign, err := p.modelAssign(target, typ, value[len(value)-1])
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", field.SQLName, err)
+		}
+		statements := append(value[:len(value)-1:len(value)-1], assign...)
+		if condition == "" {
+			lines = append(lines, statements...)
+			continue
+		}
+		lines = append(lines, "if "+condition+" {")
+		lines = append(lines, statements...)
+		lines = append(lines, "} else {", target+" = "+field.Zero, "}")
+	}
+	return lines, nil
+}
+
+// modelConvert converts the value of a message field to the Go type of the model. The last element is the
+// converted expression, the others are the statements computing it.
+func (p ProtoTable) modelConvert(pf ProtoField, base, v string) ([]string, error) {
+	unsupported := fmt.Errorf("cannot convert proto %s to %s", pf.Kind, base)
+	switch pf.Kind {
+	case "timestamp":
+		switch base {
+		case "time.Time":
+			return []string{v + ".AsTime()"}, nil
+		case "Time":
+			return []string{"NewTime(" + v + ".AsTime())"}, nil
+		}
+	case "message":
+		data := []string{
+			"data, err := protojson.Marshal(" + v + ")",
+			"if err != nil {",
+			fmt.Sprintf("return fmt.Errorf(\"%s: %%w\", err)", pf.Column),
+			"}",
+		}
+		switch base {
+		case "string":
+			return append(data, "string(data)"), nil
+		case "[]byte":
+			return append(data, "data"), nil
+		}
+	case "enum":
+		switch {
+		case goNumbers[base]:
+			return []string{base + "(" + v + ")"}, nil
+		case base == "string":
+			return []string{v + ".String()"}, nil
+		case p.enums[base]:
+			return p.enumConvert(pf, base, v+".String()"), nil
+		}
+	case "bool":
+		if base == "bool" {
+			return []string{v}, nil
+		}
+	case "string":
+		switch {
+		case base == "string":
+			return []string{v}, nil
+		case base == "[]byte":
+			return []string{"[]byte(" + v + ")"}, nil
+		case p.enums[base]:
+			return p.enumConvert(pf, base, v), nil
+		}
+	case "bytes":
+		switch base {
+		case "[]byte":
+			return []string{v}, nil
+		case "string":
+			return []string{"string(" + v + ")"}, nil
+		}
+	default:
+		if goNumbers[base] && (protoIntegers[pf.Kind] || pf.Kind == "float32" || pf.Kind == "float64") {
+			if base == pf.Kind {
+				return []string{v}, nil
+			}
+			return []string{base + "(" + v + ")"}, nil
+		}
+	}
+	return nil, unsupported
+}
+
+// enumConvert parses the name of an enum value of the database
+func (p ProtoTable) enumConvert(pf ProtoField, base, name string) []string {
+	return []string{
+		"var value " + base,
+		"if err := value.UnmarshalText([]byte(" + name + ")); err != nil {",
+		fmt.Sprintf("return fmt.Errorf(\"%s: %%w\", err)", pf.Column),
+		"}",
+		"value",
+	}
+}
+
+// modelAssign returns the statements setting the model field target to the value, wrapped in its NULL type
+func (p ProtoTable) modelAssign(target string, typ modelType, value string) ([]string, error) {
+	switch {
+	case typ.value != "" && p.enums[typ.base]:
+		return []string{fmt.Sprintf("%s = Null%s{%s: %s, Valid: true}", target, typ.value, typ.value, value)}, nil
+	case typ.value != "":
+		return []string{fmt.Sprintf("%s = sql.Null%s{%s: %s, Valid: true}", target, typ.value, typ.value, value)}, nil
+	case typ.pointer:
+		return []string{"{", "value := " + value, target + " = &value", "}"}, nil
+	}
+	return []string{target + " = " + value}, nil
+}
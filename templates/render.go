@@ -0,0 +1,100 @@
+//go:build !xotpl
+
+package gotpl
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"sort"
+	"text/template"
+
+	xo "github.com/xo/xo/types"
+)
+
+// files are the templates of the package, loaded by xo from the directory with --src
+//
+//go:embed *.tpl
+var files embed.FS
+
+// Render renders the schema mode templates for set in process, the way `xo schema --src templates` does, and
+// returns the content of each generated file. The driver and schema name are read from ctx (xo.DriverKey and
+// xo.SchemaKey), flags override the defaults of the template flags, e.g. PkgKey or GeneratedKey, and may hold other
+// values of the context such as ProtoKey.
+func Render(ctx context.Context, set *xo.Set, flags map[xo.ContextKey]interface{}) (map[string][]byte, error) {
+	var typ xo.TemplateType
+	if err := Init(ctx, func(t xo.TemplateType) { typ = t }); err != nil {
+		return nil, err
+	}
+	for _, flag := range typ.Flags {
+		if _, ok := flags[flag.ContextKey]; !ok {
+			ctx = context.WithValue(ctx, flag.ContextKey, xo.NewValue(flag.Type, flag.Default, flag.Desc, flag.Enums...).Interface())
+		}
+	}
+	for key, value := range flags {
+		ctx = context.WithValue(ctx, key, value)
+	}
+	const mode = "schema"
+	ctx = typ.NewContext(ctx, mode)
+
+	emitted := make(map[string][]xo.Template)
+	emit := func(tpl xo.Template) {
+		emitted[tpl.Dest] = append(emitted[tpl.Dest], tpl)
+	}
+	if err := typ.Pre(ctx, mode, set, nil, emit); err != nil {
+		return nil, err
+	}
+	if err := typ.Process(ctx, mode, set, emit); err != nil {
+		return nil, err
+	}
+
+	funcs, err := typ.Funcs(ctx, mode)
+	if err != nil {
+		return nil, err
+	}
+	tpl, err := template.New("").Funcs(funcs).ParseFS(files, "*.tpl")
+	if err != nil {
+		return nil, err
+	}
+	order := make(map[string]int)
+	for i, partial := range typ.Order(ctx, mode) {
+		order[partial] = i
+	}
+
+	// Files are rendered in name order, the package comment goes to the first one
+	var names []string
+	for name := range emitted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	rendered := make(map[string][]byte, len(names))
+	for _, name := range names {
+		templates := emitted[name]
+		sort.SliceStable(templates, func(i, j int) bool {
+			if templates[i].Partial != templates[j].Partial {
+				return order[templates[i].Partial] < order[templates[j].Partial]
+			}
+			if templates[i].SortType != templates[j].SortType {
+				return templates[i].SortType < templates[j].SortType
+			}
+			return templates[i].SortName < templates[j].SortName
+		})
+		var buf bytes.Buffer
+		for _, t := range templates {
+			if err := tpl.ExecuteTemplate(&buf, t.Partial, t); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		rendered[name] = buf.Bytes()
+	}
+
+	formatted := make(map[string][]byte, len(rendered))
+	err = typ.Post(ctx, mode, rendered, func(name string, content []byte) {
+		formatted[name] = content
+	})
+	if err != nil {
+		return nil, err
+	}
+	return formatted, nil
+}
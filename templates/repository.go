@@ -0,0 +1,121 @@
+package gotpl
+
+import (
+	"context"
+	"strings"
+
+	xo "github.com/xo/xo/types"
+)
+
+// Repository is the repository template of a table: the repository interface of its model and the
+// implementations on a database and on a MemoryDB.
+type Repository struct {
+	Table   Table
+	Indexes []Index
+}
+
+// MemoryTable is the constraints of a table enforced by the MemoryDB, by column name.
+type MemoryTable struct {
+	SQLName     string
+	PrimaryKeys []string
+	// Sequence is the auto-increment column, assigned on insert.
+	Sequence    string
+	Uniques     []MemoryIndex
+	ForeignKeys []MemoryForeignKey
+}
+
+// MemoryIndex is a unique index of a MemoryTable.
+type MemoryIndex struct {
+	SQLName string
+	Columns []string
+}
+
+// MemoryForeignKey is a foreign key of a MemoryTable. OnDelete is CASCADE, SET NULL, or empty when
+// deleting a referenced row fails.
+type MemoryForeignKey struct {
+	SQLName    string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+	OnDelete   string
+}
+
+// convertRepository converts the indexes of a table to the repository template.
+func convertRepository(ctx context.Context, table Table, t xo.Table) (Repository, error) {
+	r := Repository{Table: table}
+	for _, i := range t.Indexes {
+		index, err := convertIndex(ctx, table, i)
+		if err != nil {
+			return Repository{}, err
+		}
+		r.Indexes = append(r.Indexes, index)
+	}
+	return r, nil
+}
+
+// convertMemoryTables converts the tables with a primary key of the set to the memory template.
+func convertMemoryTables(ctx context.Context, set *xo.Set) []MemoryTable {
+	onDelete := make(map[string]string)
+	for _, action := range OnDelete(ctx) {
+		if key, value, ok := strings.Cut(action, "="); ok {
+			onDelete[key] = value
+		}
+	}
+	var tables []MemoryTable
+	for _, schema := range set.Schemas {
+		for _, t := range schema.Tables {
+			if len(t.PrimaryKeys) == 0 {
+				continue
+			}
+			table := MemoryTable{SQLName: t.Name}
+			for _, field := range t.PrimaryKeys {
+				table.PrimaryKeys = append(table.PrimaryKeys, field.Name)
+				if field.IsSequence && !t.Manual {
+					table.Sequence = field.Name
+				}
+			}
+			for _, i := range t.Indexes {
+				if i.IsUnique {
+					table.Uniques = append(table.Uniques, MemoryIndex{SQLName: i.Name, Columns: memoryColumns(i.Fields)})
+				}
+			}
+			for _, fk := range t.ForeignKeys {
+				table.ForeignKeys = append(table.ForeignKeys, MemoryForeignKey{
+					SQLName:    fk.Name,
+					Columns:    memoryColumns(fk.Fields),
+					RefTable:   fk.RefTable,
+					RefColumns: memoryColumns(fk.RefFields),
+					OnDelete:   onDelete[t.Name+"."+fk.Name],
+				})
+			}
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+func memoryColumns(fields []xo.Field) []string {
+	var columns []string
+	for _, field := range fields {
+		columns = append(columns, field.Name)
+	}
+	return columns
+}
+
+// repository_writes returns the names of the write methods of the model of a table, Update and Upsert are
+// omitted when every field is part of the primary key.
+func (f *Funcs) repository_writes(t Table) []string {
+	if len(t.Fields) == len(t.PrimaryKeys) {
+		return []string{"Insert", "Delete"}
+	}
+	return []string{"Insert", "Update", "Upsert", "Delete"}
+}
+
+// sql_names returns the column names of the fields.
+func (f *Funcs) sql_names(fields []Field) []string {
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.SQLName)
+	}
+	return names
+}
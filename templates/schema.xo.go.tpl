@@ -537,3 +537,170 @@ func {{ $t.GoName }}KeysetPage(ctx context.Context, db DB, column string, key in
     return results, lastItem, nil
 }
 {{ end }}
+
+{{ define "proto" }}
+{{- $p := .Data -}}
+{{- $t := $p.Table -}}
+// ToProto converts the [{{ $t.GoName }}] to a [{{ $p.Proto.Type }}].
+func ({{ short $t }} *{{ $t.GoName }}) ToProto() (*{{ $p.Proto.Type }}, error) {
+	m := &{{ $p.Proto.Type }}{}
+{{- range proto_to $p }}
+	{{ . }}
+{{- end }}
+	return m, nil
+}
+
+// FromProto sets the [{{ $t.GoName }}] from a [{{ $p.Proto.Type }}].
+func ({{ short $t }} *{{ $t.GoName }}) FromProto(m *{{ $p.Proto.Type }}) error {
+{{- range proto_from $p }}
+	{{ . }}
+{{- end }}
+	return nil
+}
+{{ end }}
+
+{{ define "repository" }}
+{{- $r := .Data -}}
+{{- $t := $r.Table -}}
+{{- $s := short $t -}}
+// {{ $t.GoName }}Repository reads and writes [{{ $t.GoName }}] rows, see
+// [NewSQL{{ $t.GoName }}Repository] and [NewMemory{{ $t.GoName }}Repository].
+type {{ $t.GoName }}Repository interface {
+	Insert(ctx context.Context, {{ $s }} *{{ $t.GoName }}) error
+{{- if ne (len $t.Fields) (len $t.PrimaryKeys) }}
+	Update(ctx context.Context, {{ $s }} *{{ $t.GoName }}) error
+	Upsert(ctx context.Context, {{ $s }} *{{ $t.GoName }}) error
+{{- end }}
+	Delete(ctx context.Context, {{ $s }} *{{ $t.GoName }}) error
+{{- range $r.Indexes }}
+	{{ .Func }}(ctx context.Context, {{ params .Fields true }}) ({{ if not .IsUnique }}[]{{ end }}*{{ $t.GoName }}, error)
+{{- end }}
+}
+
+// NewSQL{{ $t.GoName }}Repository returns a [{{ $t.GoName }}Repository] running the
+// queries of the [{{ $t.GoName }}] funcs on db.
+func NewSQL{{ $t.GoName }}Repository(db DB) {{ $t.GoName }}Repository {
+	return sql{{ $t.GoName }}Repository{db: db}
+}
+
+type sql{{ $t.GoName }}Repository struct {
+	db DB
+}
+
+{{ range $name := (repository_writes $t) -}}
+func (repo sql{{ $t.GoName }}Repository) {{ $name }}(ctx context.Context, {{ $s }} *{{ $t.GoName }}) error {
+	return {{ $s }}.{{ func_name_context $name }}({{ if context }}ctx, {{ end }}repo.db)
+}
+
+{{ end -}}
+{{ range $r.Indexes -}}
+func (repo sql{{ $t.GoName }}Repository) {{ .Func }}(ctx context.Context, {{ params .Fields true }}) ({{ if not .IsUnique }}[]{{ end }}*{{ $t.GoName }}, error) {
+	return {{ func_name_context . }}({{ if context }}ctx, {{ end }}repo.db, {{ params .Fields false }})
+}
+
+{{ end -}}
+// NewMemory{{ $t.GoName }}Repository returns a [{{ $t.GoName }}Repository] storing the
+// rows in memdb.
+func NewMemory{{ $t.GoName }}Repository(memdb *MemoryDB) {{ $t.GoName }}Repository {
+	return memory{{ $t.GoName }}Repository{memdb: memdb}
+}
+
+type memory{{ $t.GoName }}Repository struct {
+	memdb *MemoryDB
+}
+
+func (repo memory{{ $t.GoName }}Repository) Insert(ctx context.Context, {{ $s }} *{{ $t.GoName }}) error {
+	switch {
+	case {{ $s }}._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case {{ $s }}._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	row, err := repo.memdb.insert("{{ $t.SQLName }}", {{ $s }}.memoryRow())
+	if err != nil {
+		return logerror(err)
+	}
+	{{ $s }}.setMemoryRow(row)
+	{{ $s }}._exists = true
+	return nil
+}
+
+{{ if ne (len $t.Fields) (len $t.PrimaryKeys) -}}
+func (repo memory{{ $t.GoName }}Repository) Update(ctx context.Context, {{ $s }} *{{ $t.GoName }}) error {
+	switch {
+	case !{{ $s }}._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case {{ $s }}._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.update("{{ $t.SQLName }}", {{ $s }}.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+func (repo memory{{ $t.GoName }}Repository) Upsert(ctx context.Context, {{ $s }} *{{ $t.GoName }}) error {
+	switch {
+	case {{ $s }}._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	if err := repo.memdb.upsert("{{ $t.SQLName }}", {{ $s }}.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	{{ $s }}._exists = true
+	return nil
+}
+
+{{ end -}}
+func (repo memory{{ $t.GoName }}Repository) Delete(ctx context.Context, {{ $s }} *{{ $t.GoName }}) error {
+	switch {
+	case !{{ $s }}._exists: // doesn't exist
+		return nil
+	case {{ $s }}._deleted: // deleted
+		return nil
+	}
+	if err := repo.memdb.delete("{{ $t.SQLName }}", {{ $s }}.memoryRow()); err != nil {
+		return logerror(err)
+	}
+	{{ $s }}._deleted = true
+	return nil
+}
+
+{{ range $r.Indexes -}}
+func (repo memory{{ $t.GoName }}Repository) {{ .Func }}(ctx context.Context, {{ params .Fields true }}) ({{ if not .IsUnique }}[]{{ end }}*{{ $t.GoName }}, error) {
+	rows := repo.memdb.find("{{ $t.SQLName }}", {{ printf "%#v" (sql_names .Fields) }}, {{ params .Fields false }})
+{{- if .IsUnique }}
+	if len(rows) == 0 {
+		return nil, logerror(sql.ErrNoRows)
+	}
+	{{ $s }} := {{ $t.GoName }}{_exists: true}
+	{{ $s }}.setMemoryRow(rows[0])
+	return &{{ $s }}, nil
+{{- else }}
+	var res []*{{ $t.GoName }}
+	for _, row := range rows {
+		{{ $s }} := {{ $t.GoName }}{_exists: true}
+		{{ $s }}.setMemoryRow(row)
+		res = append(res, &{{ $s }})
+	}
+	return res, nil
+{{- end }}
+}
+
+{{ end -}}
+// memoryRow returns the fields of the [{{ $t.GoName }}] by column.
+func ({{ $s }} *{{ $t.GoName }}) memoryRow() memoryRow {
+	return memoryRow{
+{{- range $t.Fields }}
+		"{{ .SQLName }}": {{ $s }}.{{ .GoName }},
+{{- end }}
+	}
+}
+
+// setMemoryRow sets the fields of the [{{ $t.GoName }}] from a row.
+func ({{ $s }} *{{ $t.GoName }}) setMemoryRow(row memoryRow) {
+{{- range $t.Fields }}
+	{{ $s }}.{{ .GoName }} = row["{{ .SQLName }}"].({{ type .Type }})
+{{- end }}
+}
+{{ end }}
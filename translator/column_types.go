@@ -0,0 +1,147 @@
+package proto_db
+
+import (
+	"fmt"
+
+	dbExt "github.com/imran31415/proto-db-translator/annotations"
+	"github.com/imran31415/proto-db-translator/translator/db"
+	"github.com/imran31415/proto-db-translator/translator/internal/schemautil"
+	dbAn "github.com/imran31415/protobuf-db/db-annotations"
+)
+
+const defaultVarcharLength = 255
+
+// columnTypeSpec holds the type related annotations of a field
+type columnTypeSpec struct {
+	Base      dbAn.DbColumnType
+	Extended  dbExt.DbExtendedColumnType
+	Length    int32
+	Precision int32
+	Scale     int32
+	Unsigned  bool
+}
+
+// resolveColumnType renders the SQL column type for the target database.
+// The extended type takes precedence over the base type when both are set.
+func resolveColumnType(spec columnTypeSpec, dbType db.DatabaseType) string {
+	var sqlType string
+	numeric := false
+
+	switch spec.Extended {
+	case dbExt.DbExtendedColumnType_DB_TYPE_TINYINT:
+		sqlType, numeric = "TINYINT", true
+	case dbExt.DbExtendedColumnType_DB_TYPE_SMALLINT:
+		sqlType, numeric = "SMALLINT", true
+	case dbExt.DbExtendedColumnType_DB_TYPE_BIGINT:
+		sqlType, numeric = "BIGINT", true
+	case dbExt.DbExtendedColumnType_DB_TYPE_DECIMAL:
+		sqlType, numeric = "DECIMAL"+precisionScale(spec.Precision, spec.Scale), true
+	case dbExt.DbExtendedColumnType_DB_TYPE_CHAR:
+		sqlType = fmt.Sprintf("CHAR(%d)", lengthOr(spec.Length, 1))
+	case dbExt.DbExtendedColumnType_DB_TYPE_MEDIUMTEXT:
+		sqlType = "MEDIUMTEXT"
+		if dbType == db.DatabaseTypeSQLite {
+			sqlType = "TEXT"
+		}
+	case dbExt.DbExtendedColumnType_DB_TYPE_DATE:
+		sqlType = "DATE"
+	case dbExt.DbExtendedColumnType_DB_TYPE_TIME:
+		sqlType = "TIME"
+	case dbExt.DbExtendedColumnType_DB_TYPE_TIMESTAMP:
+		sqlType = "TIMESTAMP"
+	case dbExt.DbExtendedColumnType_DB_TYPE_JSON:
+		sqlType = "JSON"
+		if dbType == db.DatabaseTypeSQLite {
+			sqlType = "TEXT" // SQLite stores JSON as text and provides json_* functions
+		}
+	case dbExt.DbExtendedColumnType_DB_TYPE_UUID:
+		sqlType = "BINARY(16)"
+		if dbType == db.DatabaseTypeSQLite {
+			sqlType = "BLOB"
+		}
+	default:
+		switch spec.Base {
+		case dbAn.DbColumnType_DB_TYPE_INT, dbAn.DbColumnType_DB_TYPE_FLOAT, dbAn.DbColumnType_DB_TYPE_DOUBLE:
+			sqlType, numeric = dbColumnTypeToMySQLType(spec.Base), true
+		case dbAn.DbColumnType_DB_TYPE_VARCHAR:
+			sqlType = fmt.Sprintf("VARCHAR(%d)", lengthOr(spec.Length, defaultVarcharLength))
+		case dbAn.DbColumnType_DB_TYPE_BINARY:
+			sqlType = "BLOB"
+			if spec.Length > 0 && dbType != db.DatabaseTypeSQLite {
+				sqlType = fmt.Sprintf("VARBINARY(%d)", spec.Length)
+			}
+		default:
+			sqlType = dbColumnTypeToMySQLType(spec.Base)
+		}
+	}
+
+	// UNSIGNED is MySQL specific, SQLite has no unsigned storage classes
+	if spec.Unsigned && numeric && dbType != db.DatabaseTypeSQLite {
+		sqlType += " UNSIGNED"
+	}
+	return sqlType
+}
+
+// precisionScale formats the precision and scale suffix of a DECIMAL type
+func precisionScale(precision, scale int32) string {
+	if precision <= 0 {
+		return ""
+	}
+	if scale > 0 {
+		return fmt.Sprintf("(%d,%d)", precision, scale)
+	}
+	return fmt.Sprintf("(%d)", precision)
+}
+
+func lengthOr(length, fallback int32) int32 {
+	if length > 0 {
+		return length
+	}
+	return fallback
+}
+
+// columnTypeSQL renders the type of a column, sizing a bare DECIMAL type from the column precision and scale
+func columnTypeSQL(col ColumnSchema) string {
+	if col.Type == "DECIMAL" && (col.Precision > 0 || col.Scale > 0) {
+		return fmt.Sprintf("DECIMAL(%d,%d)", col.Precision, col.Scale)
+	}
+	return col.Type
+}
+
+// columnTypeDDL renders the type of a column as it is written in the CREATE TABLE statement of the database
+func (t Translator) columnTypeDDL(schema Schema, col ColumnSchema) string {
+	colType := columnTypeSQL(col)
+	if t.dbConnection.DbType != db.DatabaseTypeSQLite {
+		return colType
+	}
+	if schema.Options.Strict {
+		colType = sqliteStrictType(colType)
+	}
+	// Only an INTEGER PRIMARY KEY aliases the rowid and is assigned by SQLite when left out
+	if col.AutoIncrement && isIntegerType(col.Type) {
+		colType = "INTEGER"
+	}
+	return colType
+}
+
+// sqliteStrictType maps a column type to one of the types allowed in SQLite STRICT tables
+func sqliteStrictType(sqlType string) string {
+	base := baseColumnType(sqlType)
+	switch base {
+	case "INT", "INTEGER":
+		// Kept as is, only INTEGER PRIMARY KEY is an alias of the rowid
+		return base
+	case "TINYINT", "SMALLINT", "BIGINT", "BOOLEAN":
+		return "INTEGER"
+	case "FLOAT", "DOUBLE", "DECIMAL", "REAL":
+		return "REAL"
+	case "BLOB", "ANY":
+		return base
+	}
+	return "TEXT"
+}
+
+// baseColumnType strips the length and modifiers of a column type, e.g. VARCHAR(255) becomes VARCHAR
+func baseColumnType(sqlType string) string {
+	return schemautil.BaseColumnType(sqlType)
+}
@@ -0,0 +1,106 @@
+package proto_db
+
+import (
+	"testing"
+
+	dbExt "github.com/imran31415/proto-db-translator/annotations"
+	"github.com/imran31415/proto-db-translator/translator/db"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	dbAn "github.com/imran31415/protobuf-db/db-annotations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveColumnType(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           columnTypeSpec
+		expectedMysql  string
+		expectedSqlite string
+	}{
+		{"Int", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_INT}, "INT", "INT"},
+		{"Unsigned int", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_INT, Unsigned: true}, "INT UNSIGNED", "INT"},
+		{"Default varchar", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_VARCHAR}, "VARCHAR(255)", "VARCHAR(255)"},
+		{"Sized varchar", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_VARCHAR, Length: 64}, "VARCHAR(64)", "VARCHAR(64)"},
+		{"Text", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_TEXT}, "TEXT", "TEXT"},
+		{"Boolean", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_BOOLEAN}, "BOOLEAN", "BOOLEAN"},
+		{"Datetime", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_DATETIME}, "DATETIME", "DATETIME"},
+		{"Float", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_FLOAT}, "FLOAT", "FLOAT"},
+		{"Unsigned double", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_DOUBLE, Unsigned: true}, "DOUBLE UNSIGNED", "DOUBLE"},
+		{"Binary", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_BINARY}, "BLOB", "BLOB"},
+		{"Sized binary", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_BINARY, Length: 32}, "VARBINARY(32)", "BLOB"},
+		{"Unspecified", columnTypeSpec{}, "TEXT", "TEXT"},
+		{"Tinyint", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_TINYINT}, "TINYINT", "TINYINT"},
+		{"Unsigned smallint", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_SMALLINT, Unsigned: true}, "SMALLINT UNSIGNED", "SMALLINT"},
+		{"Bigint", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_BIGINT}, "BIGINT", "BIGINT"},
+		{"Decimal", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_DECIMAL}, "DECIMAL", "DECIMAL"},
+		{"Decimal with precision", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_DECIMAL, Precision: 12}, "DECIMAL(12)", "DECIMAL(12)"},
+		{"Decimal with scale", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_DECIMAL, Precision: 10, Scale: 2}, "DECIMAL(10,2)", "DECIMAL(10,2)"},
+		{"Unsigned decimal", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_DECIMAL, Precision: 10, Scale: 2, Unsigned: true}, "DECIMAL(10,2) UNSIGNED", "DECIMAL(10,2)"},
+		{"Char", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_CHAR, Length: 2}, "CHAR(2)", "CHAR(2)"},
+		{"Default char", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_CHAR}, "CHAR(1)", "CHAR(1)"},
+		{"Mediumtext", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_MEDIUMTEXT}, "MEDIUMTEXT", "TEXT"},
+		{"Date", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_DATE}, "DATE", "DATE"},
+		{"Time", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_TIME}, "TIME", "TIME"},
+		{"Timestamp", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_TIMESTAMP}, "TIMESTAMP", "TIMESTAMP"},
+		{"Json", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_JSON}, "JSON", "TEXT"},
+		{"Uuid", columnTypeSpec{Extended: dbExt.DbExtendedColumnType_DB_TYPE_UUID}, "BINARY(16)", "BLOB"},
+		{"Extended overrides base", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_FLOAT, Extended: dbExt.DbExtendedColumnType_DB_TYPE_BIGINT}, "BIGINT", "BIGINT"},
+		{"Unsigned ignored for strings", columnTypeSpec{Base: dbAn.DbColumnType_DB_TYPE_VARCHAR, Unsigned: true}, "VARCHAR(255)", "VARCHAR(255)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedMysql, resolveColumnType(tt.spec, db.DatabaseTypeMySQL))
+			assert.Equal(t, tt.expectedSqlite, resolveColumnType(tt.spec, db.DatabaseTypeSQLite))
+		})
+	}
+}
+
+func TestColumnTypesFromOrderProtos(t *testing.T) {
+	translator := NewTranslator(db.DefaultMysqlConnection())
+
+	orders, err := translator.GenerateSchema(&userauth.Orders{})
+	require.NoError(t, err)
+	totalAmount, ok := findColumnInSchema("total_amount", orders.Columns)
+	require.True(t, ok)
+	assert.Equal(t, "DECIMAL(10,2)", totalAmount.Type)
+	assert.Equal(t, int32(10), totalAmount.Precision)
+	assert.Equal(t, int32(2), totalAmount.Scale)
+	status, ok := findColumnInSchema("status", orders.Columns)
+	require.True(t, ok)
+	assert.Equal(t, "VARCHAR(32)", status.Type)
+
+	product, err := translator.GenerateSchema(&userauth.Product{})
+	require.NoError(t, err)
+	stock, ok := findColumnInSchema("stock_quantity", product.Columns)
+	require.True(t, ok)
+	assert.Equal(t, "INT UNSIGNED", stock.Type)
+	assert.Contains(t, translator.GenerateCreateTableSQL(product), "price DECIMAL(10,2) NOT NULL")
+}
+
+func TestSqliteStrictType(t *testing.T) {
+	tests := map[string]string{
+		"INT":           "INT",
+		"INTEGER":       "INTEGER",
+		"BIGINT":        "INTEGER",
+		"INT UNSIGNED":  "INT",
+		"BOOLEAN":       "INTEGER",
+		"DECIMAL(10,2)": "REAL",
+		"DOUBLE":        "REAL",
+		"VARCHAR(255)":  "TEXT",
+		"CHAR(2)":       "TEXT",
+		"DATETIME":      "TEXT",
+		"BLOB":          "BLOB",
+	}
+	for sqlType, expected := range tests {
+		assert.Equal(t, expected, sqliteStrictType(sqlType), sqlType)
+	}
+}
+
+func TestColumnTypeSQL(t *testing.T) {
+	assert.Equal(t, "DECIMAL(8,3)", columnTypeSQL(ColumnSchema{Type: "DECIMAL", Precision: 8, Scale: 3}))
+	assert.Equal(t, "DECIMAL", columnTypeSQL(ColumnSchema{Type: "DECIMAL"}))
+	assert.Equal(t, "DECIMAL(10,2)", columnTypeSQL(ColumnSchema{Type: "DECIMAL(10,2)", Precision: 10, Scale: 2}))
+	assert.Equal(t, "VARCHAR(64)", columnTypeSQL(ColumnSchema{Type: "VARCHAR(64)"}))
+}
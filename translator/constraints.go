@@ -0,0 +1,142 @@
+package proto_db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	dbExt "github.com/imran31415/proto-db-translator/annotations"
+	"github.com/imran31415/proto-db-translator/translator/db"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// maxIdentifierLength is the longest identifier accepted by MySQL
+const maxIdentifierLength = 64
+
+var identifierPattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// parseCheckConstraints combines the expressions of db_check_constraint with the named db_check options and the
+// checks of the oneof groups. Constraints without a name get a deterministic one derived from the table and the
+// checked columns, names given in the options must be unique.
+func parseCheckConstraints(md protoreflect.MessageDescriptor, columns []ColumnSchema, expressions []string, oneofs []OneofSchema) ([]CheckConstraint, error) {
+	var checks []CheckConstraint
+	for _, expression := range expressions {
+		checks = append(checks, CheckConstraint{Expression: expression})
+	}
+	if options, ok := md.Options().(*descriptorpb.MessageOptions); ok && options != nil {
+		named, _ := proto.GetExtension(options, dbExt.E_DbCheck).([]*dbExt.DbCheckConstraint)
+		for _, check := range named {
+			checks = append(checks, CheckConstraint{Name: check.GetName(), Expression: check.GetExpression()})
+		}
+	}
+	for _, oneof := range oneofs {
+		checks = append(checks, CheckConstraint{
+			Name:       truncatedIdentifier(strings.ToLower(fmt.Sprintf("%s_%s", md.Name(), oneof.Name)), "_chk"),
+			Expression: oneofCheckConstraint(oneof),
+		})
+	}
+
+	used := make(map[string]bool)
+	for _, check := range checks {
+		if check.Name == "" {
+			continue
+		}
+		// MySQL check constraint names are case-insensitive
+		name := strings.ToLower(check.Name)
+		if used[name] {
+			return nil, fmt.Errorf("duplicate check constraint name '%s'", check.Name)
+		}
+		used[name] = true
+	}
+	for i := range checks {
+		if checks[i].Name != "" {
+			continue
+		}
+		base := checkConstraintName(string(md.Name()), checks[i].Expression, columns)
+		name := base
+		for n := 2; used[name]; n++ {
+			name = truncatedIdentifier(strings.TrimSuffix(base, "_chk"), fmt.Sprintf("_%d_chk", n))
+		}
+		used[name] = true
+		checks[i].Name = name
+	}
+	return checks, nil
+}
+
+// checkConstraintName derives a constraint name from the table and the columns used by the expression,
+// e.g. orderitems_quantity_chk for quantity > 0 on OrderItems
+func checkConstraintName(tableName, expression string, columns []ColumnSchema) string {
+	parts := []string{strings.ToLower(tableName)}
+	seen := make(map[string]bool)
+	for _, identifier := range identifierPattern.FindAllString(expression, -1) {
+		if _, ok := findColumnInSchema(identifier, columns); ok && !seen[identifier] {
+			seen[identifier] = true
+			parts = append(parts, strings.ToLower(identifier))
+		}
+	}
+	return truncatedIdentifier(strings.Join(parts, "_"), "_chk")
+}
+
+// truncatedIdentifier appends the suffix to a generated name, truncating the name so that the identifier fits
+// in maxIdentifierLength
+func truncatedIdentifier(name, suffix string) string {
+	if len(name) > maxIdentifierLength-len(suffix) {
+		name = name[:maxIdentifierLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+// checkConstraintSQL renders a CHECK constraint of a CREATE TABLE statement
+func checkConstraintSQL(check CheckConstraint) string {
+	if check.Name == "" {
+		return fmt.Sprintf("CHECK (%s)", check.Expression)
+	}
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", check.Name, check.Expression)
+}
+
+// checkConstraintMigration renders the statements adding, dropping and replacing CHECK constraints by name.
+// SQLite can't alter the constraints of an existing table.
+func (t Translator) checkConstraintMigration(oldSchema, newSchema Schema) string {
+	oldChecks := make(map[string]CheckConstraint)
+	for _, check := range oldSchema.CheckConstraints {
+		oldChecks[check.Name] = check
+	}
+	newChecks := make(map[string]bool)
+	for _, check := range newSchema.CheckConstraints {
+		newChecks[check.Name] = true
+	}
+
+	var dropped, added []CheckConstraint
+	for _, check := range oldSchema.CheckConstraints {
+		if !newChecks[check.Name] {
+			dropped = append(dropped, check)
+		}
+	}
+	for _, check := range newSchema.CheckConstraints {
+		oldCheck, exists := oldChecks[check.Name]
+		if exists && oldCheck.Expression == check.Expression {
+			continue
+		}
+		if exists {
+			dropped = append(dropped, oldCheck)
+		}
+		added = append(added, check)
+	}
+	if len(dropped) == 0 && len(added) == 0 {
+		return ""
+	}
+	if t.dbConnection.DbType == db.DatabaseTypeSQLite {
+		return fmt.Sprintf("-- SQLite can't alter the CHECK constraints of %s, the table must be rebuilt\n", newSchema.TableName)
+	}
+
+	var migration strings.Builder
+	for _, check := range dropped {
+		migration.WriteString(fmt.Sprintf("ALTER TABLE %s DROP CHECK %s;\n", newSchema.TableName, check.Name))
+	}
+	for _, check := range added {
+		migration.WriteString(fmt.Sprintf("ALTER TABLE %s ADD %s;\n", newSchema.TableName, checkConstraintSQL(check)))
+	}
+	return migration.String()
+}
@@ -0,0 +1,166 @@
+package proto_db
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	dbExt "github.com/imran31415/proto-db-translator/annotations"
+	"github.com/imran31415/proto-db-translator/translator/db"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestGenerateSchemaCheckConstraints(t *testing.T) {
+	schema, err := NewSqliteTranslator().GenerateSchema(&userauth.OrderItems{})
+	require.NoError(t, err)
+	require.Equal(t, []CheckConstraint{
+		{Name: "orderitems_quantity_chk", Expression: "quantity > 0"},
+		{Name: "orderitems_price_per_unit_chk", Expression: "price_per_unit >= 0"},
+	}, schema.CheckConstraints)
+}
+
+func TestParseCheckConstraints(t *testing.T) {
+	schema, err := NewSqliteTranslator().GenerateSchema(&userauth.Payment{})
+	require.NoError(t, err)
+	// payment returns the descriptor of Payment with the given db_check options
+	payment := func(checks ...*dbExt.DbCheckConstraint) protoreflect.MessageDescriptor {
+		fdp := protodesc.ToFileDescriptorProto(userauth.File_proto_order_proto)
+		for _, message := range fdp.MessageType {
+			if message.GetName() == "Payment" {
+				message.Options = &descriptorpb.MessageOptions{}
+				proto.SetExtension(message.Options, dbExt.E_DbCheck, checks)
+			}
+		}
+		fd, err := protodesc.FileOptions{AllowUnresolvable: true}.New(fdp, protoregistry.GlobalFiles)
+		require.NoError(t, err)
+		return fd.Messages().ByName("Payment")
+	}
+	oneofCheck := oneofCheckConstraint(schema.Oneofs[0])
+
+	tests := []struct {
+		name        string
+		expressions []string
+		checks      []*dbExt.DbCheckConstraint
+		expected    []CheckConstraint
+		errMsg      string
+	}{
+		{
+			name:        "generated names skip the oneof check",
+			expressions: []string{"1 = 1", "2 = 2"},
+			checks:      []*dbExt.DbCheckConstraint{{Name: "payment_2_chk", Expression: "order_id > 0"}},
+			expected: []CheckConstraint{
+				{Name: "payment_chk", Expression: "1 = 1"},
+				{Name: "payment_3_chk", Expression: "2 = 2"},
+				{Name: "payment_2_chk", Expression: "order_id > 0"},
+				{Name: "payment_method_chk", Expression: oneofCheck},
+			},
+		},
+		{
+			name:   "duplicate names",
+			checks: []*dbExt.DbCheckConstraint{{Name: "payment_order_chk", Expression: "order_id > 0"}, {Name: "Payment_Order_chk", Expression: "order_id < 10"}},
+			errMsg: "duplicate check constraint name 'Payment_Order_chk'",
+		},
+		{
+			name:   "name of the oneof check",
+			checks: []*dbExt.DbCheckConstraint{{Name: "payment_method_chk", Expression: "order_id > 0"}},
+			errMsg: "duplicate check constraint name 'payment_method_chk'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks, err := parseCheckConstraints(payment(tt.checks...), schema.Columns, tt.expressions, schema.Oneofs)
+			if tt.errMsg != "" {
+				require.EqualError(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, checks)
+		})
+	}
+}
+
+func TestCheckConstraintName(t *testing.T) {
+	columns := []ColumnSchema{{Name: "start_date"}, {Name: "end_date"}, {Name: "quantity"}}
+	tests := []struct {
+		name       string
+		table      string
+		expression string
+		expected   string
+	}{
+		{name: "single column", table: "OrderItems", expression: "quantity > 0", expected: "orderitems_quantity_chk"},
+		{name: "multiple columns", table: "Bookings", expression: "end_date >= start_date", expected: "bookings_end_date_start_date_chk"},
+		{name: "repeated column", table: "Bookings", expression: "quantity > 0 AND quantity < 10", expected: "bookings_quantity_chk"},
+		{name: "keywords are ignored", table: "Bookings", expression: "start_date IS NOT NULL", expected: "bookings_start_date_chk"},
+		{name: "no columns", table: "Bookings", expression: "1 = 1", expected: "bookings_chk"},
+		{
+			name:       "truncated",
+			table:      strings.Repeat("t", 70),
+			expression: "quantity > 0",
+			expected:   strings.Repeat("t", 60) + "_chk",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, checkConstraintName(tt.table, tt.expression, columns))
+		})
+	}
+}
+
+func TestCheckConstraintsSQL(t *testing.T) {
+	schema, err := NewSqliteTranslator().GenerateSchema(&userauth.OrderItems{})
+	require.NoError(t, err)
+
+	statement := NewTranslator(db.DefaultMysqlConnection()).GenerateCreateTableSQL(schema)
+	require.Contains(t, statement, ",\n  CONSTRAINT orderitems_quantity_chk CHECK (quantity > 0),\n"+
+		"  CONSTRAINT orderitems_price_per_unit_chk CHECK (price_per_unit >= 0)")
+
+	statement = NewSqliteTranslator().GenerateCreateTableSQL(schema)
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+	_, err = database.Exec(statement)
+	require.NoError(t, err)
+
+	_, err = database.Exec("INSERT INTO OrderItems (order_item_id, order_id, product_id, quantity, price_per_unit) VALUES (1, 1, 1, 1, 9.99)")
+	require.NoError(t, err)
+
+	// Each violation names the failing constraint
+	_, err = database.Exec("INSERT INTO OrderItems (order_item_id, order_id, product_id, quantity, price_per_unit) VALUES (2, 1, 1, 0, 9.99)")
+	require.ErrorContains(t, err, "orderitems_quantity_chk")
+	_, err = database.Exec("INSERT INTO OrderItems (order_item_id, order_id, product_id, quantity, price_per_unit) VALUES (3, 1, 1, 1, -1)")
+	require.ErrorContains(t, err, "orderitems_price_per_unit_chk")
+}
+
+func TestCheckConstraintMigration(t *testing.T) {
+	oldSchema := Schema{
+		TableName: "OrderItems",
+		CheckConstraints: []CheckConstraint{
+			{Name: "orderitems_quantity_chk", Expression: "quantity > 0"},
+			{Name: "orderitems_discount_chk", Expression: "discount >= 0"},
+		},
+	}
+	newSchema := Schema{
+		TableName: "OrderItems",
+		CheckConstraints: []CheckConstraint{
+			{Name: "orderitems_quantity_chk", Expression: "quantity >= 1 AND quantity <= 100"},
+			{Name: "orderitems_price_per_unit_chk", Expression: "price_per_unit >= 0"},
+		},
+	}
+
+	mysql := NewTranslator(db.DefaultMysqlConnection())
+	require.Equal(t, "ALTER TABLE OrderItems DROP CHECK orderitems_discount_chk;\n"+
+		"ALTER TABLE OrderItems DROP CHECK orderitems_quantity_chk;\n"+
+		"ALTER TABLE OrderItems ADD CONSTRAINT orderitems_quantity_chk CHECK (quantity >= 1 AND quantity <= 100);\n"+
+		"ALTER TABLE OrderItems ADD CONSTRAINT orderitems_price_per_unit_chk CHECK (price_per_unit >= 0);\n",
+		mysql.checkConstraintMigration(oldSchema, newSchema))
+	require.Empty(t, mysql.checkConstraintMigration(newSchema, newSchema))
+
+	require.Equal(t, "-- SQLite can't alter the CHECK constraints of OrderItems, the table must be rebuilt\n",
+		NewSqliteTranslator().checkConstraintMigration(oldSchema, newSchema))
+}
@@ -0,0 +1,296 @@
+package proto_db
+
+import (
+	"fmt"
+	"time"
+
+	dbAn "github.com/imran31415/protobuf-db/db-annotations"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MessageToColumns converts an annotated proto message into a map of column name to column value.
+// Oneof groups are flattened into their discriminator column and one value per variant column,
+// where every variant other than the set case is NULL.
+func (t Translator) MessageToColumns(message proto.Message) (map[string]interface{}, error) {
+	m := message.ProtoReflect()
+	md := m.Descriptor()
+	columns := make(map[string]interface{})
+
+	for i := 0; i < md.Fields().Len(); i++ {
+		field := md.Fields().Get(i)
+		column, err := fieldColumnName(field)
+		if err != nil {
+			return nil, err
+		}
+
+		if od := field.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			set := m.WhichOneof(od)
+			discriminator := oneofDiscriminatorColumn(od).Name
+			if set == nil {
+				columns[discriminator] = nil
+			} else {
+				columns[discriminator] = string(set.Name())
+			}
+			if set == nil || set.Number() != field.Number() {
+				columns[column] = nil
+				continue
+			}
+		}
+
+		value, err := fieldToColumnValue(field, m.Get(field))
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert field '%s': %w", field.FullName(), err)
+		}
+		columns[column] = value
+	}
+	return columns, nil
+}
+
+// ColumnsToMessage populates an annotated proto message from a map of column name to column value,
+// as produced by MessageToColumns or scanned from a database row.
+// For oneof groups only the variant named by the discriminator column is set.
+func (t Translator) ColumnsToMessage(columns map[string]interface{}, message proto.Message) error {
+	m := message.ProtoReflect()
+	md := m.Descriptor()
+
+	for i := 0; i < md.Fields().Len(); i++ {
+		field := md.Fields().Get(i)
+		column, err := fieldColumnName(field)
+		if err != nil {
+			return err
+		}
+
+		if od := field.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			discriminator := oneofDiscriminatorColumn(od).Name
+			setCase, _ := asString(columns[discriminator])
+			if setCase != string(field.Name()) {
+				continue
+			}
+			if columns[column] == nil {
+				return fmt.Errorf("oneof '%s' is set to '%s' but column '%s' is NULL", od.Name(), setCase, column)
+			}
+		}
+
+		raw, ok := columns[column]
+		if !ok || raw == nil {
+			continue
+		}
+		value, err := columnValueToField(field, raw, m)
+		if err != nil {
+			return fmt.Errorf("failed to convert column '%s': %w", column, err)
+		}
+		m.Set(field, value)
+	}
+	return nil
+}
+
+// fieldColumnName returns the db_column annotation for the field
+func fieldColumnName(field protoreflect.FieldDescriptor) (string, error) {
+	options, _ := field.Options().(*descriptorpb.FieldOptions)
+	if options == nil {
+		return "", newFieldError(field, CodeMissingAnnotation, "missing field options for field %s", field.Name())
+	}
+	column, ok := proto.GetExtension(options, dbAn.E_DbColumn).(string)
+	if !ok || column == "" {
+		return "", newFieldError(field, CodeMissingAnnotation, "missing or invalid db_column annotation")
+	}
+	return column, nil
+}
+
+// fieldToColumnValue converts a proto field value into a value accepted by database/sql drivers
+func fieldToColumnValue(field protoreflect.FieldDescriptor, value protoreflect.Value) (interface{}, error) {
+	if field.IsList() || field.IsMap() {
+		return nil, fmt.Errorf("repeated and map fields are not supported")
+	}
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return value.Bool(), nil
+	case protoreflect.EnumKind:
+		return int32(value.Enum()), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return int32(value.Int()), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return value.Int(), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return uint32(value.Uint()), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return value.Uint(), nil
+	case protoreflect.FloatKind:
+		return float32(value.Float()), nil
+	case protoreflect.DoubleKind:
+		return value.Float(), nil
+	case protoreflect.StringKind:
+		return value.String(), nil
+	case protoreflect.BytesKind:
+		return value.Bytes(), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if !value.Message().IsValid() {
+			return nil, nil
+		}
+		if ts, ok := value.Message().Interface().(*timestamppb.Timestamp); ok {
+			return ts.AsTime(), nil
+		}
+		// Other messages are stored as their JSON representation
+		b, err := protojson.Marshal(value.Message().Interface())
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	return nil, fmt.Errorf("unsupported field kind %s", field.Kind())
+}
+
+// columnValueToField converts a database value into a proto value for the field
+func columnValueToField(field protoreflect.FieldDescriptor, raw interface{}, m protoreflect.Message) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		switch v := raw.(type) {
+		case bool:
+			return protoreflect.ValueOfBool(v), nil
+		default:
+			i, err := asInt64(raw)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			return protoreflect.ValueOfBool(i != 0), nil
+		}
+	case protoreflect.EnumKind:
+		i, err := asInt64(raw)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i)), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := asInt64(raw)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfInt32(int32(i)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := asInt64(raw)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfInt64(i), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		i, err := asInt64(raw)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfUint32(uint32(i)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		i, err := asInt64(raw)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfUint64(uint64(i)), nil
+	case protoreflect.FloatKind:
+		f, err := asFloat64(raw)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfFloat32(float32(f)), nil
+	case protoreflect.DoubleKind:
+		f, err := asFloat64(raw)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfFloat64(f), nil
+	case protoreflect.StringKind:
+		s, ok := asString(raw)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("cannot convert %T to string", raw)
+		}
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		switch v := raw.(type) {
+		case []byte:
+			return protoreflect.ValueOfBytes(v), nil
+		case string:
+			return protoreflect.ValueOfBytes([]byte(v)), nil
+		}
+		return protoreflect.Value{}, fmt.Errorf("cannot convert %T to bytes", raw)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := m.NewField(field).Message()
+		if ts, ok := msg.Interface().(*timestamppb.Timestamp); ok {
+			tm, ok := raw.(time.Time)
+			if !ok {
+				return protoreflect.Value{}, fmt.Errorf("cannot convert %T to timestamp", raw)
+			}
+			proto.Merge(ts, timestamppb.New(tm))
+			return protoreflect.ValueOfMessage(msg), nil
+		}
+		s, ok := asString(raw)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("cannot convert %T to message", raw)
+		}
+		if err := protojson.Unmarshal([]byte(s), msg.Interface()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfMessage(msg), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", field.Kind())
+}
+
+func asString(raw interface{}) (string, bool) {
+	switch v := raw.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return "", false
+}
+
+func asInt64(raw interface{}) (int64, error) {
+	switch v := raw.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case []byte:
+		var i int64
+		_, err := fmt.Sscan(string(v), &i)
+		return i, err
+	case string:
+		var i int64
+		_, err := fmt.Sscan(v, &i)
+		return i, err
+	}
+	return 0, fmt.Errorf("cannot convert %T to integer", raw)
+}
+
+func asFloat64(raw interface{}) (float64, error) {
+	switch v := raw.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case []byte:
+		var f float64
+		_, err := fmt.Sscan(string(v), &f)
+		return f, err
+	case string:
+		var f float64
+		_, err := fmt.Sscan(v, &f)
+		return f, err
+	}
+	i, err := asInt64(raw)
+	return float64(i), err
+}
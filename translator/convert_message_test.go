@@ -0,0 +1,168 @@
+package proto_db
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGenerateSchemaOneof(t *testing.T) {
+	schema, err := NewSqliteTranslator().GenerateSchema(&userauth.Payment{})
+	require.NoError(t, err)
+
+	var names []string
+	for _, col := range schema.Columns {
+		names = append(names, col.Name)
+	}
+	require.Equal(t, []string{"payment_id", "order_id", "method_case", "card_token", "iban", "voucher_id"}, names)
+
+	discriminator, ok := findColumnInSchema("method_case", schema.Columns)
+	require.True(t, ok)
+	require.Equal(t, "VARCHAR(255)", discriminator.Type)
+	require.Empty(t, discriminator.Constraints, "NULL when no variant is set")
+
+	require.Equal(t, []OneofSchema{{
+		Name:                "method",
+		DiscriminatorColumn: "method_case",
+		Variants: []OneofVariant{
+			{Case: "card_token", Column: "card_token"},
+			{Case: "iban", Column: "iban"},
+			{Case: "voucher_id", Column: "voucher_id"},
+		},
+	}}, schema.Oneofs)
+
+	require.Equal(t, []CheckConstraint{{
+		Name: "payment_method_chk",
+		Expression: "((method_case IS NOT NULL AND method_case = 'card_token' AND card_token IS NOT NULL AND iban IS NULL AND voucher_id IS NULL) OR " +
+			"(method_case IS NOT NULL AND method_case = 'iban' AND card_token IS NULL AND iban IS NOT NULL AND voucher_id IS NULL) OR " +
+			"(method_case IS NOT NULL AND method_case = 'voucher_id' AND card_token IS NULL AND iban IS NULL AND voucher_id IS NOT NULL) OR " +
+			"(method_case IS NULL AND card_token IS NULL AND iban IS NULL AND voucher_id IS NULL))",
+	}}, schema.CheckConstraints)
+}
+
+func TestMessageToColumns(t *testing.T) {
+	translator := NewSqliteTranslator()
+
+	columns, err := translator.MessageToColumns(&userauth.Payment{
+		PaymentId: 1,
+		OrderId:   2,
+		Method:    &userauth.Payment_Iban{Iban: "DE89370400440532013000"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"payment_id":  int32(1),
+		"order_id":    int32(2),
+		"method_case": "iban",
+		"card_token":  nil,
+		"iban":        "DE89370400440532013000",
+		"voucher_id":  nil,
+	}, columns)
+
+	columns, err = translator.MessageToColumns(&userauth.Payment{PaymentId: 1, OrderId: 2})
+	require.NoError(t, err)
+	require.Nil(t, columns["method_case"])
+}
+
+func TestColumnsToMessageOneofMismatch(t *testing.T) {
+	err := NewSqliteTranslator().ColumnsToMessage(map[string]interface{}{
+		"payment_id":  int64(1),
+		"method_case": "card_token",
+		"iban":        "DE89370400440532013000",
+	}, &userauth.Payment{})
+	require.ErrorContains(t, err, "oneof 'method' is set to 'card_token' but column 'card_token' is NULL")
+}
+
+func TestMessageColumnsRoundTripSqlite(t *testing.T) {
+	translator := NewTranslator(db.DefaultSqliteConnection())
+
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+
+	schema, err := translator.GenerateSchema(&userauth.Payment{})
+	require.NoError(t, err)
+	_, err = database.Exec(translator.GenerateCreateTableSQL(schema))
+	require.NoError(t, err)
+
+	payments := []*userauth.Payment{
+		{PaymentId: 1, OrderId: 10, Method: &userauth.Payment_CardToken{CardToken: "tok_123"}},
+		{PaymentId: 2, OrderId: 10, Method: &userauth.Payment_Iban{Iban: "DE89370400440532013000"}},
+		{PaymentId: 3, OrderId: 11, Method: &userauth.Payment_VoucherId{VoucherId: 42}},
+		{PaymentId: 4, OrderId: 11},
+	}
+	for _, payment := range payments {
+		columns, err := translator.MessageToColumns(payment)
+		require.NoError(t, err)
+		_, err = database.Exec(
+			"INSERT INTO Payment (payment_id, order_id, method_case, card_token, iban, voucher_id) VALUES (?, ?, ?, ?, ?, ?)",
+			columns["payment_id"], columns["order_id"], columns["method_case"], columns["card_token"], columns["iban"], columns["voucher_id"],
+		)
+		require.NoError(t, err)
+	}
+
+	// The check constraint rejects rows where the discriminator and the variant columns disagree
+	_, err = database.Exec("INSERT INTO Payment (payment_id, order_id, method_case, card_token, iban) VALUES (5, 10, 'iban', 'tok_456', NULL)")
+	require.Error(t, err)
+	_, err = database.Exec("INSERT INTO Payment (payment_id, order_id, method_case, card_token, iban) VALUES (6, 10, 'card_token', 'tok_456', 'DE89370400440532013000')")
+	require.Error(t, err)
+	_, err = database.Exec("INSERT INTO Payment (payment_id, order_id, method_case, card_token) VALUES (7, 10, NULL, 'tok_456')")
+	require.Error(t, err)
+
+	rows, err := database.Query("SELECT payment_id, order_id, method_case, card_token, iban, voucher_id FROM Payment ORDER BY payment_id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var got []*userauth.Payment
+	for rows.Next() {
+		var paymentID, orderID int64
+		var methodCase sql.NullString
+		var cardToken, iban sql.NullString
+		var voucherID sql.NullInt64
+		require.NoError(t, rows.Scan(&paymentID, &orderID, &methodCase, &cardToken, &iban, &voucherID))
+
+		columns := map[string]interface{}{
+			"payment_id": paymentID,
+			"order_id":   orderID,
+		}
+		if methodCase.Valid {
+			columns["method_case"] = methodCase.String
+		}
+		if cardToken.Valid {
+			columns["card_token"] = cardToken.String
+		}
+		if iban.Valid {
+			columns["iban"] = iban.String
+		}
+		if voucherID.Valid {
+			columns["voucher_id"] = voucherID.Int64
+		}
+		payment := &userauth.Payment{}
+		require.NoError(t, translator.ColumnsToMessage(columns, payment))
+		got = append(got, payment)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, got, len(payments))
+	for i := range payments {
+		require.True(t, proto.Equal(payments[i], got[i]), "expected %v, got %v", payments[i], got[i])
+	}
+}
+
+func TestMessageColumnsTimestamp(t *testing.T) {
+	translator := NewSqliteTranslator()
+	createdAt := timestamppb.Now()
+
+	columns, err := translator.MessageToColumns(&userauth.Customer{CustomerId: 7, Email: "a@b.c", CreatedAt: createdAt})
+	require.NoError(t, err)
+	require.Equal(t, createdAt.AsTime(), columns["created_at"])
+	require.Nil(t, columns["updated_at"])
+
+	customer := &userauth.Customer{}
+	require.NoError(t, translator.ColumnsToMessage(columns, customer))
+	require.Equal(t, int32(7), customer.CustomerId)
+	require.True(t, proto.Equal(createdAt, customer.CreatedAt))
+}
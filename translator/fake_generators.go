@@ -0,0 +1,127 @@
+package proto_db
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"strings"
+)
+
+// FakeGenerator generates the value of row n of a column from the random source of the seeder.
+// Generators of text columns return strings, generators of numeric columns float64 values.
+type FakeGenerator func(r *rand.Rand, n int) interface{}
+
+var (
+	firstNames = []string{"Ada", "Alan", "Barbara", "Claude", "Dennis", "Edsger", "Frances", "Grace", "Hedy", "Ivan",
+		"John", "Katherine", "Ken", "Linus", "Margaret", "Niklaus", "Radia", "Rob", "Sophie", "Tim"}
+	lastNames = []string{"Allen", "Backus", "Berners-Lee", "Dijkstra", "Hamilton", "Hopper", "Johnson", "Kernighan",
+		"Knuth", "Lamarr", "Liskov", "Lovelace", "McCarthy", "Perlman", "Pike", "Ritchie", "Shannon", "Sutherland",
+		"Thompson", "Turing", "Wilson", "Wirth"}
+	companySuffixes = []string{"Labs", "Systems", "Industries", "Works", "Partners", "Group"}
+	cities          = []string{"Amsterdam", "Austin", "Berlin", "Boston", "Dublin", "Lisbon", "London", "Madrid", "Montreal",
+		"Oslo", "Paris", "Seattle", "Sydney", "Tokyo", "Toronto", "Vienna"}
+	countries = []string{"Australia", "Austria", "Canada", "France", "Germany", "Ireland", "Japan", "Netherlands",
+		"Norway", "Portugal", "Spain", "United Kingdom", "United States"}
+	adjectives = []string{"Compact", "Deluxe", "Durable", "Ergonomic", "Lightweight", "Portable", "Rugged", "Sleek",
+		"Smart", "Wireless"}
+	nouns = []string{"Backpack", "Bottle", "Chair", "Desk", "Headphones", "Keyboard", "Lamp", "Monitor", "Mouse",
+		"Notebook", "Speaker", "Watch"}
+	words = []string{"alpha", "bright", "calm", "delta", "early", "fresh", "green", "honest", "ideal", "jolly",
+		"kind", "lucky", "merry", "noble", "open", "proud", "quick", "rapid", "solid", "tidy", "urban", "vivid",
+		"warm", "young", "zesty"}
+)
+
+func pick(r *rand.Rand, values []string) string {
+	return values[r.Intn(len(values))]
+}
+
+// fakeGenerators are the generators selectable with db_ext.db_fake or SeedConfig.Generators
+var fakeGenerators = map[string]FakeGenerator{
+	"first_name": func(r *rand.Rand, n int) interface{} { return pick(r, firstNames) },
+	"last_name":  func(r *rand.Rand, n int) interface{} { return pick(r, lastNames) },
+	"name": func(r *rand.Rand, n int) interface{} {
+		return pick(r, firstNames) + " " + pick(r, lastNames)
+	},
+	"username": func(r *rand.Rand, n int) interface{} {
+		return fmt.Sprintf("%s%s%d", strings.ToLower(pick(r, firstNames)), strings.ToLower(pick(r, lastNames))[:1], n)
+	},
+	"email": func(r *rand.Rand, n int) interface{} {
+		return fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(pick(r, firstNames)), strings.ToLower(pick(r, lastNames)), n)
+	},
+	"phone": func(r *rand.Rand, n int) interface{} {
+		return fmt.Sprintf("+1-555-%03d-%04d", r.Intn(1000), r.Intn(10000))
+	},
+	"company": func(r *rand.Rand, n int) interface{} {
+		return pick(r, lastNames) + " " + pick(r, companySuffixes)
+	},
+	"city":    func(r *rand.Rand, n int) interface{} { return pick(r, cities) },
+	"country": func(r *rand.Rand, n int) interface{} { return pick(r, countries) },
+	"url": func(r *rand.Rand, n int) interface{} {
+		return fmt.Sprintf("https://%s%d.example.com", pick(r, words), n)
+	},
+	"product": func(r *rand.Rand, n int) interface{} {
+		return pick(r, adjectives) + " " + pick(r, nouns)
+	},
+	"word": func(r *rand.Rand, n int) interface{} { return pick(r, words) },
+	"sentence": func(r *rand.Rand, n int) interface{} {
+		sentence := make([]string, 4+r.Intn(8))
+		for i := range sentence {
+			sentence[i] = pick(r, words)
+		}
+		return strings.ToUpper(sentence[0][:1]) + strings.Join(sentence, " ")[1:] + "."
+	},
+	"uuid": func(r *rand.Rand, n int) interface{} {
+		b := make([]byte, 16)
+		r.Read(b)
+		b[6] = b[6]&0x0f | 0x40 // Version 4
+		b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	},
+	"money": func(r *rand.Rand, n int) interface{} {
+		return math.Round((1+r.Float64()*999)*100) / 100
+	},
+}
+
+var moneyColumnPattern = regexp.MustCompile(`(price|amount|cost|total|balance|fee|salary)`)
+
+// numericFakeGenerators are the generators of numeric columns
+var numericFakeGenerators = map[string]bool{"money": true}
+
+// RegisterFakeGenerator adds a generator selectable by name, replacing the generator of the same name.
+// Numeric generators return float64 values.
+func RegisterFakeGenerator(name string, generator FakeGenerator, numeric bool) {
+	fakeGenerators[name] = generator
+	numericFakeGenerators[name] = numeric
+}
+
+// inferFakeGenerator selects a generator from the name of a column without an explicit generator,
+// e.g. email for contact_email and money for total_amount
+func inferFakeGenerator(col ColumnSchema) string {
+	name := strings.ToLower(col.Name)
+	if isNumericType(col.Type) {
+		if !isIntegerType(col.Type) && moneyColumnPattern.MatchString(name) {
+			return "money"
+		}
+		return ""
+	}
+	switch baseColumnType(col.Type) {
+	case "JSON", "BLOB", "BINARY", "VARBINARY":
+		return ""
+	}
+	if !isStringType(col.Type) {
+		return ""
+	}
+	for _, generator := range []string{"email", "first_name", "last_name", "username", "phone", "company", "city", "country", "url"} {
+		if strings.Contains(name, generator) {
+			return generator
+		}
+	}
+	switch {
+	case name == "name" || strings.HasSuffix(name, "_name"):
+		return "name"
+	case strings.Contains(name, "description") || strings.Contains(name, "comment"):
+		return "sentence"
+	}
+	return ""
+}
@@ -0,0 +1,447 @@
+package proto_db
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// FixtureMode selects how fixture rows are written
+type FixtureMode int
+
+const (
+	// FixtureModeInsert inserts the rows, failing on rows that already exist
+	FixtureModeInsert FixtureMode = iota
+	// FixtureModeUpsert inserts the rows or updates the existing ones, so fixtures can be applied again
+	FixtureModeUpsert
+)
+
+// fixtureHeader names the message of the rows of a textproto fixture file
+const fixtureHeader = "proto-message:"
+
+// fixtureFile is the JSON fixture file format
+type fixtureFile struct {
+	Message string            `json:"message"`
+	Rows    []json.RawMessage `json:"rows"`
+}
+
+// ReadFixtures reads the rows of annotated messages from fixture files. Files ending in .json hold
+// {"message": "userauth.Role", "rows": [{...}]} with rows in the proto JSON format, other files are
+// textproto naming the message in a header comment followed by one rows block per row:
+//
+//	# proto-message: userauth.Role
+//	rows { role_id: 1 role_name: "admin" }
+//	rows { role_id: 2 role_name: "editor" parent_role_id: 1 }
+//
+// The message must be linked into the binary or loaded with WithSourceInfo.
+func (t Translator) ReadFixtures(paths ...string) ([]proto.Message, error) {
+	var rows []proto.Message
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture file: %w", err)
+		}
+		var fileRows []proto.Message
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			fileRows, err = t.parseJSONFixture(b)
+		} else {
+			fileRows, err = t.parseTextFixture(b)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixture file '%s': %w", path, err)
+		}
+		rows = append(rows, fileRows...)
+	}
+	return rows, nil
+}
+
+func (t Translator) parseJSONFixture(b []byte) ([]proto.Message, error) {
+	var file fixtureFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, err
+	}
+	if file.Message == "" {
+		return nil, fmt.Errorf("missing message name")
+	}
+	newRow, err := t.fixtureMessageType(file.Message)
+	if err != nil {
+		return nil, err
+	}
+	var rows []proto.Message
+	for i, raw := range file.Rows {
+		row := newRow()
+		if err := protojson.Unmarshal(raw, row); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (t Translator) parseTextFixture(b []byte) ([]proto.Message, error) {
+	var message string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			continue
+		}
+		if comment := strings.TrimSpace(strings.TrimPrefix(line, "#")); strings.HasPrefix(comment, fixtureHeader) {
+			message = strings.TrimSpace(strings.TrimPrefix(comment, fixtureHeader))
+			break
+		}
+	}
+	if message == "" {
+		return nil, fmt.Errorf("missing '# %s <message>' header", fixtureHeader)
+	}
+	newRow, err := t.fixtureMessageType(message)
+	if err != nil {
+		return nil, err
+	}
+
+	// The rows are parsed as the repeated field of a fixture message built for the message
+	fixtureDescriptor, err := fixtureMessageDescriptor(newRow().ProtoReflect().Descriptor())
+	if err != nil {
+		return nil, err
+	}
+	fixture := dynamicpb.NewMessage(fixtureDescriptor)
+	if err := prototext.Unmarshal(b, fixture); err != nil {
+		return nil, err
+	}
+	list := fixture.Get(fixtureDescriptor.Fields().ByNumber(1)).List()
+	var rows []proto.Message
+	for i := 0; i < list.Len(); i++ {
+		// Rows are parsed as dynamic messages, they are converted to the generated type of the message
+		b, err := proto.Marshal(list.Get(i).Message().Interface())
+		if err != nil {
+			return nil, err
+		}
+		row := newRow()
+		if err := proto.Unmarshal(b, row); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// fixtureMessageType returns a constructor of the message, of its generated type when it's linked into the binary
+func (t Translator) fixtureMessageType(name string) (func() proto.Message, error) {
+	if messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(name)); err == nil {
+		return func() proto.Message { return messageType.New().Interface() }, nil
+	}
+	md, err := t.findMessageDescriptor(name)
+	if err != nil {
+		return nil, err
+	}
+	return func() proto.Message { return dynamicpb.NewMessage(md) }, nil
+}
+
+// fixtureMessageDescriptor builds a message holding the rows of a fixture file in a repeated rows field
+func fixtureMessageDescriptor(md protoreflect.MessageDescriptor) (protoreflect.MessageDescriptor, error) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("proto_db_fixture.proto"),
+		Package:    proto.String("proto_db_fixture"),
+		Dependency: []string{md.ParentFile().Path()},
+		Syntax:     proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Fixture"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("rows"),
+				JsonName: proto.String("rows"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String("." + string(md.FullName())),
+			}},
+		}},
+	}
+	files := new(protoregistry.Files)
+	if err := files.RegisterFile(md.ParentFile()); err != nil {
+		return nil, err
+	}
+	fd, err := protodesc.NewFile(file, files)
+	if err != nil {
+		return nil, err
+	}
+	return fd.Messages().Get(0), nil
+}
+
+// fixtureRow is a row of a fixture with the values of the columns it sets
+type fixtureRow struct {
+	schema  Schema
+	columns []string
+	values  []interface{}
+}
+
+// GenerateFixtureStatements renders one INSERT statement per row, or an upsert with FixtureModeUpsert, with the
+// values inlined as literals of the dialect. Rows are ordered so that referenced tables come first, rows of a
+// table keep their order. Unset fields of nullable columns and of columns with a default are left out.
+func (t Translator) GenerateFixtureStatements(rows []proto.Message, mode FixtureMode) ([]SqlStatement, error) {
+	fixtureRows, err := t.fixtureRows(rows, mode)
+	if err != nil {
+		return nil, err
+	}
+	var statements []SqlStatement
+	for _, row := range fixtureRows {
+		var literals []string
+		for _, value := range row.values {
+			literal, err := t.sqlLiteral(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of table '%s': %w", row.schema.TableName, err)
+			}
+			literals = append(literals, literal)
+		}
+		statements = append(statements, SqlStatement{
+			Statement: t.fixtureInsertSQL(row, literals, mode) + ";",
+			TableName: row.schema.TableName,
+		})
+	}
+	return statements, nil
+}
+
+// ApplyFixtures writes the rows like GenerateFixtureStatements, passing the values as arguments of the
+// statements. All rows are written in one transaction.
+func (t Translator) ApplyFixtures(database *sql.DB, rows []proto.Message, mode FixtureMode) error {
+	fixtureRows, err := t.fixtureRows(rows, mode)
+	if err != nil {
+		return err
+	}
+	tx, err := database.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for _, row := range fixtureRows {
+		placeholders := make([]string, len(row.values))
+		for i := range placeholders {
+			placeholders[i] = "?"
+		}
+		if _, err := tx.Exec(t.fixtureInsertSQL(row, placeholders, mode), row.values...); err != nil {
+			return StatementError{
+				TableName:  row.schema.TableName,
+				Statement:  t.fixtureInsertSQL(row, placeholders, mode),
+				DriverCode: driverErrorCode(err),
+				Err:        err,
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// fixtureRows converts the messages to rows ordered by foreign key dependencies
+func (t Translator) fixtureRows(rows []proto.Message, mode FixtureMode) ([]fixtureRow, error) {
+	var schemas []Schema
+	rowsByTable := make(map[string][]fixtureRow)
+	for _, row := range rows {
+		schema, err := t.GenerateSchema(row)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := rowsByTable[schema.TableName]; !ok {
+			schemas = append(schemas, schema)
+		}
+		fixture, err := t.fixtureRow(schema, row)
+		if err != nil {
+			return nil, err
+		}
+		if mode == FixtureModeUpsert {
+			if _, err := conflictColumns(fixture); err != nil {
+				return nil, err
+			}
+		}
+		rowsByTable[schema.TableName] = append(rowsByTable[schema.TableName], fixture)
+	}
+
+	sorted, cyclic := sortByDependencies(schemas)
+	if len(cyclic) > 0 {
+		var names []string
+		for _, schema := range cyclic {
+			names = append(names, schema.TableName)
+		}
+		return nil, fmt.Errorf("tables %s reference each other, their rows can't be ordered", strings.Join(names, ", "))
+	}
+	var ordered []fixtureRow
+	for _, schema := range sorted {
+		ordered = append(ordered, rowsByTable[schema.TableName]...)
+	}
+	return ordered, nil
+}
+
+// fixtureRow selects the columns set by a message, with the rules of Update: zero values are written as is, only
+// fields tracking presence (optional, message and oneof fields) can be unset. Unset fields of nullable columns are
+// written as NULL, unset fields of other columns with a default are left out, and so is a zero auto-increment key.
+func (t Translator) fixtureRow(schema Schema, message proto.Message) (fixtureRow, error) {
+	columns, err := t.MessageToColumns(message)
+	if err != nil {
+		return fixtureRow{}, err
+	}
+	m := message.ProtoReflect()
+	oneofColumns := schemaOneofColumns(schema)
+
+	row := fixtureRow{schema: schema}
+	for _, col := range schema.Columns {
+		if col.GeneratedExpression != "" {
+			continue
+		}
+		value := columns[col.Name]
+		if !oneofColumns[col.Name] {
+			field := columnField(m.Descriptor(), col.Name)
+			if field == nil {
+				continue
+			}
+			if col.AutoIncrement && !m.Has(field) {
+				continue
+			}
+			if field.HasPresence() && !m.Has(field) {
+				switch {
+				case !contains(col.Constraints, "NOT NULL") && !col.IsPrimaryKey:
+					value = nil
+				case hasDefault(col):
+					continue
+				}
+			}
+		}
+		row.columns = append(row.columns, col.Name)
+		row.values = append(row.values, value)
+	}
+	return row, nil
+}
+
+// schemaOneofColumns returns the discriminator and variant columns of the oneof groups of a table
+func schemaOneofColumns(schema Schema) map[string]bool {
+	columns := make(map[string]bool)
+	for _, oneof := range schema.Oneofs {
+		columns[oneof.DiscriminatorColumn] = true
+		for _, variant := range oneof.Variants {
+			columns[variant.Column] = true
+		}
+	}
+	return columns
+}
+
+// hasDefault reports whether the database fills the column when it's left out of an INSERT
+func hasDefault(col ColumnSchema) bool {
+	if col.DefaultFunction != "" {
+		return true
+	}
+	for _, constraint := range col.Constraints {
+		if strings.HasPrefix(constraint, "DEFAULT") {
+			return true
+		}
+	}
+	return false
+}
+
+// conflictColumns returns the key identifying the existing row of an upsert: the primary key, or the first
+// unique key, set by the row
+func conflictColumns(row fixtureRow) ([]string, error) {
+	for _, key := range schemaUniqueKeys(row.schema) {
+		set := true
+		for _, column := range key {
+			set = set && contains(row.columns, column)
+		}
+		if set {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("rows of table '%s' must set its primary key or a unique key to be upserted", row.schema.TableName)
+}
+
+// fixtureInsertSQL renders the INSERT statement of a row with the given values or placeholders
+func (t Translator) fixtureInsertSQL(row fixtureRow, values []string, mode FixtureMode) string {
+	var quoted []string
+	for _, column := range row.columns {
+		quoted = append(quoted, fmt.Sprintf("`%s`", column))
+	}
+	statement := fmt.Sprintf("INSERT INTO `%s` (%s) VALUES (%s)", row.schema.TableName, strings.Join(quoted, ", "), strings.Join(values, ", "))
+	if mode != FixtureModeUpsert {
+		return statement
+	}
+
+	key, _ := conflictColumns(row)
+	var updates []string
+	for _, column := range row.columns {
+		if !contains(key, column) {
+			updates = append(updates, fmt.Sprintf("`%s` = excluded.`%s`", column, column))
+		}
+	}
+	if t.dbConnection.DbType == db.DatabaseTypeSQLite {
+		var conflict []string
+		for _, column := range key {
+			conflict = append(conflict, fmt.Sprintf("`%s`", column))
+		}
+		if len(updates) == 0 {
+			return fmt.Sprintf("%s ON CONFLICT (%s) DO NOTHING", statement, strings.Join(conflict, ", "))
+		}
+		return fmt.Sprintf("%s ON CONFLICT (%s) DO UPDATE SET %s", statement, strings.Join(conflict, ", "), strings.Join(updates, ", "))
+	}
+	// MySQL 8.0.19+ names the inserted row with an alias, like the excluded row of SQLite
+	if len(updates) == 0 {
+		updates = []string{fmt.Sprintf("`%s` = excluded.`%s`", key[0], key[0])}
+	}
+	return fmt.Sprintf("%s AS excluded ON DUPLICATE KEY UPDATE %s", statement, strings.Join(updates, ", "))
+}
+
+// sqlLiteral renders a column value as an SQL literal of the dialect
+func (t Translator) sqlLiteral(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case bool:
+		if t.dbConnection.DbType == db.DatabaseTypeSQLite {
+			if v {
+				return "1", nil
+			}
+			return "0", nil
+		}
+		return strings.ToUpper(strconv.FormatBool(v)), nil
+	case int32, int64, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	case float32:
+		return t.floatLiteral(float64(v), 32)
+	case float64:
+		return t.floatLiteral(v, 64)
+	case string:
+		return t.stringLiteral(v), nil
+	case []byte:
+		return fmt.Sprintf("X'%s'", hex.EncodeToString(v)), nil
+	case time.Time:
+		return t.stringLiteral(v.UTC().Format("2006-01-02 15:04:05.999999")), nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
+
+func (t Translator) floatLiteral(value float64, bitSize int) (string, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return "", fmt.Errorf("%v can't be stored", value)
+	}
+	return strconv.FormatFloat(value, 'g', -1, bitSize), nil
+}
+
+// stringLiteral quotes a string, MySQL also treats backslashes as escape characters
+func (t Translator) stringLiteral(value string) string {
+	if t.dbConnection.DbType != db.DatabaseTypeSQLite {
+		value = strings.NewReplacer(`\`, `\\`, "\x00", `\0`).Replace(value)
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,188 @@
+package proto_db
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestReadFixtures(t *testing.T) {
+	rows, err := NewSqliteTranslator().ReadFixtures("testdata/roles.textproto", "testdata/products.json")
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+	require.True(t, proto.Equal(&userauth.Role{RoleId: 2, RoleName: "editor", ParentRoleId: 1}, rows[1]))
+	require.True(t, proto.Equal(&userauth.Product{ProductId: 1, Name: "Ergonomic Keyboard", Price: 79.5, StockQuantity: 120}, rows[3]))
+}
+
+func TestReadFixturesErrors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected string
+	}{
+		{"Missing Header", "roles.textproto", `rows { role_id: 1 }`, "missing '# proto-message: <message>' header"},
+		{"Unknown Message", "roles.textproto", "# proto-message: userauth.Unknown\n", "unknown message 'userauth.Unknown'"},
+		{"Unknown Field", "roles.textproto", "# proto-message: proto_db_translator.Role\nrows { name: \"admin\" }", "unknown field: name"},
+		{"Missing JSON Message", "roles.json", `{"rows": []}`, "missing message name"},
+		{"Invalid JSON Row", "roles.json", `{"message": "proto_db_translator.Role", "rows": [{"roleId": "one"}]}`, "row 1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.file)
+			require.NoError(t, os.WriteFile(path, []byte(test.content), 0o644))
+			_, err := NewSqliteTranslator().ReadFixtures(path)
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+}
+
+func TestGenerateFixtureStatements(t *testing.T) {
+	rows := []proto.Message{
+		&userauth.Orders{OrderId: 10, CustomerId: 1, TotalAmount: 12.5, Status: "it's \\ shipped",
+			OrderDate: timestamppb.New(time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC))},
+		&userauth.Customer{CustomerId: 1, CustomerName: "Ada", Email: "ada@example.com"},
+		&userauth.Payment{PaymentId: 3, OrderId: 10, Method: &userauth.Payment_Iban{Iban: "DE89"}},
+	}
+	tests := []struct {
+		name       string
+		translator Translator
+		mode       FixtureMode
+		expected   []string
+	}{
+		{
+			name:       "MySQL Insert",
+			translator: NewTranslator(db.DefaultMysqlConnection()),
+			mode:       FixtureModeInsert,
+			expected: []string{
+				"INSERT INTO `Customer` (`customer_id`, `customer_name`, `email`, `phone`) VALUES (1, 'Ada', 'ada@example.com', '');",
+				"INSERT INTO `Orders` (`order_id`, `customer_id`, `order_date`, `total_amount`, `status`) VALUES (10, 1, '2024-05-01 10:30:00', 12.5, 'it''s \\\\ shipped');",
+				"INSERT INTO `Payment` (`payment_id`, `order_id`, `method_case`, `card_token`, `iban`, `voucher_id`) VALUES (3, 10, 'iban', NULL, 'DE89', NULL);",
+			},
+		},
+		{
+			name:       "MySQL Upsert",
+			translator: NewTranslator(db.DefaultMysqlConnection()),
+			mode:       FixtureModeUpsert,
+			expected: []string{
+				"INSERT INTO `Customer` (`customer_id`, `customer_name`, `email`, `phone`) VALUES (1, 'Ada', 'ada@example.com', '') AS excluded ON DUPLICATE KEY UPDATE `customer_name` = excluded.`customer_name`, `email` = excluded.`email`, `phone` = excluded.`phone`;",
+			},
+		},
+		{
+			name:       "SQLite Upsert",
+			translator: NewSqliteTranslator(),
+			mode:       FixtureModeUpsert,
+			expected: []string{
+				"INSERT INTO `Customer` (`customer_id`, `customer_name`, `email`, `phone`) VALUES (1, 'Ada', 'ada@example.com', '') ON CONFLICT (`customer_id`) DO UPDATE SET `customer_name` = excluded.`customer_name`, `email` = excluded.`email`, `phone` = excluded.`phone`;",
+				"INSERT INTO `Orders` (`order_id`, `customer_id`, `order_date`, `total_amount`, `status`) VALUES (10, 1, '2024-05-01 10:30:00', 12.5, 'it''s \\ shipped') ON CONFLICT (`order_id`) DO UPDATE SET `customer_id` = excluded.`customer_id`, `order_date` = excluded.`order_date`, `total_amount` = excluded.`total_amount`, `status` = excluded.`status`;",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statements, err := test.translator.GenerateFixtureStatements(rows, test.mode)
+			require.NoError(t, err)
+			require.Len(t, statements, 3)
+			for i, expected := range test.expected {
+				require.Equal(t, expected, statements[i].Statement)
+			}
+		})
+	}
+}
+
+func TestGenerateFixtureStatementsUpsertNeedsKey(t *testing.T) {
+	_, err := NewSqliteTranslator().GenerateFixtureStatements([]proto.Message{&userauth.RoleHierarchy{ChildRoleId: 2, ParentRoleId: 1}}, FixtureModeUpsert)
+	require.ErrorContains(t, err, "rows of table 'RoleHierarchy' must set its primary key or a unique key to be upserted")
+}
+
+func TestApplyFixtures(t *testing.T) {
+	translator := NewSqliteTranslator()
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+	database.SetMaxOpenConns(1)
+	_, err = database.Exec("PRAGMA foreign_keys = ON;")
+	require.NoError(t, err)
+	for _, message := range []proto.Message{&userauth.Role{}, &userauth.Product{}} {
+		schema, err := translator.GenerateSchema(message)
+		require.NoError(t, err)
+		_, err = database.Exec(translator.GenerateCreateTableSQL(schema))
+		require.NoError(t, err)
+	}
+
+	rows, err := translator.ReadFixtures("testdata/products.json", "testdata/roles.textproto")
+	require.NoError(t, err)
+	require.NoError(t, translator.ApplyFixtures(database, rows, FixtureModeInsert))
+
+	// Inserting again fails, upserting is idempotent
+	var statementErr StatementError
+	require.ErrorAs(t, translator.ApplyFixtures(database, rows, FixtureModeInsert), &statementErr)
+	require.Equal(t, "Product", statementErr.TableName)
+	require.Contains(t, []int{1555, 2067}, statementErr.DriverCode, "primary key or unique name")
+	rows[2].(*userauth.Role).Description = "Owns everything"
+	require.NoError(t, translator.ApplyFixtures(database, rows, FixtureModeUpsert))
+	require.NoError(t, translator.ApplyFixtures(database, rows, FixtureModeUpsert))
+
+	var count int
+	var description string
+	require.NoError(t, database.QueryRow("SELECT COUNT(*) FROM `Role`").Scan(&count))
+	require.Equal(t, 3, count)
+	require.NoError(t, database.QueryRow("SELECT description FROM `Role` WHERE role_id = 1").Scan(&description))
+	require.Equal(t, "Owns everything", description)
+
+	// Zero values are written as is, so upserting an empty description clears it
+	rows[2].(*userauth.Role).Description = ""
+	require.NoError(t, translator.ApplyFixtures(database, rows, FixtureModeUpsert))
+	var cleared sql.NullString
+	require.NoError(t, database.QueryRow("SELECT description FROM `Role` WHERE role_id = 1").Scan(&cleared))
+	require.Equal(t, sql.NullString{String: "", Valid: true}, cleared)
+	require.NoError(t, database.QueryRow("SELECT description FROM `Role` WHERE role_id = 2").Scan(&cleared))
+	require.Equal(t, sql.NullString{String: "", Valid: true}, cleared, "the unset description of the editor isn't NULL")
+}
+
+func TestApplyFixturesUpsertZeroValues(t *testing.T) {
+	translator := NewSqliteTranslator()
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+	database.SetMaxOpenConns(1)
+	schema, err := translator.GenerateSchema(&userauth.User{})
+	require.NoError(t, err)
+	_, err = database.Exec(translator.GenerateCreateTableSQL(schema))
+	require.NoError(t, err)
+
+	user := &userauth.User{Id: 1, Username: "ada", Email: "ada@example.com", HashedPassword: "hash", Is_2FaEnabled: true}
+	require.NoError(t, translator.ApplyFixtures(database, []proto.Message{user}, FixtureModeUpsert))
+	user.Is_2FaEnabled = false
+	require.NoError(t, translator.ApplyFixtures(database, []proto.Message{user}, FixtureModeUpsert))
+
+	var enabled bool
+	require.NoError(t, database.QueryRow("SELECT is_2fa_enabled FROM `User` WHERE id = 1").Scan(&enabled))
+	require.False(t, enabled, "upserting false overwrites true")
+}
+
+func TestConflictColumns(t *testing.T) {
+	schema := Schema{
+		TableName: "accounts",
+		Columns: []ColumnSchema{
+			{Name: "id", Type: "INT", IsPrimaryKey: true, AutoIncrement: true},
+			{Name: "tenant_id", Type: "INT", Constraints: []string{"NOT NULL"}},
+			{Name: "handle", Type: "VARCHAR(255)", Constraints: []string{"NOT NULL"}},
+		},
+		CompositeIndexes: []IndexSchema{
+			{Name: "accounts_tenant_handle_idx", Unique: true, Columns: []IndexColumn{{Name: "tenant_id"}, {Name: "handle"}}},
+		},
+	}
+	key, err := conflictColumns(fixtureRow{schema: schema, columns: []string{"tenant_id", "handle"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"tenant_id", "handle"}, key, "a unique index is a conflict key when the primary key isn't set")
+}
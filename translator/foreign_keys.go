@@ -0,0 +1,139 @@
+package proto_db
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	dbExt "github.com/imran31415/proto-db-translator/annotations"
+	"github.com/imran31415/proto-db-translator/translator/db"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// parseForeignKeys parses the db_foreign_key options of a message.
+// Foreign keys referencing a message are resolved to its table and columns.
+func (t Translator) parseForeignKeys(md protoreflect.MessageDescriptor, columns []ColumnSchema) ([]ForeignKeySchema, error) {
+	options, ok := md.Options().(*descriptorpb.MessageOptions)
+	if !ok || options == nil {
+		return nil, nil
+	}
+
+	dbForeignKeys, _ := proto.GetExtension(options, dbExt.E_DbForeignKey).([]*dbExt.DbForeignKey)
+	var foreignKeys []ForeignKeySchema
+	for i, dbForeignKey := range dbForeignKeys {
+		if len(dbForeignKey.GetColumns()) == 0 || (dbForeignKey.GetReferencesTable() == "") == (dbForeignKey.GetReferencesMessage() == "") {
+			return nil, fmt.Errorf("foreign key %d of message '%s' needs columns and either a referenced table or message", i, md.FullName())
+		}
+		if len(dbForeignKey.GetColumns()) != len(dbForeignKey.GetReferencesColumns()) {
+			return nil, fmt.Errorf("foreign key %d of message '%s' has %d columns but references %d columns",
+				i, md.FullName(), len(dbForeignKey.GetColumns()), len(dbForeignKey.GetReferencesColumns()))
+		}
+		foreignKey := ForeignKeySchema{
+			Name:              dbForeignKey.GetName(),
+			Columns:           dbForeignKey.GetColumns(),
+			ReferencesTable:   dbForeignKey.GetReferencesTable(),
+			ReferencesColumns: dbForeignKey.GetReferencesColumns(),
+			OnDelete:          parseForeignKeyAction(dbForeignKey.GetOnDelete()),
+			OnUpdate:          parseForeignKeyAction(dbForeignKey.GetOnUpdate()),
+		}
+		if dbForeignKey.GetReferencesMessage() != "" {
+			var referencing []ColumnSchema
+			for _, name := range foreignKey.Columns {
+				column, ok := findColumnInSchema(name, columns)
+				if !ok {
+					return nil, fmt.Errorf("foreign key %d of message '%s' references unknown column '%s'", i, md.FullName(), name)
+				}
+				referencing = append(referencing, column)
+			}
+			var err error
+			foreignKey.ReferencesTable, foreignKey.ReferencesColumns, err = t.resolveReferences(dbForeignKey.GetReferencesMessage(), foreignKey.ReferencesColumns, referencing)
+			if err != nil {
+				return nil, fmt.Errorf("foreign key %d of message '%s': %w", i, md.FullName(), err)
+			}
+		}
+		if foreignKey.Name == "" {
+			foreignKey.Name = truncatedIdentifier(strings.ToLower(fmt.Sprintf("%s_%s", md.Name(), strings.Join(foreignKey.Columns, "_"))), "_fk")
+		}
+		foreignKeys = append(foreignKeys, foreignKey)
+	}
+	return foreignKeys, nil
+}
+
+// validateForeignKeys checks that the foreign keys of a schema only use columns of the table
+func validateForeignKeys(schema Schema) error {
+	names := make(map[string]bool)
+	for _, foreignKey := range schema.ForeignKeys {
+		if names[foreignKey.Name] {
+			return fmt.Errorf("duplicate foreign key name '%s'", foreignKey.Name)
+		}
+		names[foreignKey.Name] = true
+		for _, column := range foreignKey.Columns {
+			if _, ok := findColumnInSchema(column, schema.Columns); !ok {
+				return fmt.Errorf("foreign key '%s' references unknown column '%s'", foreignKey.Name, column)
+			}
+		}
+	}
+	return nil
+}
+
+// foreignKeySQL renders a named foreign key constraint.
+// Both MySQL and SQLite support ON UPDATE actions on table constraints.
+func foreignKeySQL(foreignKey ForeignKeySchema) string {
+	var sql strings.Builder
+	sql.WriteString(fmt.Sprintf("CONSTRAINT `%s` FOREIGN KEY (%s) REFERENCES `%s` (%s)",
+		foreignKey.Name, strings.Join(foreignKey.Columns, ", "), foreignKey.ReferencesTable, strings.Join(foreignKey.ReferencesColumns, ", ")))
+	if foreignKey.OnDelete != "" {
+		sql.WriteString(fmt.Sprintf(" ON DELETE %s", foreignKey.OnDelete))
+	}
+	if foreignKey.OnUpdate != "" {
+		sql.WriteString(fmt.Sprintf(" ON UPDATE %s", foreignKey.OnUpdate))
+	}
+	return sql.String()
+}
+
+// foreignKeyMigration renders the statements adding, dropping and replacing foreign keys by name.
+// SQLite can't alter the foreign keys of an existing table.
+func (t Translator) foreignKeyMigration(oldSchema, newSchema Schema) string {
+	oldForeignKeys := make(map[string]ForeignKeySchema)
+	for _, foreignKey := range oldSchema.ForeignKeys {
+		oldForeignKeys[foreignKey.Name] = foreignKey
+	}
+	newForeignKeys := make(map[string]bool)
+	for _, foreignKey := range newSchema.ForeignKeys {
+		newForeignKeys[foreignKey.Name] = true
+	}
+
+	var dropped, added []ForeignKeySchema
+	for _, foreignKey := range oldSchema.ForeignKeys {
+		if !newForeignKeys[foreignKey.Name] {
+			dropped = append(dropped, foreignKey)
+		}
+	}
+	for _, foreignKey := range newSchema.ForeignKeys {
+		oldForeignKey, exists := oldForeignKeys[foreignKey.Name]
+		if exists && reflect.DeepEqual(oldForeignKey, foreignKey) {
+			continue
+		}
+		if exists {
+			dropped = append(dropped, oldForeignKey)
+		}
+		added = append(added, foreignKey)
+	}
+	if len(dropped) == 0 && len(added) == 0 {
+		return ""
+	}
+	if t.dbConnection.DbType == db.DatabaseTypeSQLite {
+		return fmt.Sprintf("-- SQLite can't alter the foreign keys of %s, the table must be rebuilt\n", newSchema.TableName)
+	}
+
+	var migration strings.Builder
+	for _, foreignKey := range dropped {
+		migration.WriteString(fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY `%s`;\n", newSchema.TableName, foreignKey.Name))
+	}
+	for _, foreignKey := range added {
+		migration.WriteString(fmt.Sprintf("ALTER TABLE %s ADD %s;\n", newSchema.TableName, foreignKeySQL(foreignKey)))
+	}
+	return migration.String()
+}
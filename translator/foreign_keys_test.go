@@ -0,0 +1,178 @@
+package proto_db
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	dbExt "github.com/imran31415/proto-db-translator/annotations"
+	"github.com/imran31415/proto-db-translator/translator/db"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestGenerateSchemaForeignKeys(t *testing.T) {
+	schema, err := NewSqliteTranslator().GenerateSchema(&userauth.OrderDetailShipments{})
+	require.NoError(t, err)
+	require.Equal(t, []ForeignKeySchema{{
+		Name:              "orderdetailshipments_order_detail_fk",
+		Columns:           []string{"order_id", "product_id"},
+		ReferencesTable:   "OrderDetails",
+		ReferencesColumns: []string{"order_id", "product_id"},
+		OnDelete:          "CASCADE",
+		OnUpdate:          "CASCADE",
+	}}, schema.ForeignKeys)
+}
+
+func TestParseForeignKeysDefaultName(t *testing.T) {
+	translator := NewSqliteTranslator()
+	schema, err := translator.GenerateSchema(&userauth.OrderDetailShipments{})
+	require.NoError(t, err)
+
+	// Leave the name of the foreign key out and rename the message so that the default name is too long
+	fdp := protodesc.ToFileDescriptorProto(userauth.File_proto_order_details_proto)
+	for _, message := range fdp.MessageType {
+		if message.GetName() != "OrderDetailShipments" {
+			continue
+		}
+		message.Name = proto.String(strings.Repeat("Shipments", 8))
+		foreignKeys := proto.GetExtension(message.Options, dbExt.E_DbForeignKey).([]*dbExt.DbForeignKey)
+		foreignKeys[0].Name = ""
+	}
+	fd, err := protodesc.FileOptions{AllowUnresolvable: true}.New(fdp, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+
+	foreignKeys, err := translator.parseForeignKeys(fd.Messages().ByName(protoreflect.Name(strings.Repeat("Shipments", 8))), schema.Columns)
+	require.NoError(t, err)
+	require.Len(t, foreignKeys[0].Name, maxIdentifierLength)
+	require.Equal(t, strings.Repeat("shipments", 8)[:61]+"_fk", foreignKeys[0].Name)
+}
+
+func TestForeignKeySQL(t *testing.T) {
+	tests := []struct {
+		name       string
+		foreignKey ForeignKeySchema
+		expected   string
+	}{
+		{
+			name: "composite with actions",
+			foreignKey: ForeignKeySchema{
+				Name:              "shipments_order_detail_fk",
+				Columns:           []string{"order_id", "product_id"},
+				ReferencesTable:   "OrderDetails",
+				ReferencesColumns: []string{"order_id", "product_id"},
+				OnDelete:          "CASCADE",
+				OnUpdate:          "RESTRICT",
+			},
+			expected: "CONSTRAINT `shipments_order_detail_fk` FOREIGN KEY (order_id, product_id) REFERENCES `OrderDetails` (order_id, product_id) ON DELETE CASCADE ON UPDATE RESTRICT",
+		},
+		{
+			name: "single column without actions",
+			foreignKey: ForeignKeySchema{
+				Name:              "orders_customer_fk",
+				Columns:           []string{"customer_id"},
+				ReferencesTable:   "Customer",
+				ReferencesColumns: []string{"customer_id"},
+			},
+			expected: "CONSTRAINT `orders_customer_fk` FOREIGN KEY (customer_id) REFERENCES `Customer` (customer_id)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, foreignKeySQL(tt.foreignKey))
+		})
+	}
+}
+
+func TestValidateSqliteCompositeForeignKey(t *testing.T) {
+	statements, err := NewSqliteTranslator().ValidateSchema([]proto.Message{&userauth.OrderDetails{}, &userauth.OrderDetailShipments{}})
+	require.NoError(t, err)
+	require.Len(t, statements, 2)
+	require.Contains(t, statements[1].Statement, "CONSTRAINT `orderdetailshipments_order_detail_fk` FOREIGN KEY (order_id, product_id) "+
+		"REFERENCES `OrderDetails` (order_id, product_id) ON DELETE CASCADE ON UPDATE CASCADE")
+
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+	_, err = database.Exec("PRAGMA foreign_keys = ON;")
+	require.NoError(t, err)
+	for _, statement := range statements {
+		_, err = database.Exec(statement.Statement)
+		require.NoError(t, err)
+	}
+
+	_, err = database.Exec("INSERT INTO OrderDetails (order_id, product_id, quantity) VALUES (1, 2, 3)")
+	require.NoError(t, err)
+	_, err = database.Exec("INSERT INTO OrderDetailShipments (shipment_id, order_id, product_id, quantity) VALUES (1, 1, 2, 3)")
+	require.NoError(t, err)
+
+	// Both columns must match an order line
+	_, err = database.Exec("INSERT INTO OrderDetailShipments (shipment_id, order_id, product_id, quantity) VALUES (2, 1, 3, 3)")
+	require.ErrorContains(t, err, "FOREIGN KEY constraint failed")
+
+	// Updates and deletes cascade
+	_, err = database.Exec("UPDATE OrderDetails SET product_id = 5")
+	require.NoError(t, err)
+	var productID int
+	require.NoError(t, database.QueryRow("SELECT product_id FROM OrderDetailShipments WHERE shipment_id = 1").Scan(&productID))
+	require.Equal(t, 5, productID)
+	_, err = database.Exec("DELETE FROM OrderDetails")
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, database.QueryRow("SELECT COUNT(*) FROM OrderDetailShipments").Scan(&count))
+	require.Zero(t, count)
+}
+
+func TestValidateForeignKeys(t *testing.T) {
+	schema := Schema{
+		TableName: "Shipments",
+		Columns:   []ColumnSchema{{Name: "order_id"}, {Name: "product_id"}},
+		ForeignKeys: []ForeignKeySchema{{
+			Name:              "shipments_order_detail_fk",
+			Columns:           []string{"order_id", "product"},
+			ReferencesTable:   "OrderDetails",
+			ReferencesColumns: []string{"order_id", "product_id"},
+		}},
+	}
+	require.EqualError(t, validateForeignKeys(schema), "foreign key 'shipments_order_detail_fk' references unknown column 'product'")
+
+	schema.ForeignKeys[0].Columns = []string{"order_id", "product_id"}
+	require.NoError(t, validateForeignKeys(schema))
+
+	schema.ForeignKeys = append(schema.ForeignKeys, schema.ForeignKeys[0])
+	require.EqualError(t, validateForeignKeys(schema), "duplicate foreign key name 'shipments_order_detail_fk'")
+}
+
+func TestForeignKeyMigration(t *testing.T) {
+	orderDetailFK := ForeignKeySchema{
+		Name:              "shipments_order_detail_fk",
+		Columns:           []string{"order_id", "product_id"},
+		ReferencesTable:   "OrderDetails",
+		ReferencesColumns: []string{"order_id", "product_id"},
+	}
+	carrierFK := ForeignKeySchema{
+		Name:              "shipments_carrier_fk",
+		Columns:           []string{"carrier_id"},
+		ReferencesTable:   "Carriers",
+		ReferencesColumns: []string{"carrier_id"},
+	}
+	cascadingOrderDetailFK := orderDetailFK
+	cascadingOrderDetailFK.OnDelete = "CASCADE"
+
+	oldSchema := Schema{TableName: "Shipments", ForeignKeys: []ForeignKeySchema{orderDetailFK, carrierFK}}
+	newSchema := Schema{TableName: "Shipments", ForeignKeys: []ForeignKeySchema{cascadingOrderDetailFK}}
+
+	mysql := NewTranslator(db.DefaultMysqlConnection())
+	require.Equal(t, "ALTER TABLE Shipments DROP FOREIGN KEY `shipments_carrier_fk`;\n"+
+		"ALTER TABLE Shipments DROP FOREIGN KEY `shipments_order_detail_fk`;\n"+
+		"ALTER TABLE Shipments ADD CONSTRAINT `shipments_order_detail_fk` FOREIGN KEY (order_id, product_id) REFERENCES `OrderDetails` (order_id, product_id) ON DELETE CASCADE;\n",
+		mysql.foreignKeyMigration(oldSchema, newSchema))
+	require.Empty(t, mysql.foreignKeyMigration(newSchema, newSchema))
+
+	require.Equal(t, "-- SQLite can't alter the foreign keys of Shipments, the table must be rebuilt\n",
+		NewSqliteTranslator().foreignKeyMigration(oldSchema, newSchema))
+}
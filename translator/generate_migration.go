@@ -3,6 +3,8 @@ package proto_db
 import (
 	"fmt"
 	"strings"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
 )
 
 // Compare schemas and generate migration SQL
@@ -20,15 +22,21 @@ func (t Translator) GenerateMigration(oldSchema, newSchema Schema) string {
 	// Handle new or modified columns
 	for _, newCol := range newSchema.Columns {
 		if oldCol, exists := oldColumns[newCol.Name]; exists {
+			// A column can't be switched between regular, VIRTUAL and STORED in place, so it is re-created
+			if generatedStorageChanged(oldCol, newCol) {
+				migration.WriteString(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;\n", newSchema.TableName, newCol.Name))
+				migration.WriteString(t.addColumnSQL(newSchema.TableName, newCol))
+				continue
+			}
 			// Check if the column has changed
-			if oldCol.Type != newCol.Type || !equalConstraints(oldCol.Constraints, newCol.Constraints) {
-				migration.WriteString(fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s %s;\n",
-					newSchema.TableName, newCol.Name, newCol.Type, joinConstraints(newCol.Constraints)))
+			if columnTypeSQL(oldCol) != columnTypeSQL(newCol) || !equalConstraints(oldCol.Constraints, newCol.Constraints) ||
+				oldCol.GeneratedExpression != newCol.GeneratedExpression || t.commentChanged(oldCol.Comment, newCol.Comment) {
+				migration.WriteString(fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;\n",
+					newSchema.TableName, t.columnDefinitionSQL(newCol)))
 			}
 		} else {
 			// Column is new
-			migration.WriteString(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s %s;\n",
-				newSchema.TableName, newCol.Name, newCol.Type, joinConstraints(newCol.Constraints)))
+			migration.WriteString(t.addColumnSQL(newSchema.TableName, newCol))
 		}
 	}
 
@@ -39,5 +47,88 @@ func (t Translator) GenerateMigration(oldSchema, newSchema Schema) string {
 		}
 	}
 
+	// Handle new, changed and removed indexes
+	migration.WriteString(t.indexMigration(oldSchema, newSchema))
+
+	// Handle new, changed and removed foreign keys
+	migration.WriteString(t.foreignKeyMigration(oldSchema, newSchema))
+
+	// Handle new, changed and removed CHECK constraints
+	migration.WriteString(t.checkConstraintMigration(oldSchema, newSchema))
+
+	// Update the table options
+	migration.WriteString(t.tableOptionsMigration(oldSchema, newSchema))
+
+	// Update the table comment
+	if t.commentChanged(oldSchema.Comment, newSchema.Comment) {
+		migration.WriteString(fmt.Sprintf("ALTER TABLE %s COMMENT = %s;\n", newSchema.TableName, t.stringLiteral(newSchema.Comment)))
+	}
+
 	return migration.String()
 }
+
+// addColumnSQL renders the ALTER TABLE statement adding a column
+func (t Translator) addColumnSQL(tableName string, col ColumnSchema) string {
+	if t.dbConnection.DbType == db.DatabaseTypeSQLite && generatedStorage(col) == "STORED" {
+		// SQLite only supports adding VIRTUAL generated columns with ALTER TABLE
+		return fmt.Sprintf("-- SQLite can't add STORED generated column %s to %s, the table must be rebuilt\n", col.Name, tableName)
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;\n", tableName, t.columnDefinitionSQL(col))
+}
+
+// columnDefinitionSQL renders the column definition used by migrations
+func (t Translator) columnDefinitionSQL(col ColumnSchema) string {
+	definition := fmt.Sprintf("%s %s", col.Name, columnTypeSQL(col))
+	if col.GeneratedExpression != "" {
+		definition += " " + generatedColumnSQL(col)
+	}
+	definition = strings.TrimSpace(fmt.Sprintf("%s %s", definition, joinConstraints(col.Constraints)))
+	if col.Comment != "" && t.dbConnection.DbType != db.DatabaseTypeSQLite {
+		definition += fmt.Sprintf(" COMMENT %s", t.stringLiteral(col.Comment))
+	}
+	return definition
+}
+
+// commentChanged reports whether a comment changed, SQLite doesn't store comments so they are never migrated
+func (t Translator) commentChanged(oldComment, newComment string) bool {
+	return oldComment != newComment && t.dbConnection.DbType != db.DatabaseTypeSQLite
+}
+
+// generatedStorageChanged reports whether a column became generated, stopped being generated or changed its storage
+func generatedStorageChanged(oldCol, newCol ColumnSchema) bool {
+	return generatedStorage(oldCol) != generatedStorage(newCol)
+}
+
+// tableOptionsMigration renders the statements applying changed table options.
+// Options that are no longer set keep their current value.
+func (t Translator) tableOptionsMigration(oldSchema, newSchema Schema) string {
+	oldOptions, newOptions := oldSchema.Options, newSchema.Options
+	if t.dbConnection.DbType == db.DatabaseTypeSQLite {
+		if oldOptions.Strict != newOptions.Strict || oldOptions.WithoutRowid != newOptions.WithoutRowid {
+			return fmt.Sprintf("-- SQLite can't change STRICT or WITHOUT ROWID of %s, the table must be rebuilt\n", newSchema.TableName)
+		}
+		return ""
+	}
+
+	changed := TableOptions{}
+	if newOptions.Engine != oldOptions.Engine {
+		changed.Engine = newOptions.Engine
+	}
+	if newOptions.DefaultCharset != oldOptions.DefaultCharset {
+		changed.DefaultCharset = newOptions.DefaultCharset
+	}
+	if newOptions.Collation != oldOptions.Collation {
+		changed.Collation = newOptions.Collation
+	}
+	if newOptions.RowFormat != oldOptions.RowFormat {
+		changed.RowFormat = newOptions.RowFormat
+	}
+	if newOptions.AutoIncrement != oldOptions.AutoIncrement {
+		changed.AutoIncrement = newOptions.AutoIncrement
+	}
+	options := mysqlTableOptions(changed)
+	if len(options) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("ALTER TABLE %s %s;\n", newSchema.TableName, strings.Join(options, " "))
+}
@@ -99,6 +99,140 @@ ALTER TABLE User ADD COLUMN email VARCHAR(255) NOT NULL;
 ALTER TABLE User MODIFY COLUMN username VARCHAR(255) NOT NULL;
 `,
 		},
+		// Scenario 2b: Changing the precision of a DECIMAL column
+		{
+			name: "Modify Decimal Precision",
+			oldSchema: Schema{
+				TableName: "Orders",
+				Columns: []ColumnSchema{
+					{Name: "total_amount", Type: "DECIMAL", Precision: 10, Scale: 2, Constraints: []string{"NOT NULL"}},
+				},
+			},
+			newSchema: Schema{
+				TableName: "Orders",
+				Columns: []ColumnSchema{
+					{Name: "total_amount", Type: "DECIMAL", Precision: 12, Scale: 2, Constraints: []string{"NOT NULL"}},
+				},
+			},
+			expected: `-- Migration for table: Orders
+ALTER TABLE Orders MODIFY COLUMN total_amount DECIMAL(12,2) NOT NULL;
+`,
+		},
+		// Scenario 2c: Adding and changing generated columns
+		{
+			name: "Generated Columns",
+			oldSchema: Schema{
+				TableName: "Customer",
+				Columns: []ColumnSchema{
+					{Name: "email", Type: "VARCHAR(255)", Constraints: []string{"NOT NULL"}},
+					{Name: "email_lower", Type: "VARCHAR(255)", GeneratedExpression: "LOWER(email)", GeneratedStorage: "VIRTUAL"},
+					{Name: "email_length", Type: "INT", GeneratedExpression: "LENGTH(email)", GeneratedStorage: "VIRTUAL"},
+				},
+			},
+			newSchema: Schema{
+				TableName: "Customer",
+				Columns: []ColumnSchema{
+					{Name: "email", Type: "VARCHAR(255)", Constraints: []string{"NOT NULL"}},
+					{Name: "email_lower", Type: "VARCHAR(255)", GeneratedExpression: "LOWER(TRIM(email))", GeneratedStorage: "VIRTUAL"},
+					{Name: "email_length", Type: "INT", GeneratedExpression: "LENGTH(email)", GeneratedStorage: "STORED"},
+					{Name: "email_upper", Type: "VARCHAR(255)", GeneratedExpression: "UPPER(email)"},
+				},
+			},
+			expected: `-- Migration for table: Customer
+ALTER TABLE Customer MODIFY COLUMN email_lower VARCHAR(255) GENERATED ALWAYS AS (LOWER(TRIM(email))) VIRTUAL;
+ALTER TABLE Customer DROP COLUMN email_length;
+ALTER TABLE Customer ADD COLUMN email_length INT GENERATED ALWAYS AS (LENGTH(email)) STORED;
+ALTER TABLE Customer ADD COLUMN email_upper VARCHAR(255) GENERATED ALWAYS AS (UPPER(email)) VIRTUAL;
+`,
+		},
+		// Scenario 2d: Changing table and column comments
+		{
+			name: "Update Comments",
+			oldSchema: Schema{
+				TableName: "Customer",
+				Comment:   "Customer entity",
+				Columns: []ColumnSchema{
+					{Name: "email", Type: "VARCHAR(255)", Constraints: []string{"NOT NULL"}},
+					{Name: "phone", Type: "VARCHAR(32)", Comment: "Phone"},
+				},
+			},
+			newSchema: Schema{
+				TableName: "Customer",
+				Comment:   "Message for the Customer entity",
+				Columns: []ColumnSchema{
+					{Name: "email", Type: "VARCHAR(255)", Constraints: []string{"NOT NULL"}, Comment: "Contact email"},
+					{Name: "phone", Type: "VARCHAR(32)", Comment: "Phone"},
+				},
+			},
+			expected: `-- Migration for table: Customer
+ALTER TABLE Customer MODIFY COLUMN email VARCHAR(255) NOT NULL COMMENT 'Contact email';
+ALTER TABLE Customer COMMENT = 'Message for the Customer entity';
+`,
+		},
+		// Scenario 2e: Changing table options
+		{
+			name: "Update Table Options",
+			oldSchema: Schema{
+				TableName: "Orders",
+				Columns:   []ColumnSchema{{Name: "order_id", Type: "INT", Constraints: []string{"NOT NULL"}}},
+				Options:   TableOptions{Engine: "InnoDB", DefaultCharset: "utf8", RowFormat: "COMPACT"},
+			},
+			newSchema: Schema{
+				TableName: "Orders",
+				Columns:   []ColumnSchema{{Name: "order_id", Type: "INT", Constraints: []string{"NOT NULL"}}},
+				Options:   TableOptions{Engine: "InnoDB", DefaultCharset: "utf8mb4", Collation: "utf8mb4_unicode_ci", RowFormat: "DYNAMIC", AutoIncrement: 500},
+			},
+			expected: `-- Migration for table: Orders
+ALTER TABLE Orders DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC AUTO_INCREMENT=500;
+`,
+		},
+		// Scenario 2f: Adding, changing and removing indexes
+		{
+			name: "Update Indexes",
+			oldSchema: Schema{
+				TableName: "Product",
+				Columns:   []ColumnSchema{{Name: "name", Type: "VARCHAR(255)"}, {Name: "description", Type: "TEXT"}},
+				Indexes: []IndexSchema{
+					{Name: "Product_name_idx", Columns: []IndexColumn{{Name: "name"}}},
+					{Name: "Product_description_idx", Columns: []IndexColumn{{Name: "description"}}, Type: "FULLTEXT"},
+				},
+				CompositeIndexes: []IndexSchema{{Name: "product_lookup_idx", Columns: []IndexColumn{{Name: "name"}}}},
+			},
+			newSchema: Schema{
+				TableName: "Product",
+				Columns:   []ColumnSchema{{Name: "name", Type: "VARCHAR(255)"}, {Name: "description", Type: "TEXT"}},
+				Indexes: []IndexSchema{
+					{Name: "Product_description_idx", Columns: []IndexColumn{{Name: "description"}}, Type: "FULLTEXT"},
+				},
+				CompositeIndexes: []IndexSchema{
+					{Name: "product_lookup_idx", Columns: []IndexColumn{{Name: "name", Desc: true}}, Unique: true},
+					{Name: "Product_name_description_idx", Columns: []IndexColumn{{Name: "name"}, {Name: "description", PrefixLength: 32}}},
+				},
+			},
+			expected: "-- Migration for table: Product\n" +
+				"DROP INDEX `Product_name_idx` ON `Product`;\n" +
+				"DROP INDEX `product_lookup_idx` ON `Product`;\n" +
+				"CREATE UNIQUE INDEX `product_lookup_idx` ON `Product` (name DESC);\n" +
+				"CREATE INDEX `Product_name_description_idx` ON `Product` (name, description(32));\n",
+		},
+		// Scenario 2g: Adding an expression index, partial indexes are not supported by MySQL
+		{
+			name: "Add Expression Index",
+			oldSchema: Schema{
+				TableName: "User",
+				Columns:   []ColumnSchema{{Name: "email", Type: "VARCHAR(255)"}},
+			},
+			newSchema: Schema{
+				TableName: "User",
+				Columns:   []ColumnSchema{{Name: "email", Type: "VARCHAR(255)"}},
+				CompositeIndexes: []IndexSchema{
+					{Name: "User_email_ci_idx", Columns: []IndexColumn{{Expression: "LOWER(email)"}}, Unique: true},
+					{Name: "User_email_active_idx", Columns: []IndexColumn{{Name: "email"}}, Where: "email IS NOT NULL"},
+				},
+			},
+			expected: "-- Migration for table: User\n" +
+				"CREATE UNIQUE INDEX `User_email_ci_idx` ON `User` ((LOWER(email)));\n",
+		},
 		// Scenario 3: Adding a constraint to an existing column
 		{
 			name: "Add Constraint to Column",
@@ -1,122 +1,447 @@
 package proto_db
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
-	"log"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/imran31415/proto-db-translator/templates"
+	"github.com/imran31415/proto-db-translator/translator/db"
+	"github.com/kenshaw/inflector"
+	xo "github.com/xo/xo/types"
 	"google.golang.org/protobuf/proto"
 )
 
+// GenerateModels writes the Go models of the tables of the messages to outputDir, in a package named after
+// the directory. The templates under templates/ are rendered in process from the schemas, no database or
+// xo binary is needed and the output is the same on every OS.
 func (t Translator) GenerateModels(outputDir string, protoMessages []proto.Message) error {
-	// Database connection string (without specifying a database)
-	dbConnStr := fmt.Sprintf("%s:%s@tcp(%s:%s)/", t.dbConnection.DbUser, t.dbConnection.DbPass, t.dbConnection.DbHost, t.dbConnection.DbPort)
-	dbConnStr += "?multiStatements=true"
-	// Step 1: Connect to MySQL
-	db, err := sql.Open("mysql", dbConnStr)
+	dir, err := filepath.Abs(outputDir)
 	if err != nil {
-		return fmt.Errorf("failed to connect to MySQL: %w", err)
+		return err
 	}
-	defer db.Close()
-	_, dropErr := db.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s;", t.dbConnection.DbName))
-	if dropErr != nil {
-		fmt.Printf("Failed to drop test database: %v\n", dropErr)
+	files, err := t.RenderModels(filepath.Base(dir), protoMessages)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory '%s': %w", outputDir, err)
 	}
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), files[name], 0o644); err != nil {
+			return fmt.Errorf("failed to write model '%s': %w", name, err)
+		}
+	}
+	return nil
+}
 
-	// Step 2: Create and switch to the test database
-	_, err = db.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s;", t.dbConnection.DbName))
-	if err != nil {
-		return fmt.Errorf("failed to create test database: %w", err)
+// RenderModels renders the Go models of the tables of the messages, returning the content of each file
+// by file name. Each model gets ToProto and FromProto methods converting from and to its message.
+func (t Translator) RenderModels(pkg string, protoMessages []proto.Message) (map[string][]byte, error) {
+	if err := t.ValidateSchemaOffline(protoMessages); err != nil {
+		return nil, fmt.Errorf("schema validation failed: %w", err)
+	}
+	var schemas []Schema
+	imports := newModelImports()
+	protos := make(map[string]gotpl.Proto)
+	for _, message := range protoMessages {
+		schema, err := t.GenerateSchema(message)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+		if protos[schema.TableName], err = modelProto(message, schema, imports); err != nil {
+			return nil, fmt.Errorf("table '%s': %w", schema.TableName, err)
+		}
 	}
 
-	dbConnStr = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", t.dbConnection.DbUser, t.dbConnection.DbPass, t.dbConnection.DbHost, t.dbConnection.DbPort, t.dbConnection.DbName)
-	// Step 1: Connect to MySQL
-	dbConnStr += "?multiStatements=true"
-	db, err = sql.Open("mysql", dbConnStr)
+	driver, err := t.modelDriver()
 	if err != nil {
-		return fmt.Errorf("failed to connect to MySQL: %w", err)
+		return nil, err
 	}
-
-	// Validate schema
-	statements, err := t.ValidateSchema(protoMessages)
+	set, generated, onDelete, err := t.modelSet(driver, schemas)
 	if err != nil {
-		return fmt.Errorf("schema validation failed for tables '%v': %w", protoMessages, err)
+		return nil, err
 	}
+	ctx := context.WithValue(context.Background(), xo.DriverKey, driver)
+	ctx = context.WithValue(ctx, xo.SchemaKey, t.dbConnection.DbName)
+	files, err := gotpl.Render(ctx, set, map[xo.ContextKey]interface{}{
+		gotpl.PkgKey:       pkg,
+		gotpl.GeneratedKey: generated,
+		gotpl.ImportKey:    imports.list(),
+		gotpl.ProtoKey:     protos,
+		gotpl.OnDeleteKey:  onDelete,
+		// Table names are left unqualified, so the models work with any database name
+		gotpl.UnqualifiedKey: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("model generation failed: %w", err)
+	}
+	return files, nil
+}
+
+// modelDriver returns the xo driver name of the target database
+func (t Translator) modelDriver() (string, error) {
+	switch t.dbConnection.DbType {
+	case db.DatabaseTypeMySQL:
+		return "mysql", nil
+	case db.DatabaseTypeSQLite:
+		return "sqlite3", nil
+	}
+	return "", fmt.Errorf("model generation is not supported for database type %d", t.dbConnection.DbType)
+}
 
-	execute := ""
-	for x, statement := range statements {
-		if x == 0 {
-			execute += statement.Statement
-		} else {
-			// execute := "\n"
-			execute += statement.Statement
+// modelSet describes the tables the way xo loads them from the database: columns in order, the indexes
+// the database creates for the keys and constraints, and the foreign keys. It also returns the generated
+// columns, as <table>.<column>, which the models only read, and the ON DELETE actions of the foreign keys,
+// as <table>.<constraint>=<action>, which the in-memory repositories apply.
+func (t Translator) modelSet(driver string, schemas []Schema) (*xo.Set, []string, []string, error) {
+	xoSchema := xo.Schema{Driver: driver, Name: t.dbConnection.DbName}
+	var generated []string
+	tables := make(map[string]*xo.Table)
+	for _, schema := range schemas {
+		table, enums, err := t.modelTable(driver, schema)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("table '%s': %w", schema.TableName, err)
+		}
+		xoSchema.Tables = append(xoSchema.Tables, table)
+		xoSchema.Enums = append(xoSchema.Enums, enums...)
+		for _, col := range schema.Columns {
+			if col.GeneratedExpression != "" {
+				generated = append(generated, schema.TableName+"."+col.Name)
+			}
 		}
 	}
+	sort.Slice(xoSchema.Tables, func(i, j int) bool {
+		return xoSchema.Tables[i].Name < xoSchema.Tables[j].Name
+	})
+	for i := range xoSchema.Tables {
+		tables[xoSchema.Tables[i].Name] = &xoSchema.Tables[i]
+	}
 
-	// Switch to the temporary database
-	_, err = db.Exec(fmt.Sprintf("USE %s", t.dbConnection.DbName))
-	if err != nil {
-		return fmt.Errorf("failed to switch to temporary database: %w", err)
+	var onDelete []string
+	for _, schema := range schemas {
+		table := tables[schema.TableName]
+		table.Indexes = t.modelIndexes(driver, schema, *table)
+		var actions []string
+		table.ForeignKeys, actions = modelForeignKeys(driver, schema, *table, tables)
+		onDelete = append(onDelete, actions...)
 	}
+	return &xo.Set{Schemas: []xo.Schema{xoSchema}}, generated, onDelete, nil
+}
 
-	// Step 3: Process xo
-	_, err = db.Exec(strings.TrimSpace(execute))
-	if err != nil {
-		return fmt.Errorf("failed to apply schema err: %s. \nSQL: %s", err, execute)
+// modelTable converts the columns of a schema with their type in the DDL, ENUM columns of MySQL get an enum
+// type named after the column
+func (t Translator) modelTable(driver string, schema Schema) (xo.Table, []xo.Enum, error) {
+	table := xo.Table{Type: "table", Name: schema.TableName, Manual: true}
+	primaryKey := splitColumns(schema.CompositePrimaryKeys)
+	var enums []xo.Enum
+	for _, col := range schema.Columns {
+		typ, err := xo.ParseType(strings.ToLower(t.columnTypeDDL(schema, col)), driver)
+		if err != nil {
+			return xo.Table{}, nil, fmt.Errorf("column '%s': %w", col.Name, err)
+		}
+		typ.Nullable = !contains(col.Constraints, "NOT NULL") && !col.IsPrimaryKey && !contains(primaryKey, col.Name)
+		if driver == "mysql" && typ.Type == "enum" {
+			enum := modelEnum(col)
+			enums = append(enums, enum)
+			typ.Type, typ.Enum = enum.Name, &enums[len(enums)-1]
+		}
+		field := xo.Field{
+			Name:       col.Name,
+			Type:       typ,
+			IsPrimary:  col.IsPrimaryKey || contains(primaryKey, col.Name),
+			IsSequence: col.AutoIncrement,
+			Comment:    strings.ReplaceAll(strings.TrimSpace(col.Comment), "\n", " "),
+		}
+		if field.IsSequence {
+			table.Manual = false
+		}
+		table.Columns = append(table.Columns, field)
+		if field.IsPrimary {
+			table.PrimaryKeys = append(table.PrimaryKeys, field)
+		}
 	}
-	log.Printf("Successfully executed statement on DB: %s ", t.dbConnection.DbName)
+	return table, enums, nil
+}
 
-	// Update the connection string to include the database name
-	fullDBConnStr := fmt.Sprintf("mysql://%s:%s@%s:%s/%s", t.dbConnection.DbUser, t.dbConnection.DbPass, t.dbConnection.DbHost, t.dbConnection.DbPort, t.dbConnection.DbName)
+var enumValuesPattern = regexp.MustCompile(`(?i)^ENUM\s*\((.*)\)$`)
 
-	// Generate XO models
-	err = t.runXo(fullDBConnStr, outputDir, "../templates")
-	if err != nil {
-		return fmt.Errorf("model generation failed for dir '%s': %s", outputDir, err)
+func modelEnum(col ColumnSchema) xo.Enum {
+	enum := xo.Enum{Name: col.Name}
+	if m := enumValuesPattern.FindStringSubmatch(strings.TrimSpace(col.Type)); m != nil {
+		values := strings.TrimSpace(m[1])
+		for i, value := range strings.Split(strings.Trim(values, "'"), "','") {
+			constValue := i + 1
+			enum.Values = append(enum.Values, xo.Field{Name: value, ConstValue: &constValue})
+		}
 	}
-	return nil
+	return enum
 }
 
-func (t Translator) runXo(dbConnStr, outputDir, templatesDir string) error {
-	// Check if XO is installed
-	_, err := exec.LookPath("xo")
-	if err != nil {
-		return fmt.Errorf("xo is not installed: %w", err)
+// modelIndexes lists the indexes the database creates for a table, ordered by name. When the primary key
+// has no index of its own, e.g. on MySQL where xo leaves out the PRIMARY index or for the rowid of SQLite,
+// it is added last and named <table>_<columns>_pkey like xo does.
+func (t Translator) modelIndexes(driver string, schema Schema, table xo.Table) []xo.Index {
+	var indexes []xo.Index
+	if driver == "sqlite3" {
+		indexes = t.sqliteModelIndexes(schema, table)
+	} else {
+		indexes = t.mysqlModelIndexes(schema, table)
 	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].Name < indexes[j].Name
+	})
 
-	// Build the XO command
-	cmdArgs := []string{
-		"schema",           // XO subcommand for schema inspection
-		dbConnStr,          // Database connection string as positional argument
-		"--out", outputDir, // Output directory for generated models
-		"--src", templatesDir, // Specify the templates directory
+	for _, index := range indexes {
+		if index.IsPrimary {
+			return indexes
+		}
 	}
+	if len(table.PrimaryKeys) > 0 {
+		name := table.Name + "_"
+		for _, field := range table.PrimaryKeys {
+			name += field.Name + "_"
+		}
+		index := xo.Index{Name: name + "pkey", Fields: table.PrimaryKeys, IsUnique: true, IsPrimary: true}
+		index.Func = modelIndexFuncName(index, table.Name)
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
 
-	// Prepare XO command
-	cmd := exec.Command("xo", cmdArgs...)
-	cmd.Stdout = os.Stdout // Forward XO's stdout for visibility
-	cmd.Stderr = os.Stderr // Forward XO's stderr for error visibility
+// mysqlModelIndexes names the indexes the way MySQL does: UNIQUE columns and unnamed unique constraints
+// after their first column, and foreign keys without an index covering their columns after the column,
+// or the constraint for named foreign keys
+func (t Translator) mysqlModelIndexes(schema Schema, table xo.Table) []xo.Index {
+	var indexes []xo.Index
+	names := make(map[string]bool)
+	add := func(name string, columns []string, unique bool) {
+		// MySQL appends _2, _3, ... to names already taken
+		base := name
+		for i := 2; names[name]; i++ {
+			name = fmt.Sprintf("%s_%d", base, i)
+		}
+		if index, ok := modelIndex(table, name, columns, unique, false); ok {
+			names[name] = true
+			indexes = append(indexes, index)
+		}
+	}
+	covered := func(columns []string) bool {
+		if len(table.PrimaryKeys) >= len(columns) && sameColumns(fieldNames(table.PrimaryKeys[:len(columns)]), columns) {
+			return true
+		}
+		for _, index := range indexes {
+			if len(index.Fields) >= len(columns) && sameColumns(fieldNames(index.Fields[:len(columns)]), columns) {
+				return true
+			}
+		}
+		return false
+	}
 
-	// Run the XO command
-	err = cmd.Run()
-	if err != nil {
-		return fmt.Errorf("failed to generate model with XO: %s", err)
+	for _, col := range schema.Columns {
+		if contains(col.Constraints, "UNIQUE") {
+			add(col.Name, []string{col.Name}, true)
+		}
+	}
+	for _, unique := range schema.UniqueConstraints {
+		columns := splitColumns(unique)
+		add(columns[0], columns, true)
+	}
+	for _, index := range t.tableIndexes(schema) {
+		if columns := indexColumnNames(index); columns != nil {
+			add(index.Name, columns, index.Unique)
+		}
+	}
+	named := make(map[string]bool)
+	for _, foreignKey := range schema.ForeignKeys {
+		named[foreignKey.Name] = true
 	}
+	for _, foreignKey := range foreignKeys(schema) {
+		if covered(foreignKey.Columns) {
+			continue
+		}
+		name := foreignKey.Columns[0]
+		if named[foreignKey.Name] {
+			name = foreignKey.Name
+		}
+		add(name, foreignKey.Columns, false)
+	}
+	return indexes
+}
 
-	// Run the find and sed command to update generated files to remove the DB name from sql statements
-	findCmd := exec.Command("find", outputDir, "-type", "f", "-name", "*.go", "-exec", "sed", "-i", "", fmt.Sprintf("s/%s\\.//g", t.dbConnection.DbName), "{}", "+")
-	findCmd.Stdout = os.Stdout // Forward find command's stdout for visibility
-	findCmd.Stderr = os.Stderr // Forward find command's stderr for error visibility
+// sqliteModelIndexes names the indexes the way SQLite does: the UNIQUE and PRIMARY KEY constraints get
+// sqlite_autoindex_<table>_<n> indexes numbered in the order of the CREATE TABLE statement, unless an
+// index on the same columns exists already or the key is the rowid. Foreign keys get no index.
+func (t Translator) sqliteModelIndexes(schema Schema, table xo.Table) []xo.Index {
+	var indexes []xo.Index
+	autoindex := func(columns []string, primary bool) {
+		for _, index := range indexes {
+			if index.IsUnique && strings.Join(fieldNames(index.Fields), ",") == strings.Join(columns, ",") {
+				return
+			}
+		}
+		name := fmt.Sprintf("sqlite_autoindex_%s_%d", table.Name, len(indexes)+1)
+		if index, ok := modelIndex(table, name, columns, true, primary); ok {
+			indexes = append(indexes, index)
+		}
+	}
 
-	err = findCmd.Run()
-	if err != nil {
-		log.Printf("failed to process generated files with find/sed: %s", err)
+	for _, col := range schema.Columns {
+		if contains(col.Constraints, "UNIQUE") {
+			autoindex([]string{col.Name}, false)
+		}
+		rowid := t.columnTypeDDL(schema, col) == "INTEGER" && !schema.Options.WithoutRowid
+		if (col.AutoIncrement || col.IsPrimaryKey && schema.CompositePrimaryKeys == "") && !rowid {
+			autoindex([]string{col.Name}, true)
+		}
+	}
+	if schema.CompositePrimaryKeys != "" {
+		autoindex(splitColumns(schema.CompositePrimaryKeys), true)
+	}
+	for _, unique := range schema.UniqueConstraints {
+		autoindex(splitColumns(unique), false)
 	}
+	for _, index := range t.tableIndexes(schema) {
+		if columns := indexColumnNames(index); columns != nil {
+			if index, ok := modelIndex(table, index.Name, columns, index.Unique, false); ok {
+				indexes = append(indexes, index)
+			}
+		}
+	}
+	return indexes
+}
 
-	fmt.Printf("Model generated and processed successfully in directory '%s'\n", outputDir)
-	return nil
+// modelIndex returns the index on the columns, false when a column doesn't exist
+func modelIndex(table xo.Table, name string, columns []string, unique, primary bool) (xo.Index, bool) {
+	fields := modelFields(table, columns)
+	if fields == nil {
+		return xo.Index{}, false
+	}
+	index := xo.Index{Name: name, Fields: fields, IsUnique: unique, IsPrimary: primary}
+	index.Func = modelIndexFuncName(index, table.Name)
+	return index, true
+}
+
+// indexColumnNames returns the columns of an index, nil when it indexes an expression
+func indexColumnNames(index IndexSchema) []string {
+	var columns []string
+	for _, column := range index.Columns {
+		if column.Expression != "" {
+			return nil
+		}
+		columns = append(columns, column.Name)
+	}
+	return columns
+}
+
+// modelForeignKeys lists the foreign keys of a table referencing tables of the set, ordered by name. The
+// column foreign keys are created without a name, they are named <table>_ibfk_<n> like MySQL does. SQLite
+// doesn't report the names, they are named <table>_<columns>_fkey like xo does. The ON DELETE actions are
+// returned as <table>.<constraint>=<action>.
+func modelForeignKeys(driver string, schema Schema, table xo.Table, tables map[string]*xo.Table) ([]xo.ForeignKey, []string) {
+	named := make(map[string]bool)
+	for _, foreignKey := range schema.ForeignKeys {
+		named[foreignKey.Name] = true
+	}
+	var keys []xo.ForeignKey
+	var onDelete []string
+	unnamed := 0
+	for _, foreignKey := range foreignKeys(schema) {
+		switch {
+		case driver == "sqlite3":
+			foreignKey.Name = fmt.Sprintf("%s_%s_fkey", schema.TableName, strings.Join(foreignKey.Columns, "_"))
+		case !named[foreignKey.Name]:
+			unnamed++
+			foreignKey.Name = fmt.Sprintf("%s_ibfk_%d", strings.ToLower(schema.TableName), unnamed)
+		}
+		refTable, ok := tables[foreignKey.ReferencesTable]
+		if !ok {
+			continue
+		}
+		fields, refFields := modelFields(table, foreignKey.Columns), modelFields(*refTable, foreignKey.ReferencesColumns)
+		if fields == nil || refFields == nil {
+			continue
+		}
+		keys = append(keys, xo.ForeignKey{
+			Name:      foreignKey.Name,
+			Fields:    fields,
+			RefTable:  refTable.Name,
+			RefFields: refFields,
+			RefFunc:   modelIndexFuncName(xo.Index{IsUnique: true, Fields: refFields}, refTable.Name),
+		})
+		if foreignKey.OnDelete != "" {
+			onDelete = append(onDelete, schema.TableName+"."+foreignKey.Name+"="+foreignKey.OnDelete)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+	for i := range keys {
+		keys[i].Func = modelForeignKeyFuncName(keys[i], keys)
+	}
+	return keys, onDelete
+}
+
+// modelFields returns the fields of the columns, nil when a column doesn't exist
+func modelFields(table xo.Table, columns []string) []xo.Field {
+	var fields []xo.Field
+	for _, column := range columns {
+		found := false
+		for _, field := range table.Columns {
+			if field.Name == column {
+				fields, found = append(fields, field), true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	}
+	return fields
+}
+
+func fieldNames(fields []xo.Field) []string {
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	return names
+}
+
+// modelIndexFuncName names the lookup function of an index after the table and the columns,
+// e.g. customer_by_email, like xo does
+func modelIndexFuncName(index xo.Index, tableName string) string {
+	if index.IsUnique {
+		tableName = inflector.Singularize(tableName)
+	}
+	return strings.Join(append([]string{tableName, "by"}, fieldNames(index.Fields)...), "_")
+}
+
+// modelForeignKeyFuncName names the function returning the referenced row after the referenced table,
+// adding the columns when the table references it more than once, like the smart mode of xo
+func modelForeignKeyFuncName(foreignKey xo.ForeignKey, foreignKeys []xo.ForeignKey) string {
+	name := modelSingularize(foreignKey.RefTable)
+	for _, other := range foreignKeys {
+		if other.Name != foreignKey.Name && other.RefTable == foreignKey.RefTable {
+			return name + "_by_" + strings.Join(fieldNames(foreignKey.Fields), "_")
+		}
+	}
+	return name
+}
+
+func modelSingularize(name string) string {
+	if i := strings.LastIndex(name, "_"); i != -1 {
+		return name[:i+1] + inflector.Singularize(name[i+1:])
+	}
+	return inflector.Singularize(name)
 }
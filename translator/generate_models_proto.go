@@ -0,0 +1,179 @@
+package proto_db
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/imran31415/proto-db-translator/templates"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// modelImports assigns the import aliases of the packages of the proto types used by the models
+type modelImports struct {
+	aliases map[string]string // Alias by import path
+	names   map[string]string // Package name by import path
+}
+
+func newModelImports() *modelImports {
+	names := map[string]string{
+		"google.golang.org/protobuf/encoding/protojson": "protojson",
+		"google.golang.org/protobuf/proto":              "proto",
+	}
+	aliases := make(map[string]string)
+	for pkg, name := range names {
+		aliases[pkg] = name
+	}
+	return &modelImports{aliases: aliases, names: names}
+}
+
+// qualify returns the name of a named type qualified with the alias of its package
+func (i *modelImports) qualify(typ reflect.Type) string {
+	alias, ok := i.aliases[typ.PkgPath()]
+	if !ok {
+		// The string of a named type is qualified with the name of its package
+		name := strings.TrimSuffix(typ.String(), "."+typ.Name())
+		alias = name
+		for n := 2; i.taken(alias); n++ {
+			alias = fmt.Sprintf("%s%d", name, n)
+		}
+		i.aliases[typ.PkgPath()] = alias
+		i.names[typ.PkgPath()] = name
+	}
+	return alias + "." + typ.Name()
+}
+
+func (i *modelImports) taken(alias string) bool {
+	for _, other := range i.aliases {
+		if other == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// list returns the imports ordered by path, as "<alias> <path>" when the alias isn't the package name.
+// Unused imports are removed when the models are formatted.
+func (i *modelImports) list() []string {
+	var paths []string
+	for pkg := range i.aliases {
+		paths = append(paths, pkg)
+	}
+	sort.Strings(paths)
+	var imports []string
+	for _, pkg := range paths {
+		if i.aliases[pkg] == i.names[pkg] {
+			imports = append(imports, pkg)
+		} else {
+			imports = append(imports, i.aliases[pkg]+" "+pkg)
+		}
+	}
+	return imports
+}
+
+// modelProto describes the message of a table for the ToProto and FromProto converters of its model, the
+// Go names of the fields are read from the generated message struct
+func modelProto(message proto.Message, schema Schema, imports *modelImports) (gotpl.Proto, error) {
+	m := message.ProtoReflect()
+	md := m.Descriptor()
+	typ := reflect.TypeOf(message).Elem()
+	result := gotpl.Proto{Type: imports.qualify(typ)}
+
+	goNames := make(map[string]reflect.StructField)
+	oneofNames := make(map[string]string)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if oneof := field.Tag.Get("protobuf_oneof"); oneof != "" {
+			oneofNames[oneof] = field.Name
+		}
+		for _, option := range strings.Split(field.Tag.Get("protobuf"), ",") {
+			if name, ok := strings.CutPrefix(option, "name="); ok {
+				goNames[name] = field
+			}
+		}
+	}
+
+	variants := make(map[string]gotpl.ProtoField)
+	for i := 0; i < md.Fields().Len(); i++ {
+		field := md.Fields().Get(i)
+		column, err := fieldColumnName(field)
+		if err != nil {
+			return gotpl.Proto{}, err
+		}
+		if field.IsList() || field.IsMap() {
+			return gotpl.Proto{}, fmt.Errorf("field '%s': repeated and map fields are not supported", field.FullName())
+		}
+		protoField := gotpl.ProtoField{Column: column, Kind: protoFieldKind(field)}
+		var goType reflect.Type
+		if od := field.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			// The wrapper type of a variant is the type of the oneof field once the variant is set
+			variant := m.New()
+			variant.Set(field, variant.NewField(field))
+			oneof := reflect.ValueOf(variant.Interface()).Elem().FieldByName(oneofNames[string(od.Name())])
+			wrapper := oneof.Elem().Type().Elem()
+			protoField.Oneof = oneofNames[string(od.Name())]
+			protoField.Case = string(field.Name())
+			protoField.Wrapper = imports.qualify(wrapper)
+			protoField.GoName = wrapper.Field(0).Name
+			goType = wrapper.Field(0).Type
+			variants[column] = protoField
+		} else {
+			goField, ok := goNames[string(field.Name())]
+			if !ok {
+				return gotpl.Proto{}, fmt.Errorf("field '%s' not found in %s", field.FullName(), typ)
+			}
+			protoField.GoName = goField.Name
+			protoField.Optional = od != nil && od.IsSynthetic() && field.Kind() != protoreflect.MessageKind
+			goType = goField.Type
+		}
+		if protoField.Kind == "enum" || protoField.Kind == "timestamp" || protoField.Kind == "message" {
+			if goType.Kind() == reflect.Ptr {
+				goType = goType.Elem()
+			}
+			protoField.Type = imports.qualify(goType)
+		}
+		result.Fields = append(result.Fields, protoField)
+	}
+
+	for _, oneof := range schema.Oneofs {
+		protoOneof := gotpl.ProtoOneof{Column: oneof.DiscriminatorColumn, GoName: oneofNames[oneof.Name]}
+		for _, variant := range oneof.Variants {
+			protoOneof.Cases = append(protoOneof.Cases, variants[variant.Column])
+		}
+		result.Oneofs = append(result.Oneofs, protoOneof)
+	}
+	return result, nil
+}
+
+// protoFieldKind returns the kind of a field for the converters, see gotpl.ProtoField
+func protoFieldKind(field protoreflect.FieldDescriptor) string {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.EnumKind:
+		return "enum"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "bytes"
+	}
+	if field.Message().FullName() == "google.protobuf.Timestamp" {
+		return "timestamp"
+	}
+	// Other messages are stored as their JSON representation, like MessageToColumns does
+	return "message"
+}
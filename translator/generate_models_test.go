@@ -1,20 +1,47 @@
 package proto_db
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
+	models "github.com/imran31415/proto-db-translator/generated_models_sqlite"
 	"github.com/imran31415/proto-db-translator/translator/db"
 	userauth "github.com/imran31415/proto-db-translator/user"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// modelProtoMessages are the messages of generated_models and generated_models_sqlite, the messages of
+// generate/main.go
+var modelProtoMessages = []proto.Message{
+	&userauth.User{},
+	&userauth.Role{},
+	&userauth.RoleHierarchy{},
+	&userauth.Customer{},
+	&userauth.Product{},
+	&userauth.Orders{},
+	&userauth.OrderDetails{},
+	&userauth.OrderDetailShipments{},
+	&userauth.OrderItems{},
+	&userauth.Payment{},
+}
+
 func TestProcessProtoMessages(t *testing.T) {
 
 	outputDir := "../generated_models"
@@ -30,23 +57,12 @@ func TestProcessProtoMessages(t *testing.T) {
 	}
 	translator := NewTranslator(db.DefaultMysqlConnection())
 
-	protoMessages := []proto.Message{
-		&userauth.User{}, // Replace with your actual proto message types
-		&userauth.Role{},
-		&userauth.RoleHierarchy{},
-		&userauth.Customer{},
-		&userauth.Product{},
-		&userauth.Orders{},
-		&userauth.OrderDetails{},
-		&userauth.OrderItems{},
-	}
-
-	err = translator.GenerateModels(outputDir, protoMessages)
+	err = translator.GenerateModels(outputDir, modelProtoMessages)
 	if err != nil {
 		fmt.Println("err is", err)
 	}
 	require.NoError(t, err, "ProcessProtoMessages failed")
-	filenames := []string{"db.xo.go", "orderdetail.xo.go", "rolehierarchy.xo.go", "user.xo.go"} // Replace with actual filenames
+	filenames := []string{"db.xo.go", "memory.xo.go", "orderdetail.xo.go", "rolehierarchy.xo.go", "user.xo.go"} // Replace with actual filenames
 
 	err = checkFilesExist(outputDir, filenames)
 	require.NoError(t, err, "Models were not generatedd")
@@ -93,3 +109,371 @@ func createDirIfNotExists(dir string) error {
 	}
 	return nil
 }
+
+func TestRenderModels(t *testing.T) {
+	translator := NewTranslator(db.DefaultMysqlConnection())
+	protoMessages := []proto.Message{&userauth.Role{}, &userauth.Customer{}, &userauth.Product{}, &userauth.Orders{}, &userauth.OrderItems{}}
+
+	files, err := translator.RenderModels("models", protoMessages)
+	require.NoError(t, err)
+	again, err := translator.RenderModels("models", protoMessages)
+	require.NoError(t, err)
+	require.Equal(t, files, again, "output is deterministic")
+
+	tests := []struct {
+		name     string
+		file     string
+		expected string
+	}{
+		{"Package", "db.xo.go", "package models"},
+		{"Deadlock Retry", "db.xo.go", "return errors.As(err, &mysqlErr) && mysqlErr.Number == 1213"},
+		{"Unqualified Table", "role.xo.go", "`FROM Role ` +"},
+		{"Primary Key Index", "role.xo.go", "Generated from index 'Role_role_id_pkey'."},
+		{"Unique Column Index", "role.xo.go", "func RoleByRoleName(ctx context.Context, db DB, roleName string) (*Role, error)"},
+		{"Foreign Key", "orderitem.xo.go", "Generated from foreign key 'orderitems_ibfk_2'."},
+		{"Implicit Foreign Key Index", "orderitem.xo.go", "func OrderItemsByProductID("},
+		{"Unsigned Column", "product.xo.go", "StockQuantity uint"},
+		{"Repository", "role.xo.go", "func NewMemoryRoleRepository(memdb *MemoryDB) RoleRepository {"},
+		{"Memory Foreign Key", "memory.xo.go", `{name: "orderitems_ibfk_1", columns: []string{"order_id"}, refTable: "Orders", refColumns: []string{"order_id"}, onDelete: "CASCADE"}`},
+		{"Proto Converter", "customer.xo.go", "func (c *Customer) ToProto() (*user.Customer, error)"},
+		{"Generated Column", "customer.xo.go", "`INSERT INTO Customer (` +\n\t\t`customer_id, customer_name, email, phone, created_at, updated_at` +"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Contains(t, files, test.file)
+			require.Contains(t, string(files[test.file]), test.expected)
+		})
+	}
+	require.NotContains(t, string(files["customer.xo.go"]), "proto_db_default.")
+}
+
+func TestRenderModelsDbName(t *testing.T) {
+	// A database name which is also an identifier of the generated code
+	connection := db.DefaultMysqlConnection()
+	connection.DbName = "db"
+	files, err := NewTranslator(connection).RenderModels("models", []proto.Message{&userauth.Role{}, &userauth.Product{}})
+	require.NoError(t, err)
+	require.Contains(t, string(files["role.xo.go"]), "db.ExecContext(ctx, sqlstr")
+	require.Contains(t, string(files["role.xo.go"]), "`FROM Role ` +")
+	require.Contains(t, string(files["db.xo.go"]), "types from schema 'db'")
+	for name, content := range files {
+		require.NotContains(t, string(content), "db.Role", name)
+		require.NotContains(t, string(content), "db.Product", name)
+	}
+}
+
+// updateModels rewrites generated_models_sqlite instead of comparing it with the rendered models:
+// go test ./translator -run TestGenerateSqliteModels -update
+var updateModels = flag.Bool("update", false, "rewrite the committed SQLite models")
+
+func TestGenerateSqliteModels(t *testing.T) {
+	outputDir := "../generated_models_sqlite"
+	translator := NewSqliteTranslator()
+	if *updateModels {
+		require.NoError(t, clearDirectory(outputDir))
+		require.NoError(t, translator.GenerateModels(outputDir, modelProtoMessages))
+	}
+
+	// The committed models must be the ones rendered from the templates and the messages
+	files, err := translator.RenderModels(filepath.Base(outputDir), modelProtoMessages)
+	require.NoError(t, err)
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.ElementsMatch(t, slices.Collect(maps.Keys(files)), names, "update the models with -update")
+	for name, content := range files {
+		committed, err := os.ReadFile(filepath.Join(outputDir, name))
+		require.NoError(t, err)
+		require.Equal(t, string(content), string(committed), "%s is out of date, update the models with -update", name)
+	}
+}
+
+func TestSqliteModels(t *testing.T) {
+	ctx := context.Background()
+	database := newRepositoryDatabase(t, NewSqliteTranslator(), &userauth.Role{}, &userauth.Product{})
+
+	admin := &models.Role{RoleName: "admin"}
+	require.NoError(t, admin.Insert(ctx, database))
+	require.Equal(t, 1, admin.RoleID, "auto-increment key is set on the model")
+	editor := &models.Role{RoleName: "editor", ParentRoleID: sql.NullInt64{Int64: int64(admin.RoleID), Valid: true}}
+	require.NoError(t, editor.Insert(ctx, database))
+	parent, err := editor.Role(ctx, database)
+	require.NoError(t, err)
+	require.Equal(t, "admin", parent.RoleName)
+
+	// Upsert updates the existing row on conflict of the primary key
+	upserted := &models.Role{RoleID: editor.RoleID, RoleName: "editor", Description: sql.NullString{String: "Edits", Valid: true}}
+	require.NoError(t, upserted.Upsert(ctx, database))
+	got, err := models.RoleByRoleName(ctx, database, "editor")
+	require.NoError(t, err)
+	require.Equal(t, editor.RoleID, got.RoleID)
+	require.Equal(t, "Edits", got.Description.String)
+	require.False(t, got.ParentRoleID.Valid)
+
+	require.NoError(t, got.Delete(ctx, database))
+	_, err = models.RoleByRoleID(ctx, database, editor.RoleID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	for i := 1; i <= 5; i++ {
+		product := &models.Product{Name: fmt.Sprintf("product %d", i), Price: 9.5, StockQuantity: i}
+		require.NoError(t, product.Insert(ctx, database))
+	}
+	page, last, err := models.ProductKeysetPage(ctx, database, "product_id", 1, 2, "ASC", map[string]interface{}{"stock_quantity": []int{2, 4, 5}})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, "product 2", page[0].Name)
+	require.Equal(t, "product 4", last.Name)
+	page, _, err = models.ProductKeysetPage(ctx, database, "product_id", last.ProductID, 2, "ASC", map[string]interface{}{"stock_quantity": []int{2, 4, 5}})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, 5, page[0].StockQuantity)
+}
+
+func TestModelProtoConverters(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	customer := &userauth.Customer{
+		CustomerId:   7,
+		CustomerName: "Ada",
+		Email:        "ada@example.com",
+		CreatedAt:    timestamppb.New(createdAt),
+		UpdatedAt:    timestamppb.New(createdAt),
+	}
+	var model models.Customer
+	require.NoError(t, model.FromProto(customer))
+	require.Equal(t, 7, model.CustomerID)
+	require.False(t, model.Phone.Valid, "empty optional column is NULL")
+	require.True(t, createdAt.Equal(model.CreatedAt.Time()))
+	got, err := model.ToProto()
+	require.NoError(t, err)
+	require.True(t, proto.Equal(customer, got), "round trip of %v", got)
+
+	tests := []struct {
+		name    string
+		payment *userauth.Payment
+		column  string
+	}{
+		{"Card Token", &userauth.Payment{PaymentId: 1, OrderId: 2, Method: &userauth.Payment_CardToken{CardToken: "tok_1"}}, "card_token"},
+		{"Voucher", &userauth.Payment{PaymentId: 1, OrderId: 2, Method: &userauth.Payment_VoucherId{VoucherId: 9}}, "voucher_id"},
+		{"No Method", &userauth.Payment{PaymentId: 1, OrderId: 2}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var model models.Payment
+			require.NoError(t, model.FromProto(test.payment))
+			require.Equal(t, test.column, model.MethodCase.String)
+			require.Equal(t, test.column != "", model.MethodCase.Valid)
+			got, err := model.ToProto()
+			require.NoError(t, err)
+			require.True(t, proto.Equal(test.payment, got), "round trip of %v", got)
+		})
+	}
+}
+
+func TestModelRepositories(t *testing.T) {
+	type repositories struct {
+		customers models.CustomerRepository
+		orders    models.OrderRepository
+		items     models.OrderItemRepository
+		products  models.ProductRepository
+	}
+	tests := []struct {
+		name         string
+		repositories func(t *testing.T) repositories
+	}{
+		{"SQL", func(t *testing.T) repositories {
+			database := newRepositoryDatabase(t, NewSqliteTranslator(), &userauth.Customer{}, &userauth.Product{}, &userauth.Orders{}, &userauth.OrderItems{})
+			return repositories{
+				models.NewSQLCustomerRepository(database),
+				models.NewSQLOrderRepository(database),
+				models.NewSQLOrderItemRepository(database),
+				models.NewSQLProductRepository(database),
+			}
+		}},
+		{"Memory", func(t *testing.T) repositories {
+			memdb := models.NewMemoryDB()
+			return repositories{
+				models.NewMemoryCustomerRepository(memdb),
+				models.NewMemoryOrderRepository(memdb),
+				models.NewMemoryOrderItemRepository(memdb),
+				models.NewMemoryProductRepository(memdb),
+			}
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := test.repositories(t)
+
+			customer := &models.Customer{CustomerID: 1, CustomerName: "Ada", Email: "ada@example.com"}
+			require.NoError(t, repos.customers.Insert(ctx, customer))
+			require.Error(t, repos.customers.Insert(ctx, &models.Customer{CustomerID: 2, CustomerName: "Ada", Email: "ada@example.com"}), "unique email")
+			chair := &models.Product{Name: "chair", Price: 20}
+			require.NoError(t, repos.products.Insert(ctx, chair))
+			table := &models.Product{Name: "table", Price: 80}
+			require.NoError(t, repos.products.Insert(ctx, table))
+			require.Equal(t, 2, table.ProductID, "auto-increment key is set on the model")
+
+			order := &models.Order{OrderID: 10, CustomerID: customer.CustomerID, TotalAmount: 80, Status: "pending"}
+			require.NoError(t, repos.orders.Insert(ctx, order))
+			require.Error(t, repos.orders.Insert(ctx, &models.Order{OrderID: 11, CustomerID: 99, TotalAmount: 80, Status: "pending"}), "unknown customer")
+			item := &models.OrderItem{OrderID: order.OrderID, ProductID: chair.ProductID, Quantity: 4, PricePerUnit: 20}
+			require.NoError(t, repos.items.Insert(ctx, item))
+			require.Error(t, repos.items.Insert(ctx, &models.OrderItem{OrderID: order.OrderID, ProductID: chair.ProductID, Quantity: 1}), "unique order and product")
+			require.Error(t, repos.items.Insert(ctx, &models.OrderItem{OrderID: order.OrderID, ProductID: 99, Quantity: 1}), "unknown product")
+
+			item.Quantity = 5
+			require.NoError(t, repos.items.Update(ctx, item))
+			got, err := repos.items.OrderItemByOrderIDProductID(ctx, order.OrderID, chair.ProductID)
+			require.NoError(t, err)
+			require.Equal(t, 5, got.Quantity)
+			require.True(t, got.Exists())
+
+			require.Error(t, repos.products.Delete(ctx, chair), "product referenced by an order item")
+			require.NoError(t, repos.products.Delete(ctx, table))
+			_, err = repos.products.ProductByName(ctx, "table")
+			require.ErrorIs(t, err, sql.ErrNoRows)
+
+			// Deleting the customer cascades to its orders and their items
+			require.NoError(t, repos.customers.Delete(ctx, customer))
+			_, err = repos.orders.OrderByOrderID(ctx, order.OrderID)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+			_, err = repos.items.OrderItemByOrderItemID(ctx, item.OrderItemID)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+			require.NoError(t, repos.products.Delete(ctx, chair))
+		})
+	}
+}
+
+func TestMemoryDBConstraintErrors(t *testing.T) {
+	ctx := context.Background()
+	memdb := models.NewMemoryDB()
+	roles := models.NewMemoryRoleRepository(memdb)
+
+	admin := &models.Role{RoleName: "admin"}
+	require.NoError(t, roles.Insert(ctx, admin))
+	err := roles.Insert(ctx, &models.Role{RoleName: "admin"})
+	require.ErrorIs(t, err, models.ErrDuplicateKey)
+	var constraint *models.ErrConstraint
+	require.ErrorAs(t, err, &constraint)
+	require.Equal(t, "Role", constraint.Table)
+	require.Equal(t, "sqlite_autoindex_Role_1", constraint.Constraint)
+
+	orphan := &models.Role{RoleName: "orphan", ParentRoleID: sql.NullInt64{Int64: 42, Valid: true}}
+	require.ErrorIs(t, roles.Insert(ctx, orphan), models.ErrForeignKey)
+	require.False(t, orphan.Exists(), "failed insert leaves the model unchanged")
+
+	editor := &models.Role{RoleName: "editor", ParentRoleID: sql.NullInt64{Int64: int64(admin.RoleID), Valid: true}}
+	require.NoError(t, roles.Insert(ctx, editor))
+	require.NoError(t, roles.Delete(ctx, admin))
+	_, err = roles.RoleByRoleName(ctx, "editor")
+	require.ErrorIs(t, err, sql.ErrNoRows, "child role deleted by ON DELETE CASCADE")
+}
+
+func TestMemoryDBConcurrentInserts(t *testing.T) {
+	ctx := context.Background()
+	products := models.NewMemoryProductRepository(models.NewMemoryDB())
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, products.Insert(ctx, &models.Product{Name: fmt.Sprintf("product %d", i), Price: 1}))
+		}(i)
+	}
+	wg.Wait()
+	for i := 1; i <= 20; i++ {
+		_, err := products.ProductByProductID(ctx, i)
+		require.NoError(t, err, "keys are assigned once")
+	}
+}
+
+func TestWithTx(t *testing.T) {
+	ctx := context.Background()
+	database := newRepositoryDatabase(t, NewSqliteTranslator(), &userauth.Role{})
+	roleExists := func(name string) bool {
+		_, err := models.RoleByRoleName(ctx, database, name)
+		if errors.Is(err, sql.ErrNoRows) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+	insertRole := func(name string) func(tx *models.Tx) error {
+		return func(tx *models.Tx) error {
+			return (&models.Role{RoleName: name}).Insert(ctx, tx)
+		}
+	}
+	errFailed := errors.New("failed")
+
+	require.NoError(t, models.WithTx(ctx, database, nil, insertRole("committed")))
+	require.True(t, roleExists("committed"))
+
+	err := models.WithTx(ctx, database, nil, func(tx *models.Tx) error {
+		require.NoError(t, insertRole("failed")(tx))
+		return errFailed
+	})
+	require.ErrorIs(t, err, errFailed)
+	require.False(t, roleExists("failed"), "rolled back on error")
+
+	require.PanicsWithValue(t, "boom", func() {
+		_ = models.WithTx(ctx, database, nil, func(tx *models.Tx) error {
+			require.NoError(t, insertRole("panicked")(tx))
+			panic("boom")
+		})
+	})
+	require.False(t, roleExists("panicked"), "rolled back on panic")
+
+	// A failed nested call only rolls back to its savepoint
+	err = models.WithTx(ctx, database, nil, func(tx *models.Tx) error {
+		if err := insertRole("outer")(tx); err != nil {
+			return err
+		}
+		err := models.WithTx(ctx, tx, nil, func(tx *models.Tx) error {
+			require.NoError(t, insertRole("inner failed")(tx))
+			return errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+		return models.WithTx(ctx, tx, nil, insertRole("inner"))
+	})
+	require.NoError(t, err)
+	require.True(t, roleExists("outer"))
+	require.True(t, roleExists("inner"))
+	require.False(t, roleExists("inner failed"))
+
+	tests := []struct {
+		name     string
+		retry    models.RetryPolicy
+		failures int
+		attempts int
+		code     sqlite3.ErrNo
+	}{
+		{"Retried", models.RetryPolicy{MaxRetries: 3}, 2, 3, 0},
+		{"Retries Exhausted", models.RetryPolicy{MaxRetries: 1}, 2, 2, sqlite3.ErrBusy},
+		{"No Retry", models.RetryPolicy{}, 2, 1, sqlite3.ErrBusy},
+		{"Not Retryable", models.RetryPolicy{MaxRetries: 3, Retryable: func(error) bool { return false }}, 2, 1, sqlite3.ErrBusy},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.retry.Backoff = func(int) time.Duration { return 0 }
+			attempts := 0
+			err := models.WithTx(ctx, database, &models.TxOptions{Isolation: sql.LevelSerializable, Retry: test.retry}, func(tx *models.Tx) error {
+				attempts++
+				if attempts <= test.failures {
+					return sqlite3.Error{Code: sqlite3.ErrBusy}
+				}
+				return nil
+			})
+			require.Equal(t, test.attempts, attempts)
+			if test.code == 0 {
+				require.NoError(t, err)
+			} else {
+				var sqliteErr sqlite3.Error
+				require.ErrorAs(t, err, &sqliteErr)
+				require.Equal(t, test.code, sqliteErr.Code)
+			}
+		})
+	}
+}
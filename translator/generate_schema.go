@@ -1,46 +1,106 @@
 package proto_db
 
 import (
-	"fmt"
-
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
+// GenerateSchema builds the table schema of a message. Every problem found is reported as a ValidationError,
+// joined into the returned error.
 func (t Translator) GenerateSchema(message proto.Message) (Schema, error) {
 	md := message.ProtoReflect().Descriptor()
 	tableName := string(md.Name())
 
+	var errs []error
 	var columns []ColumnSchema
-	var indexes []string
+	var indexes []IndexSchema
+	var oneofs []OneofSchema
+	oneofIndex := make(map[protoreflect.FullName]int)
 	for i := 0; i < md.Fields().Len(); i++ {
 		field := md.Fields().Get(i)
 		c, err := extractFieldSchema(field, t.dbConnection.DbType)
 		if err != nil {
-			return Schema{}, err
+			errs = append(errs, err)
+			continue
+		}
+		c.Comment = t.sourceComment(field)
+		if reference := fieldReference(field); reference != "" {
+			c.ForeignKeyTable, c.ForeignKeyColumn, err = t.resolveFieldReference(reference, c)
+			if err != nil {
+				errs = append(errs, newFieldError(field, CodeInvalidReference, "%s", err))
+			}
+		}
+
+		// Oneof variants are stored in nullable columns next to a discriminator column
+		if od := field.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			idx, seen := oneofIndex[od.FullName()]
+			if !seen {
+				discriminator := oneofDiscriminatorColumn(od)
+				columns = append(columns, discriminator)
+				oneofs = append(oneofs, OneofSchema{
+					Name:                string(od.Name()),
+					DiscriminatorColumn: discriminator.Name,
+				})
+				idx = len(oneofs) - 1
+				oneofIndex[od.FullName()] = idx
+			}
+			c.Constraints = removeConstraint(c.Constraints, "NOT NULL")
+			oneofs[idx].Variants = append(oneofs[idx].Variants, OneofVariant{
+				Case:   string(field.Name()),
+				Column: c.Name,
+			})
 		}
 
 		// Parse index type for individual fields
-		index, err := parseIndexes(field)
+		indexed, indexType, err := parseIndexes(field)
 		if err != nil {
-			return Schema{}, err
+			errs = append(errs, newFieldError(field, CodeInvalidIndex, "%s", err))
 		}
-		if index != "" {
-			indexes = append(indexes, fmt.Sprintf("%s (%s)", index, c.Name))
+		// Primary key and unique columns are already indexed
+		redundant := indexType == "" && (c.IsPrimaryKey || contains(c.Constraints, "UNIQUE"))
+		if indexed && !redundant {
+			indexColumns := []IndexColumn{{Name: c.Name}}
+			indexes = append(indexes, IndexSchema{
+				Name:    defaultIndexName(tableName, indexColumns),
+				Columns: indexColumns,
+				Type:    indexType,
+			})
 		}
 		columns = append(columns, c)
 	}
 
 	// Parse composite indexes
 	compositeIndexes := parseCompositeIndexes(md)
-	uniqueConstraints, checkConstraints := parseTableLevelConstraints(md)
+	tableIndexes, err := parseTableIndexes(md)
+	if err != nil {
+		errs = append(errs, newMessageError(md, CodeInvalidIndex, "%s", err))
+	}
+	compositeIndexes = append(compositeIndexes, tableIndexes...)
+	// Foreign keys are resolved against the columns, which are incomplete when fields are invalid
+	if len(errs) > 0 {
+		return Schema{}, t.locateErrors(errs)
+	}
+	foreignKeys, err := t.parseForeignKeys(md, columns)
+	if err != nil {
+		return Schema{}, t.locateErrors([]error{newMessageError(md, CodeInvalidForeignKey, "%s", err)})
+	}
+	uniqueConstraints, legacyChecks := parseTableLevelConstraints(md)
+	checkConstraints, err := parseCheckConstraints(md, columns, legacyChecks, oneofs)
+	if err != nil {
+		return Schema{}, t.locateErrors([]error{newMessageError(md, CodeInvalidCheck, "%s", err)})
+	}
 
 	return Schema{
 		TableName:            tableName,
+		Comment:              t.sourceComment(md),
 		Columns:              columns,
 		Indexes:              indexes,
 		CompositeIndexes:     compositeIndexes,
 		CompositePrimaryKeys: parseCompositePrimaryKeys(md),
 		UniqueConstraints:    uniqueConstraints,
 		CheckConstraints:     checkConstraints,
+		ForeignKeys:          foreignKeys,
+		Oneofs:               oneofs,
+		Options:              parseTableOptions(md),
 	}, nil
 }
@@ -9,6 +9,10 @@ import (
 
 func (t Translator) GenerateCreateTableSQL(schema Schema) string {
 	var createStmt strings.Builder
+	if t.dbConnection.DbType == db.DatabaseTypeSQLite {
+		// SQLite has no COMMENT clause, comments are written as a header instead
+		createStmt.WriteString(sqliteCommentHeader(schema))
+	}
 	createStmt.WriteString(fmt.Sprintf("CREATE TABLE `%s` (\n", schema.TableName))
 
 	// Add column definitions
@@ -21,18 +25,19 @@ func (t Translator) GenerateCreateTableSQL(schema Schema) string {
 				col.Collation = ""
 			}
 		}
-		createStmt.WriteString(fmt.Sprintf("  %s %s", col.Name, col.Type))
-
-		// Add precision for DECIMAL
-		if col.Type == "DECIMAL" && (col.Precision > 0 || col.Scale > 0) {
-			createStmt.WriteString(fmt.Sprintf("(%d,%d)", col.Precision, col.Scale))
-		}
+		// Type, including precision for DECIMAL
+		createStmt.WriteString(fmt.Sprintf("  %s %s", col.Name, t.columnTypeDDL(schema, col)))
 
 		// Add character set and collation
 		if col.CharacterSet != "" {
 			createStmt.WriteString(fmt.Sprintf(" CHARACTER SET %s", col.CharacterSet))
 		}
 
+		// Generated columns are computed from an expression and can't be written to
+		if col.GeneratedExpression != "" {
+			createStmt.WriteString(" " + generatedColumnSQL(col))
+		}
+
 		// Add constraints
 		if len(col.Constraints) > 0 {
 			createStmt.WriteString(fmt.Sprintf(" %s", joinConstraints(col.Constraints)))
@@ -62,6 +67,10 @@ func (t Translator) GenerateCreateTableSQL(schema Schema) string {
 			createStmt.WriteString(" PRIMARY KEY")
 		}
 
+		if col.Comment != "" && t.dbConnection.DbType != db.DatabaseTypeSQLite {
+			createStmt.WriteString(fmt.Sprintf(" COMMENT %s", t.stringLiteral(col.Comment)))
+		}
+
 		// Add a comma if it's not the last column
 		if i < len(schema.Columns)-1 {
 			createStmt.WriteString(",\n")
@@ -78,29 +87,15 @@ func (t Translator) GenerateCreateTableSQL(schema Schema) string {
 		createStmt.WriteString(fmt.Sprintf(",\n  UNIQUE (%s)", unique))
 	}
 
-	// Generate CREATE INDEX statements for composite indexes
-	for _, compositeIndex := range schema.CompositeIndexes {
-		// Split the composite index definition into individual column names
-		indexColumns := strings.Split(compositeIndex, ",")
-		// Generate a unique index name based on the column names
-		indexName := fmt.Sprintf("%s_%s_idx", schema.TableName, strings.Join(indexColumns, "_"))
-		// Create the CREATE INDEX statement
-		createStmt.WriteString(fmt.Sprintf(",\n  UNIQUE KEY `%s` (%s)", indexName, strings.Join(indexColumns, ", ")))
-	}
-	// CHECK (quantity > 0 AND price_per_unit >= 0),
-
-	if len(schema.CheckConstraints) > 0 {
-		checkConstraint := ""
-		for i, x := range schema.CheckConstraints {
-			if i == 0 {
-				checkConstraint += x
-			} else {
-				checkConstraint += " AND "
-				checkConstraint += x
-			}
+	// Add indexes inline for MySQL, SQLite only supports standalone CREATE INDEX statements
+	if t.dbConnection.DbType != db.DatabaseTypeSQLite {
+		for _, index := range t.tableIndexes(schema) {
+			createStmt.WriteString(fmt.Sprintf(",\n  %s", t.inlineIndexSQL(index)))
 		}
-
-		createStmt.WriteString(fmt.Sprintf(",\n  CHECK (%s)", checkConstraint))
+	}
+	// Add each CHECK constraint separately, so it can be identified and managed on its own
+	for _, check := range schema.CheckConstraints {
+		createStmt.WriteString(fmt.Sprintf(",\n  %s", checkConstraintSQL(check)))
 	}
 	// Add foreign key constraints as table-level constraints
 	for _, col := range schema.Columns {
@@ -115,15 +110,80 @@ func (t Translator) GenerateCreateTableSQL(schema Schema) string {
 			}
 		}
 	}
+	for _, foreignKey := range schema.ForeignKeys {
+		createStmt.WriteString(fmt.Sprintf(",\n  %s", foreignKeySQL(foreignKey)))
+	}
 
-	createStmt.WriteString("\n);")
-	for _, index := range schema.Indexes {
-		createStmt.WriteString(fmt.Sprintf("\nCREATE %s;", index))
-
+	createStmt.WriteString("\n)")
+	if t.dbConnection.DbType == db.DatabaseTypeSQLite {
+		if sqliteOptions := sqliteTableOptions(schema.Options); len(sqliteOptions) > 0 {
+			createStmt.WriteString(" " + strings.Join(sqliteOptions, ", "))
+		}
+	} else {
+		tableOptions := mysqlTableOptions(schema.Options)
+		if schema.Comment != "" {
+			tableOptions = append(tableOptions, fmt.Sprintf("COMMENT=%s", t.stringLiteral(schema.Comment)))
+		}
+		if len(tableOptions) > 0 {
+			createStmt.WriteString(" " + strings.Join(tableOptions, " "))
+		}
 	}
+	createStmt.WriteString(";")
 
-	// Add composite index definitions
+	// Add the indexes as standalone statements for SQLite
+	if t.dbConnection.DbType == db.DatabaseTypeSQLite {
+		for _, index := range t.tableIndexes(schema) {
+			createStmt.WriteString("\n" + t.createIndexSQL(schema.TableName, index))
+		}
+	}
 
 	// log.Printf("----Table: %s, Statement: %s", schema.TableName, createStmt.String())
 	return createStmt.String()
 }
+
+// sqliteCommentHeader renders the table and column comments as SQL comment lines
+func sqliteCommentHeader(schema Schema) string {
+	var header strings.Builder
+	if schema.Comment != "" {
+		header.WriteString(fmt.Sprintf("-- %s: %s\n", schema.TableName, schema.Comment))
+	}
+	for _, col := range schema.Columns {
+		if col.Comment != "" {
+			header.WriteString(fmt.Sprintf("-- %s.%s: %s\n", schema.TableName, col.Name, col.Comment))
+		}
+	}
+	return header.String()
+}
+
+// mysqlTableOptions renders the MySQL table options that are set
+func mysqlTableOptions(options TableOptions) []string {
+	var result []string
+	if options.Engine != "" {
+		result = append(result, fmt.Sprintf("ENGINE=%s", options.Engine))
+	}
+	if options.DefaultCharset != "" {
+		result = append(result, fmt.Sprintf("DEFAULT CHARSET=%s", options.DefaultCharset))
+	}
+	if options.Collation != "" {
+		result = append(result, fmt.Sprintf("COLLATE=%s", options.Collation))
+	}
+	if options.RowFormat != "" {
+		result = append(result, fmt.Sprintf("ROW_FORMAT=%s", options.RowFormat))
+	}
+	if options.AutoIncrement > 0 {
+		result = append(result, fmt.Sprintf("AUTO_INCREMENT=%d", options.AutoIncrement))
+	}
+	return result
+}
+
+// sqliteTableOptions renders the SQLite table options that are set
+func sqliteTableOptions(options TableOptions) []string {
+	var result []string
+	if options.Strict {
+		result = append(result, "STRICT")
+	}
+	if options.WithoutRowid {
+		result = append(result, "WITHOUT ROWID")
+	}
+	return result
+}
@@ -97,28 +97,96 @@ func TestGenerateCreateTableSQL(t *testing.T) {
 				Columns: []ColumnSchema{
 					{Name: "content", Type: "TEXT", Constraints: []string{"NOT NULL"}},
 				},
-				Indexes: []string{"FULLTEXT INDEX (content)"},
+				Indexes: []IndexSchema{{Name: "Articles_content_idx", Columns: []IndexColumn{{Name: "content"}}, Type: "FULLTEXT"}},
 			},
 			expected: `CREATE TABLE ` + "`Articles`" + ` (
-		  content TEXT NOT NULL
-		);
-		CREATE FULLTEXT INDEX (content);`,
+		  content TEXT NOT NULL,
+		  FULLTEXT KEY ` + "`Articles_content_idx`" + ` (content)
+		);`,
 		},
 		{
-			name: "Composite Index",
+			name: "Single Column and Composite Indexes",
 			schema: Schema{
 				TableName: "Orders",
 				Columns: []ColumnSchema{
 					{Name: "order_id", Type: "INT", Constraints: []string{"NOT NULL"}},
 					{Name: "customer_id", Type: "INT", Constraints: []string{"NOT NULL"}},
 				},
-				Indexes: []string{"INDEX (order_id, customer_id)"},
+				Indexes:          []IndexSchema{{Name: "Orders_customer_id_idx", Columns: []IndexColumn{{Name: "customer_id"}}}},
+				CompositeIndexes: []IndexSchema{{Name: "Orders_order_id_customer_id_idx", Columns: []IndexColumn{{Name: "order_id"}, {Name: "customer_id"}}}},
 			},
 			expected: `CREATE TABLE ` + "`Orders`" + ` (
 		  order_id INT NOT NULL,
-		  customer_id INT NOT NULL
-		);
-		CREATE INDEX (order_id, customer_id);`,
+		  customer_id INT NOT NULL,
+		  KEY ` + "`Orders_customer_id_idx`" + ` (customer_id),
+		  KEY ` + "`Orders_order_id_customer_id_idx`" + ` (order_id, customer_id)
+		);`,
+		},
+		{
+			name: "Decimal Precision and Scale",
+			schema: Schema{
+				TableName: "Invoices",
+				Columns: []ColumnSchema{
+					{Name: "amount", Type: "DECIMAL", Precision: 10, Scale: 2, Constraints: []string{"NOT NULL"}},
+					{Name: "tax", Type: "DECIMAL(6,3)", Precision: 6, Scale: 3},
+				},
+			},
+			expected: `CREATE TABLE ` + "`Invoices`" + ` (
+		  amount DECIMAL(10,2) NOT NULL,
+		  tax DECIMAL(6,3)
+		);`,
+		},
+		{
+			name: "Generated Columns",
+			schema: Schema{
+				TableName: "Customer",
+				Columns: []ColumnSchema{
+					{Name: "email", Type: "VARCHAR(255)", Constraints: []string{"NOT NULL"}},
+					{Name: "email_lower", Type: "VARCHAR(255)", GeneratedExpression: "LOWER(email)", GeneratedStorage: "STORED"},
+					{Name: "email_length", Type: "INT", Constraints: []string{"NOT NULL"}, GeneratedExpression: "LENGTH(email)"},
+				},
+			},
+			expected: `CREATE TABLE ` + "`Customer`" + ` (
+		  email VARCHAR(255) NOT NULL,
+		  email_lower VARCHAR(255) GENERATED ALWAYS AS (LOWER(email)) STORED,
+		  email_length INT GENERATED ALWAYS AS (LENGTH(email)) VIRTUAL NOT NULL
+		);`,
+		},
+		{
+			name: "Table and Column Comments",
+			schema: Schema{
+				TableName: "Customer",
+				Comment:   `Message for the Customer entity, see C:\docs`,
+				Columns: []ColumnSchema{
+					{Name: "customer_id", Type: "INT", Constraints: []string{"NOT NULL"}, IsPrimaryKey: true, Comment: "Customer's id"},
+					{Name: "email", Type: "VARCHAR(255)", Constraints: []string{"NOT NULL"}},
+				},
+			},
+			expected: `CREATE TABLE ` + "`Customer`" + ` (
+		  customer_id INT NOT NULL PRIMARY KEY COMMENT 'Customer''s id',
+		  email VARCHAR(255) NOT NULL
+		) COMMENT='Message for the Customer entity, see C:\\docs';`,
+		},
+		{
+			name: "Table Options",
+			schema: Schema{
+				TableName: "Orders",
+				Comment:   "Placed orders",
+				Columns: []ColumnSchema{
+					{Name: "order_id", Type: "INT", Constraints: []string{"NOT NULL"}, AutoIncrement: true},
+				},
+				Options: TableOptions{
+					Engine:         "InnoDB",
+					DefaultCharset: "utf8mb4",
+					Collation:      "utf8mb4_unicode_ci",
+					RowFormat:      "DYNAMIC",
+					AutoIncrement:  1000,
+					Strict:         true,
+				},
+			},
+			expected: `CREATE TABLE ` + "`Orders`" + ` (
+		  order_id INT NOT NULL AUTO_INCREMENT PRIMARY KEY
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC AUTO_INCREMENT=1000 COMMENT='Placed orders';`,
 		},
 		{
 			name: "Table with Foreign Keys",
@@ -174,7 +242,8 @@ func TestGenerateCreateTableSQLFromUserProto(t *testing.T) {
   is_2fa_enabled BOOLEAN NOT NULL DEFAULT FALSE,
   two_factor_secret VARCHAR(255),
   created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE KEY ` + "`User_email_ci_idx`" + ` ((LOWER(email)))
 );`
 
 	// Validated by running through mysql:
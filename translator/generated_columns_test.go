@@ -0,0 +1,71 @@
+package proto_db
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSchemaGeneratedColumn(t *testing.T) {
+	schema, err := NewTranslator(db.DefaultMysqlConnection()).GenerateSchema(&userauth.Customer{})
+	require.NoError(t, err)
+
+	emailLower, ok := findColumnInSchema("email_lower", schema.Columns)
+	require.True(t, ok)
+	require.Equal(t, "LOWER(email)", emailLower.GeneratedExpression)
+	require.Equal(t, "STORED", emailLower.GeneratedStorage)
+
+	email, ok := findColumnInSchema("email", schema.Columns)
+	require.True(t, ok)
+	require.Empty(t, email.GeneratedExpression)
+	require.Empty(t, email.GeneratedStorage)
+}
+
+func TestGeneratedColumnSqlite(t *testing.T) {
+	translator := NewTranslator(db.DefaultSqliteConnection())
+
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+
+	schema, err := translator.GenerateSchema(&userauth.Customer{})
+	require.NoError(t, err)
+	_, err = database.Exec(translator.GenerateCreateTableSQL(schema))
+	require.NoError(t, err)
+
+	_, err = database.Exec("INSERT INTO Customer (customer_id, customer_name, email) VALUES (1, 'Ada', 'Ada@Example.COM')")
+	require.NoError(t, err)
+
+	var emailLower string
+	require.NoError(t, database.QueryRow("SELECT email_lower FROM Customer WHERE customer_id = 1").Scan(&emailLower))
+	require.Equal(t, "ada@example.com", emailLower)
+
+	// Generated columns are read-only
+	_, err = database.Exec("INSERT INTO Customer (customer_id, customer_name, email, email_lower) VALUES (2, 'Bob', 'bob@example.com', 'x')")
+	require.Error(t, err)
+	_, err = database.Exec("UPDATE Customer SET email_lower = 'x' WHERE customer_id = 1")
+	require.Error(t, err)
+}
+
+func TestGenerateMigrationSqliteStoredGeneratedColumn(t *testing.T) {
+	oldSchema := Schema{
+		TableName: "Customer",
+		Columns:   []ColumnSchema{{Name: "email", Type: "VARCHAR(255)"}},
+	}
+	newSchema := Schema{
+		TableName: "Customer",
+		Columns: []ColumnSchema{
+			{Name: "email", Type: "VARCHAR(255)"},
+			{Name: "email_lower", Type: "VARCHAR(255)", GeneratedExpression: "LOWER(email)", GeneratedStorage: "STORED"},
+			{Name: "email_upper", Type: "VARCHAR(255)", GeneratedExpression: "UPPER(email)", GeneratedStorage: "VIRTUAL"},
+		},
+	}
+
+	migration := NewSqliteTranslator().GenerateMigration(oldSchema, newSchema)
+	require.Equal(t, "-- Migration for table: Customer\n"+
+		"-- SQLite can't add STORED generated column email_lower to Customer, the table must be rebuilt\n"+
+		"ALTER TABLE Customer ADD COLUMN email_upper VARCHAR(255) GENERATED ALWAYS AS (UPPER(email)) VIRTUAL;\n", migration)
+}
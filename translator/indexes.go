@@ -0,0 +1,264 @@
+package proto_db
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	dbExt "github.com/imran31415/proto-db-translator/annotations"
+	"github.com/imran31415/proto-db-translator/translator/db"
+	dbAn "github.com/imran31415/protobuf-db/db-annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// parseIndexes returns whether the field has a single column index and the index type,
+// empty for a regular index
+func parseIndexes(field protoreflect.FieldDescriptor) (bool, string, error) {
+	options, _ := field.Options().(*descriptorpb.FieldOptions)
+	if options == nil {
+		return false, "", nil
+	}
+
+	indexType, _ := proto.GetExtension(options, dbExt.E_DbIndexType).(dbAn.DbIndexType)
+	switch indexType {
+	case dbAn.DbIndexType_DB_INDEX_TYPE_UNSPECIFIED:
+		indexed, _ := proto.GetExtension(options, dbAn.E_DbIndex).(bool)
+		return indexed, "", nil
+	case dbAn.DbIndexType_DB_INDEX_TYPE_SIMPLE:
+		return true, "", nil
+	case dbAn.DbIndexType_DB_INDEX_TYPE_FULLTEXT:
+		return true, "FULLTEXT", nil
+	case dbAn.DbIndexType_DB_INDEX_TYPE_SPATIAL:
+		return true, "SPATIAL", nil
+	default:
+		return false, "", fmt.Errorf("unsupported index type for field '%s': %v", field.FullName(), indexType)
+	}
+}
+
+// parseCompositeIndexes parses the legacy db_composite_index option, a semicolon separated list of
+// comma separated columns. Those indexes are plain (non-unique) lookup indexes.
+func parseCompositeIndexes(md protoreflect.MessageDescriptor) []IndexSchema {
+	options, ok := md.Options().(*descriptorpb.MessageOptions)
+	if !ok || options == nil {
+		return nil
+	}
+
+	// Extract the composite index string
+	compositeIndexStr, ok := proto.GetExtension(options, dbAn.E_DbCompositeIndex).(string)
+	if !ok || compositeIndexStr == "" {
+		return nil
+	}
+
+	var indexes []IndexSchema
+	for _, rawIndex := range strings.Split(compositeIndexStr, ";") {
+		var columns []IndexColumn
+		for _, name := range strings.Split(rawIndex, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				columns = append(columns, IndexColumn{Name: name})
+			}
+		}
+		if len(columns) > 0 {
+			indexes = append(indexes, IndexSchema{Name: defaultIndexName(string(md.Name()), columns), Columns: columns})
+		}
+	}
+	return indexes
+}
+
+// parseTableIndexes parses the db_table_index options of the message
+func parseTableIndexes(md protoreflect.MessageDescriptor) ([]IndexSchema, error) {
+	options, ok := md.Options().(*descriptorpb.MessageOptions)
+	if !ok || options == nil {
+		return nil, nil
+	}
+
+	tableIndexes, _ := proto.GetExtension(options, dbExt.E_DbTableIndex).([]*dbExt.DbTableIndex)
+	var indexes []IndexSchema
+	for i, tableIndex := range tableIndexes {
+		if len(tableIndex.GetColumns()) == 0 {
+			return nil, fmt.Errorf("index %d of message '%s' has no columns", i, md.FullName())
+		}
+		index := IndexSchema{Name: tableIndex.GetName(), Unique: tableIndex.GetUnique(), Where: tableIndex.GetWhere()}
+		for _, column := range tableIndex.GetColumns() {
+			if (column.GetName() == "") == (column.GetExpression() == "") {
+				return nil, fmt.Errorf("index %d of message '%s' needs either a column name or an expression per column", i, md.FullName())
+			}
+			index.Columns = append(index.Columns, IndexColumn{
+				Name:         column.GetName(),
+				Expression:   column.GetExpression(),
+				Desc:         column.GetDesc(),
+				PrefixLength: column.GetPrefixLength(),
+			})
+		}
+		switch tableIndex.GetMethod() {
+		case dbExt.DbIndexMethod_DB_INDEX_METHOD_BTREE:
+			index.Method = "BTREE"
+		case dbExt.DbIndexMethod_DB_INDEX_METHOD_HASH:
+			index.Method = "HASH"
+		}
+		if index.Name == "" {
+			index.Name = defaultIndexName(string(md.Name()), index.Columns)
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+// defaultIndexName derives a deterministic index name from the table and column names,
+// expressions are reduced to their identifiers, e.g. LOWER(email) becomes lower_email
+func defaultIndexName(tableName string, columns []IndexColumn) string {
+	var names []string
+	for _, column := range columns {
+		if column.Expression != "" {
+			names = append(names, expressionName(column.Expression))
+			continue
+		}
+		names = append(names, column.Name)
+	}
+	return generateIndexName(strings.Join(names, ","), tableName)
+}
+
+// expressionName turns an expression into an identifier usable in an index name
+func expressionName(expression string) string {
+	words := strings.FieldsFunc(strings.ToLower(expression), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_')
+	})
+	return strings.Join(words, "_")
+}
+
+// indexColumnsSQL renders the ordered column list of an index
+func (t Translator) indexColumnsSQL(index IndexSchema) string {
+	var columns []string
+	for _, column := range index.Columns {
+		sql := column.Name
+		if column.Expression != "" {
+			// Functional key parts are wrapped in parentheses
+			sql = fmt.Sprintf("(%s)", column.Expression)
+		}
+		if column.PrefixLength > 0 && t.dbConnection.DbType != db.DatabaseTypeSQLite {
+			sql += fmt.Sprintf("(%d)", column.PrefixLength)
+		}
+		if column.Desc {
+			sql += " DESC"
+		}
+		columns = append(columns, sql)
+	}
+	return strings.Join(columns, ", ")
+}
+
+// tableIndexes returns the single column indexes followed by the composite indexes of the schema.
+// MySQL has no partial indexes, they are left out rather than created without their predicate,
+// ValidateSchema reports them.
+func (t Translator) tableIndexes(schema Schema) []IndexSchema {
+	var indexes []IndexSchema
+	for _, index := range append(append([]IndexSchema{}, schema.Indexes...), schema.CompositeIndexes...) {
+		if index.Where != "" && t.dbConnection.DbType != db.DatabaseTypeSQLite {
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
+
+// validateIndexes checks that the indexes of the schema reference existing columns and
+// only use features supported by the database
+func (t Translator) validateIndexes(schema Schema) error {
+	for _, index := range append(append([]IndexSchema{}, schema.Indexes...), schema.CompositeIndexes...) {
+		if len(index.Columns) == 0 {
+			return fmt.Errorf("index '%s' has no columns", index.Name)
+		}
+		if index.Where != "" && t.dbConnection.DbType != db.DatabaseTypeSQLite {
+			return fmt.Errorf("index '%s': partial indexes are not supported by MySQL", index.Name)
+		}
+		for _, column := range index.Columns {
+			if column.Expression != "" {
+				if column.PrefixLength > 0 {
+					return fmt.Errorf("index '%s': prefix length is not supported for expression '%s'", index.Name, column.Expression)
+				}
+				continue
+			}
+			if _, ok := findColumnInSchema(column.Name, schema.Columns); !ok {
+				return fmt.Errorf("index '%s' references unknown column '%s'", index.Name, column.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// inlineIndexSQL renders an index as part of a MySQL CREATE TABLE statement
+func (t Translator) inlineIndexSQL(index IndexSchema) string {
+	var sql strings.Builder
+	if index.Unique {
+		sql.WriteString("UNIQUE ")
+	} else if index.Type != "" {
+		sql.WriteString(index.Type + " ")
+	}
+	sql.WriteString(fmt.Sprintf("KEY `%s`", index.Name))
+	if index.Method != "" {
+		sql.WriteString(fmt.Sprintf(" USING %s", index.Method))
+	}
+	sql.WriteString(fmt.Sprintf(" (%s)", t.indexColumnsSQL(index)))
+	return sql.String()
+}
+
+// createIndexSQL renders an index as a standalone CREATE INDEX statement.
+// SQLite has no FULLTEXT or SPATIAL indexes, they are created as regular indexes.
+func (t Translator) createIndexSQL(tableName string, index IndexSchema) string {
+	var sql strings.Builder
+	sql.WriteString("CREATE ")
+	if index.Unique {
+		sql.WriteString("UNIQUE ")
+	} else if index.Type != "" && t.dbConnection.DbType != db.DatabaseTypeSQLite {
+		sql.WriteString(index.Type + " ")
+	}
+	sql.WriteString(fmt.Sprintf("INDEX `%s`", index.Name))
+	if index.Method != "" && t.dbConnection.DbType != db.DatabaseTypeSQLite {
+		sql.WriteString(fmt.Sprintf(" USING %s", index.Method))
+	}
+	sql.WriteString(fmt.Sprintf(" ON `%s` (%s)", tableName, t.indexColumnsSQL(index)))
+	if index.Where != "" {
+		sql.WriteString(fmt.Sprintf(" WHERE %s", index.Where))
+	}
+	sql.WriteString(";")
+	return sql.String()
+}
+
+// dropIndexSQL renders the statement dropping an index
+func (t Translator) dropIndexSQL(tableName string, index IndexSchema) string {
+	if t.dbConnection.DbType == db.DatabaseTypeSQLite {
+		return fmt.Sprintf("DROP INDEX `%s`;", index.Name)
+	}
+	return fmt.Sprintf("DROP INDEX `%s` ON `%s`;", index.Name, tableName)
+}
+
+// indexMigration renders the statements creating, dropping and re-creating the indexes that changed
+func (t Translator) indexMigration(oldSchema, newSchema Schema) string {
+	var migration strings.Builder
+	oldIndexes := make(map[string]IndexSchema)
+	for _, index := range t.tableIndexes(oldSchema) {
+		oldIndexes[index.Name] = index
+	}
+	newIndexes := make(map[string]bool)
+	for _, index := range t.tableIndexes(newSchema) {
+		newIndexes[index.Name] = true
+	}
+
+	// Dropped indexes first, so a re-created index can reuse its name
+	for _, index := range t.tableIndexes(oldSchema) {
+		if !newIndexes[index.Name] {
+			migration.WriteString(t.dropIndexSQL(newSchema.TableName, index) + "\n")
+		}
+	}
+	for _, index := range t.tableIndexes(newSchema) {
+		oldIndex, exists := oldIndexes[index.Name]
+		if exists && reflect.DeepEqual(oldIndex, index) {
+			continue
+		}
+		if exists {
+			migration.WriteString(t.dropIndexSQL(newSchema.TableName, oldIndex) + "\n")
+		}
+		migration.WriteString(t.createIndexSQL(newSchema.TableName, index) + "\n")
+	}
+	return migration.String()
+}
@@ -0,0 +1,216 @@
+package proto_db
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGenerateSchemaCompositeIndexes(t *testing.T) {
+	schema, err := NewTranslator(db.DefaultMysqlConnection()).GenerateSchema(&userauth.Orders{})
+	require.NoError(t, err)
+	require.Equal(t, []IndexSchema{
+		{
+			Name:    "Orders_order_date_status_idx",
+			Columns: []IndexColumn{{Name: "order_date"}, {Name: "status"}},
+		},
+		{
+			Name:    "orders_customer_recent_idx",
+			Columns: []IndexColumn{{Name: "customer_id"}, {Name: "order_date", Desc: true}},
+		},
+	}, schema.CompositeIndexes)
+
+	schema, err = NewTranslator(db.DefaultMysqlConnection()).GenerateSchema(&userauth.OrderDetails{})
+	require.NoError(t, err)
+	require.Equal(t, []IndexSchema{
+		{Name: "OrderDetails_created_at_order_id_idx", Columns: []IndexColumn{{Name: "created_at"}, {Name: "order_id"}}},
+		{Name: "OrderDetails_product_id_quantity_idx", Columns: []IndexColumn{{Name: "product_id"}, {Name: "quantity"}}},
+	}, schema.CompositeIndexes)
+}
+
+func TestCompositeIndexesSQL(t *testing.T) {
+	schema := Schema{
+		TableName: "Articles",
+		Columns: []ColumnSchema{
+			{Name: "author_id", Type: "INT", Constraints: []string{"NOT NULL"}},
+			{Name: "slug", Type: "VARCHAR(255)", Constraints: []string{"NOT NULL"}},
+			{Name: "published_at", Type: "DATETIME"},
+		},
+		CompositeIndexes: []IndexSchema{
+			{Name: "Articles_author_id_published_at_idx", Columns: []IndexColumn{{Name: "author_id"}, {Name: "published_at", Desc: true}}},
+			{Name: "articles_slug_uidx", Columns: []IndexColumn{{Name: "author_id"}, {Name: "slug", PrefixLength: 64}}, Unique: true, Method: "BTREE"},
+		},
+	}
+
+	require.Equal(t, "CREATE TABLE `Articles` (\n"+
+		"  author_id INT NOT NULL,\n"+
+		"  slug VARCHAR(255) NOT NULL,\n"+
+		"  published_at DATETIME,\n"+
+		"  KEY `Articles_author_id_published_at_idx` (author_id, published_at DESC),\n"+
+		"  UNIQUE KEY `articles_slug_uidx` USING BTREE (author_id, slug(64))\n"+
+		");", NewTranslator(db.DefaultMysqlConnection()).GenerateCreateTableSQL(schema))
+
+	sqlite := NewSqliteTranslator()
+	statement := sqlite.GenerateCreateTableSQL(schema)
+	require.Equal(t, "CREATE TABLE `Articles` (\n"+
+		"  author_id INT NOT NULL,\n"+
+		"  slug VARCHAR(255) NOT NULL,\n"+
+		"  published_at DATETIME\n"+
+		");\n"+
+		"CREATE INDEX `Articles_author_id_published_at_idx` ON `Articles` (author_id, published_at DESC);\n"+
+		"CREATE UNIQUE INDEX `articles_slug_uidx` ON `Articles` (author_id, slug);", statement)
+
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+	_, err = database.Exec(statement)
+	require.NoError(t, err)
+
+	// Only the unique index rejects duplicates
+	_, err = database.Exec("INSERT INTO Articles (author_id, slug) VALUES (1, 'hello'), (1, 'world'), (2, 'hello')")
+	require.NoError(t, err)
+	_, err = database.Exec("INSERT INTO Articles (author_id, slug) VALUES (1, 'hello')")
+	require.Error(t, err)
+}
+
+func TestValidateSqliteCompositeIndexes(t *testing.T) {
+	statements, err := NewSqliteTranslator().ValidateSchema([]proto.Message{&userauth.Customer{}, &userauth.Orders{}, &userauth.OrderDetails{}})
+	require.NoError(t, err)
+	require.Len(t, statements, 3)
+}
+
+func TestGenerateSchemaSingleColumnIndexes(t *testing.T) {
+	translator := NewTranslator(db.DefaultMysqlConnection())
+
+	schema, err := translator.GenerateSchema(&userauth.Customer{})
+	require.NoError(t, err)
+	require.Equal(t, []IndexSchema{
+		{Name: "Customer_customer_name_idx", Columns: []IndexColumn{{Name: "customer_name"}}},
+	}, schema.Indexes)
+
+	// Indexes on unique columns are redundant and skipped
+	schema, err = translator.GenerateSchema(&userauth.User{})
+	require.NoError(t, err)
+	require.Empty(t, schema.Indexes)
+
+	schema, err = translator.GenerateSchema(&userauth.Product{})
+	require.NoError(t, err)
+	require.Equal(t, []IndexSchema{
+		{Name: "Product_description_idx", Columns: []IndexColumn{{Name: "description"}}, Type: "FULLTEXT"},
+	}, schema.Indexes)
+	require.Contains(t, translator.GenerateCreateTableSQL(schema), ",\n  FULLTEXT KEY `Product_description_idx` (description)\n)")
+
+	// SQLite has no FULLTEXT indexes, a regular index is created instead
+	sqlite := NewSqliteTranslator()
+	schema, err = sqlite.GenerateSchema(&userauth.Product{})
+	require.NoError(t, err)
+	require.Contains(t, sqlite.GenerateCreateTableSQL(schema), ";\nCREATE INDEX `Product_description_idx` ON `Product` (description);")
+	_, err = sqlite.ValidateSchema([]proto.Message{&userauth.Customer{}, &userauth.Product{}})
+	require.NoError(t, err)
+}
+
+func TestGenerateMigrationSqliteIndexes(t *testing.T) {
+	oldSchema := Schema{
+		TableName: "User",
+		Indexes:   []IndexSchema{{Name: "User_email_idx", Columns: []IndexColumn{{Name: "email"}}}},
+	}
+	newSchema := Schema{
+		TableName: "User",
+		Indexes:   []IndexSchema{{Name: "User_username_idx", Columns: []IndexColumn{{Name: "username"}}}},
+	}
+	require.Equal(t, "-- Migration for table: User\n"+
+		"DROP INDEX `User_email_idx`;\n"+
+		"CREATE INDEX `User_username_idx` ON `User` (username);\n", NewSqliteTranslator().GenerateMigration(oldSchema, newSchema))
+}
+
+func TestExpressionIndexUserEmail(t *testing.T) {
+	schema, err := NewSqliteTranslator().GenerateSchema(&userauth.User{})
+	require.NoError(t, err)
+	require.Equal(t, []IndexSchema{
+		{Name: "User_email_ci_idx", Columns: []IndexColumn{{Expression: "LOWER(email)"}}, Unique: true},
+	}, schema.CompositeIndexes)
+
+	statement := NewSqliteTranslator().GenerateCreateTableSQL(schema)
+	require.Contains(t, statement, ";\nCREATE UNIQUE INDEX `User_email_ci_idx` ON `User` ((LOWER(email)));")
+
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+	_, err = database.Exec(statement)
+	require.NoError(t, err)
+
+	// Emails differing only by case are rejected
+	_, err = database.Exec("INSERT INTO User (id, username, email, hashed_password) VALUES (1, 'ada', 'Ada@Example.com', 'x')")
+	require.NoError(t, err)
+	_, err = database.Exec("INSERT INTO User (id, username, email, hashed_password) VALUES (2, 'ada2', 'ada@example.COM', 'x')")
+	require.Error(t, err)
+}
+
+func TestPartialIndexSqlite(t *testing.T) {
+	schema := Schema{
+		TableName: "Subscription",
+		Columns: []ColumnSchema{
+			{Name: "customer_id", Type: "INT", Constraints: []string{"NOT NULL"}},
+			{Name: "deleted_at", Type: "DATETIME"},
+		},
+		CompositeIndexes: []IndexSchema{
+			{Name: "Subscription_customer_id_active_idx", Columns: []IndexColumn{{Name: "customer_id"}}, Unique: true, Where: "deleted_at IS NULL"},
+		},
+	}
+
+	statement := NewSqliteTranslator().GenerateCreateTableSQL(schema)
+	require.Contains(t, statement, ";\nCREATE UNIQUE INDEX `Subscription_customer_id_active_idx` ON `Subscription` (customer_id) WHERE deleted_at IS NULL;")
+
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+	_, err = database.Exec(statement)
+	require.NoError(t, err)
+
+	// Only active rows have to be unique
+	_, err = database.Exec("INSERT INTO Subscription (customer_id, deleted_at) VALUES (1, '2024-01-01'), (1, '2024-02-01'), (1, NULL)")
+	require.NoError(t, err)
+	_, err = database.Exec("INSERT INTO Subscription (customer_id, deleted_at) VALUES (1, NULL)")
+	require.Error(t, err)
+
+	// MySQL has no partial indexes, the index is left out and reported by validation
+	mysql := NewTranslator(db.DefaultMysqlConnection())
+	require.NotContains(t, mysql.GenerateCreateTableSQL(schema), "Subscription_customer_id_active_idx")
+	require.ErrorContains(t, mysql.validateIndexes(schema), "partial indexes are not supported by MySQL")
+	require.NoError(t, NewSqliteTranslator().validateIndexes(schema))
+}
+
+func TestValidateIndexes(t *testing.T) {
+	columns := []ColumnSchema{{Name: "email", Type: "VARCHAR(255)"}}
+	tests := []struct {
+		name   string
+		index  IndexSchema
+		errMsg string
+	}{
+		{"Valid column", IndexSchema{Name: "a", Columns: []IndexColumn{{Name: "email"}}}, ""},
+		{"Valid expression", IndexSchema{Name: "b", Columns: []IndexColumn{{Expression: "LOWER(email)"}}}, ""},
+		{"No columns", IndexSchema{Name: "c"}, "index 'c' has no columns"},
+		{"Unknown column", IndexSchema{Name: "d", Columns: []IndexColumn{{Name: "mail"}}}, "index 'd' references unknown column 'mail'"},
+		{"Expression prefix", IndexSchema{Name: "e", Columns: []IndexColumn{{Expression: "LOWER(email)", PrefixLength: 8}}}, "prefix length is not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewSqliteTranslator().validateIndexes(Schema{TableName: "User", Columns: columns, CompositeIndexes: []IndexSchema{tt.index}})
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.errMsg)
+			}
+		})
+	}
+
+	require.Equal(t, "lower_email", expressionName("LOWER(email)"))
+	require.Equal(t, "User_lower_email_idx", defaultIndexName("User", []IndexColumn{{Expression: "LOWER(email)"}}))
+	require.Equal(t, "User_"+strings.Repeat("c", 40)+"_"+strings.Repeat("c", 14)+"_idx", defaultIndexName("User", []IndexColumn{{Name: strings.Repeat("c", 40)}, {Name: strings.Repeat("c", 40)}}),
+		"default names are truncated to 64 characters")
+}
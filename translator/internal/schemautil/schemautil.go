@@ -0,0 +1,44 @@
+// Package schemautil holds the helpers on translated schemas shared by the translator and its lint package.
+package schemautil
+
+import (
+	"strings"
+
+	dbAn "github.com/imran31415/protobuf-db/db-annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// SplitColumns splits a comma separated column list
+func SplitColumns(columns string) []string {
+	var names []string
+	for _, name := range strings.Split(columns, ",") {
+		names = append(names, strings.TrimSpace(name))
+	}
+	return names
+}
+
+// BaseColumnType strips the length and modifiers of a column type, e.g. VARCHAR(255) becomes VARCHAR
+func BaseColumnType(sqlType string) string {
+	base := strings.ToUpper(sqlType)
+	if i := strings.IndexAny(base, "( "); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
+
+// ColumnField finds the field stored in a column, or nil for columns without a field like oneof discriminators
+func ColumnField(md protoreflect.MessageDescriptor, column string) protoreflect.FieldDescriptor {
+	for i := 0; i < md.Fields().Len(); i++ {
+		field := md.Fields().Get(i)
+		options, _ := field.Options().(*descriptorpb.FieldOptions)
+		if options == nil {
+			continue
+		}
+		if name, ok := proto.GetExtension(options, dbAn.E_DbColumn).(string); ok && name != "" && name == column {
+			return field
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Package lint checks translated schemas for design problems which are valid SQL but likely mistakes,
+// like tables without a primary key or money stored in floating point columns.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	proto_db "github.com/imran31415/proto-db-translator/translator"
+	"github.com/imran31415/proto-db-translator/translator/internal/schemautil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Finding is a problem reported by a rule
+type Finding struct {
+	Rule     string            `json:"rule"`
+	Severity proto_db.Severity `json:"severity"`
+	Table    string            `json:"table"`
+	Column   string            `json:"column,omitempty"`
+	Message  string            `json:"message"`
+	// Position of the message or field declaration, filled in by Locate. StartLine and StartColumn are only
+	// known when the translator has source info.
+	File        string `json:"file,omitempty"`
+	StartLine   int    `json:"start_line,omitempty"`
+	StartColumn int    `json:"start_column,omitempty"`
+}
+
+// Rule is a lint rule checking one schema. The set of all linted schemas is passed for rules
+// looking across tables.
+type Rule struct {
+	ID          string
+	Description string
+	Severity    proto_db.Severity // Default severity, overridable in the config
+	Check       func(schema proto_db.Schema, schemas []proto_db.Schema) []Finding
+}
+
+// RuleConfig overrides the defaults of a rule
+type RuleConfig struct {
+	Enabled  *bool             `json:"enabled,omitempty"`
+	Severity proto_db.Severity `json:"severity,omitempty"`
+}
+
+// Config enables, disables and sets the severity of rules by ID, rules missing from the config use their defaults
+type Config struct {
+	Rules map[string]RuleConfig `json:"rules"`
+}
+
+// LoadConfig reads a JSON config file, e.g. {"rules": {"missing-timestamps": {"enabled": false}}}
+func LoadConfig(path string) (Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read lint config: %w", err)
+	}
+	var config Config
+	if err := json.Unmarshal(b, &config); err != nil {
+		return Config{}, fmt.Errorf("failed to parse lint config '%s': %w", path, err)
+	}
+	return config, nil
+}
+
+// Lint runs the enabled rules over the schemas, findings are sorted by table, column and rule
+func Lint(schemas []proto_db.Schema, config Config) ([]Finding, error) {
+	rules, err := enabledRules(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, schema := range schemas {
+		for _, rule := range rules {
+			for _, finding := range rule.Check(schema, schemas) {
+				finding.Rule = rule.ID
+				finding.Severity = rule.Severity
+				finding.Table = schema.TableName
+				findings = append(findings, finding)
+			}
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Table != findings[j].Table {
+			return findings[i].Table < findings[j].Table
+		}
+		if findings[i].Column != findings[j].Column {
+			return findings[i].Column < findings[j].Column
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+	return findings, nil
+}
+
+// Locate fills in the proto file of the findings from the messages the schemas were generated from, and the line
+// and column of the message or field declaration when the translator has source info, see
+// proto_db.Translator.WithSourceInfo. Findings of tables generated from none of the messages are left as is.
+func Locate(translator proto_db.Translator, protoMessages []proto.Message, findings []Finding) []Finding {
+	descriptors := make(map[string]protoreflect.MessageDescriptor)
+	for _, protoMessage := range protoMessages {
+		md := protoMessage.ProtoReflect().Descriptor()
+		descriptors[string(md.Name())] = md
+	}
+
+	located := make([]Finding, len(findings))
+	for i, finding := range findings {
+		located[i] = finding
+		md, ok := descriptors[finding.Table]
+		if !ok {
+			continue
+		}
+		location := proto_db.ValidationError{Message: string(md.FullName()), File: md.ParentFile().Path()}
+		// Columns stored by no field, e.g. oneof discriminators, are located at the message
+		if field := schemautil.ColumnField(md, finding.Column); field != nil {
+			location.Field = string(field.Name())
+		}
+		location = translator.Locate(location)
+		located[i].File = location.File
+		located[i].StartLine = location.Line
+		located[i].StartColumn = location.Column
+	}
+	return located
+}
+
+// enabledRules applies the config to the built-in rules
+func enabledRules(config Config) ([]Rule, error) {
+	known := make(map[string]bool)
+	for _, rule := range Rules {
+		known[rule.ID] = true
+	}
+	for id, ruleConfig := range config.Rules {
+		if !known[id] {
+			return nil, fmt.Errorf("unknown lint rule '%s'", id)
+		}
+		switch ruleConfig.Severity {
+		case "", proto_db.SeverityError, proto_db.SeverityWarning:
+		default:
+			return nil, fmt.Errorf("invalid severity '%s' for lint rule '%s'", ruleConfig.Severity, id)
+		}
+	}
+
+	var rules []Rule
+	for _, rule := range Rules {
+		ruleConfig := config.Rules[rule.ID]
+		if ruleConfig.Enabled != nil && !*ruleConfig.Enabled {
+			continue
+		}
+		if ruleConfig.Severity != "" {
+			rule.Severity = ruleConfig.Severity
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
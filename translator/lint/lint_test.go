@@ -0,0 +1,294 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	proto_db "github.com/imran31415/proto-db-translator/translator"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// timestamps are the columns expected by the missing-timestamps rule
+var timestamps = []proto_db.ColumnSchema{
+	{Name: "created_at", Type: "DATETIME", Constraints: []string{"NOT NULL"}},
+	{Name: "updated_at", Type: "DATETIME", Constraints: []string{"NOT NULL"}},
+}
+
+func withTimestamps(columns ...proto_db.ColumnSchema) []proto_db.ColumnSchema {
+	return append(columns, timestamps...)
+}
+
+func TestRules(t *testing.T) {
+	id := proto_db.ColumnSchema{Name: "id", Type: "INT", IsPrimaryKey: true}
+	tests := []struct {
+		name     string
+		schema   proto_db.Schema
+		expected []Finding
+	}{
+		{
+			name:   "clean table",
+			schema: proto_db.Schema{TableName: "Accounts", Columns: withTimestamps(id, proto_db.ColumnSchema{Name: "active", Type: "BOOLEAN", Constraints: []string{"NOT NULL"}})},
+		},
+		{
+			name:   "composite primary key",
+			schema: proto_db.Schema{TableName: "Accounts", CompositePrimaryKeys: "a,b", Columns: withTimestamps(proto_db.ColumnSchema{Name: "a", Type: "INT"}, proto_db.ColumnSchema{Name: "b", Type: "INT"})},
+		},
+		{
+			name:     "no primary key",
+			schema:   proto_db.Schema{TableName: "Accounts", Columns: withTimestamps(proto_db.ColumnSchema{Name: "name", Type: "TEXT"})},
+			expected: []Finding{{Rule: "no-primary-key", Severity: proto_db.SeverityError, Table: "Accounts", Message: "table 'Accounts' has no primary key"}},
+		},
+		{
+			name: "unindexed foreign keys",
+			schema: proto_db.Schema{
+				TableName: "Accounts",
+				Columns: withTimestamps(id,
+					proto_db.ColumnSchema{Name: "owner_id", Type: "INT", ForeignKeyTable: "Users", ForeignKeyColumn: "id"},
+					proto_db.ColumnSchema{Name: "team_id", Type: "INT", ForeignKeyTable: "Teams", ForeignKeyColumn: "id"},
+					proto_db.ColumnSchema{Name: "region", Type: "INT"},
+					proto_db.ColumnSchema{Name: "zone", Type: "INT"},
+				),
+				CompositeIndexes: []proto_db.IndexSchema{{Name: "accounts_team_idx", Columns: []proto_db.IndexColumn{{Name: "team_id"}, {Name: "region"}}}},
+				ForeignKeys: []proto_db.ForeignKeySchema{
+					{Name: "accounts_zone_fk", Columns: []string{"region", "zone"}, ReferencesTable: "Zones", ReferencesColumns: []string{"region", "zone"}},
+				},
+			},
+			expected: []Finding{
+				{Rule: "unindexed-foreign-key", Severity: proto_db.SeverityWarning, Table: "Accounts", Column: "owner_id", Message: "foreign key column 'owner_id' referencing 'Users' has no index"},
+				{Rule: "unindexed-foreign-key", Severity: proto_db.SeverityWarning, Table: "Accounts", Column: "region", Message: "foreign key 'accounts_zone_fk' on (region, zone) has no index"},
+			},
+		},
+		{
+			name: "foreign key without columns",
+			schema: proto_db.Schema{
+				TableName:   "Accounts",
+				Columns:     withTimestamps(proto_db.ColumnSchema{Name: "id", Type: "INT"}),
+				ForeignKeys: []proto_db.ForeignKeySchema{{Name: "accounts_owner_fk", ReferencesTable: "Users"}},
+			},
+			expected: []Finding{{Rule: "no-primary-key", Severity: proto_db.SeverityError, Table: "Accounts", Message: "table 'Accounts' has no primary key"}},
+		},
+		{
+			name: "unique keys too long",
+			schema: proto_db.Schema{
+				TableName: "Accounts",
+				Columns: withTimestamps(id,
+					proto_db.ColumnSchema{Name: "email", Type: "VARCHAR(255)", Constraints: []string{"UNIQUE"}},
+					proto_db.ColumnSchema{Name: "url", Type: "VARCHAR(1000)", Constraints: []string{"UNIQUE"}},
+					proto_db.ColumnSchema{Name: "legacy_url", Type: "VARCHAR(1000)", CharacterSet: "latin1", Constraints: []string{"UNIQUE"}},
+					proto_db.ColumnSchema{Name: "title", Type: "VARCHAR(500)"},
+					proto_db.ColumnSchema{Name: "slug", Type: "VARCHAR(500)"},
+				),
+				CompositeIndexes: []proto_db.IndexSchema{
+					{Name: "accounts_title_slug_uidx", Unique: true, Columns: []proto_db.IndexColumn{{Name: "title"}, {Name: "slug"}}},
+					{Name: "accounts_title_prefix_uidx", Unique: true, Columns: []proto_db.IndexColumn{{Name: "title", PrefixLength: 100}, {Name: "slug", PrefixLength: 100}}},
+				},
+			},
+			expected: []Finding{
+				{Rule: "unique-key-too-long", Severity: proto_db.SeverityError, Table: "Accounts", Column: "title", Message: "unique key 'accounts_title_slug_uidx' is 4000 bytes long, InnoDB allows 3072 bytes"},
+				{Rule: "unique-key-too-long", Severity: proto_db.SeverityError, Table: "Accounts", Column: "url", Message: "unique key 'url' is 4000 bytes long, InnoDB allows 3072 bytes"},
+			},
+		},
+		{
+			name: "float money",
+			schema: proto_db.Schema{TableName: "Accounts", Columns: withTimestamps(id,
+				proto_db.ColumnSchema{Name: "balance", Type: "DOUBLE"},
+				proto_db.ColumnSchema{Name: "unit_price", Type: "DECIMAL(10,2)"},
+				proto_db.ColumnSchema{Name: "latitude", Type: "FLOAT"},
+			)},
+			expected: []Finding{{Rule: "float-money", Severity: proto_db.SeverityWarning, Table: "Accounts", Column: "balance", Message: "money column 'balance' is DOUBLE, use DECIMAL"}},
+		},
+		{
+			name:     "missing timestamps",
+			schema:   proto_db.Schema{TableName: "Accounts", Columns: []proto_db.ColumnSchema{id, timestamps[0]}},
+			expected: []Finding{{Rule: "missing-timestamps", Severity: proto_db.SeverityWarning, Table: "Accounts", Message: "table 'Accounts' has no updated_at column"}},
+		},
+		{
+			name:     "column naming case",
+			schema:   proto_db.Schema{TableName: "Accounts", Columns: withTimestamps(id, proto_db.ColumnSchema{Name: "displayName", Type: "TEXT"})},
+			expected: []Finding{{Rule: "naming-case", Severity: proto_db.SeverityWarning, Table: "Accounts", Column: "displayName", Message: "column 'displayName' is not snake_case"}},
+		},
+		{
+			name:     "nullable boolean",
+			schema:   proto_db.Schema{TableName: "Accounts", Columns: withTimestamps(id, proto_db.ColumnSchema{Name: "active", Type: "BOOLEAN", Constraints: []string{"DEFAULT TRUE"}})},
+			expected: []Finding{{Rule: "nullable-boolean", Severity: proto_db.SeverityWarning, Table: "Accounts", Column: "active", Message: "boolean column 'active' is nullable"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := Lint([]proto_db.Schema{tt.schema}, Config{})
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, findings)
+		})
+	}
+}
+
+func disabled() RuleConfig {
+	enabled := false
+	return RuleConfig{Enabled: &enabled}
+}
+
+func TestTableNamingCase(t *testing.T) {
+	schemas := []proto_db.Schema{{TableName: "Users"}, {TableName: "Orders"}, {TableName: "order_items"}}
+	findings, err := Lint(schemas, Config{Rules: map[string]RuleConfig{
+		"no-primary-key":     disabled(),
+		"missing-timestamps": disabled(),
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []Finding{
+		{Rule: "naming-case", Severity: proto_db.SeverityWarning, Table: "order_items", Message: "table 'order_items' is snake_case while most tables are PascalCase"},
+	}, findings)
+}
+
+func TestLintUserSchemas(t *testing.T) {
+	translator := proto_db.NewSqliteTranslator()
+	customer, err := translator.GenerateSchema(&userauth.Customer{})
+	require.NoError(t, err)
+	orderItems, err := translator.GenerateSchema(&userauth.OrderItems{})
+	require.NoError(t, err)
+
+	findings, err := Lint([]proto_db.Schema{customer, orderItems}, Config{})
+	require.NoError(t, err)
+	require.Equal(t, []Finding{
+		{Rule: "missing-timestamps", Severity: proto_db.SeverityWarning, Table: "OrderItems", Message: "table 'OrderItems' has no created_at and updated_at columns"},
+		{Rule: "unindexed-foreign-key", Severity: proto_db.SeverityWarning, Table: "OrderItems", Column: "product_id", Message: "foreign key column 'product_id' referencing 'Product' has no index"},
+	}, findings)
+}
+
+func TestLocate(t *testing.T) {
+	findings := []Finding{
+		{Rule: "missing-timestamps", Severity: proto_db.SeverityWarning, Table: "OrderItems", Message: "table 'OrderItems' has no created_at and updated_at columns"},
+		{Rule: "unindexed-foreign-key", Severity: proto_db.SeverityWarning, Table: "OrderItems", Column: "product_id", Message: "foreign key column 'product_id' referencing 'Product' has no index"},
+		{Rule: "no-primary-key", Severity: proto_db.SeverityError, Table: "Accounts", Message: "table 'Accounts' has no primary key"},
+	}
+	messages := []proto.Message{&userauth.OrderItems{}}
+
+	// Without source info only the file is known
+	located := Locate(proto_db.NewSqliteTranslator(), messages, findings)
+	require.Equal(t, "proto/order.proto", located[0].File)
+	require.Zero(t, located[0].StartLine)
+	require.Empty(t, located[2].File, "tables of other messages aren't located")
+
+	fdp := protodesc.ToFileDescriptorProto(userauth.File_proto_order_proto)
+	md := (&userauth.OrderItems{}).ProtoReflect().Descriptor()
+	productID := md.Fields().ByName("product_id")
+	fdp.SourceCodeInfo = &descriptorpb.SourceCodeInfo{
+		Location: []*descriptorpb.SourceCodeInfo_Location{
+			{Path: []int32{4, int32(md.Index())}, Span: []int32{116, 0, 10}},
+			{Path: []int32{4, int32(md.Index()), 2, int32(productID.Index())}, Span: []int32{137, 4, 10}},
+		},
+	}
+	translator, err := proto_db.NewSqliteTranslator().WithSourceInfo(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fdp}})
+	require.NoError(t, err)
+
+	located = Locate(translator, messages, findings)
+	require.Equal(t, []Finding{
+		{Rule: "missing-timestamps", Severity: proto_db.SeverityWarning, Table: "OrderItems", Message: "table 'OrderItems' has no created_at and updated_at columns",
+			File: "proto/order.proto", StartLine: 117, StartColumn: 1},
+		{Rule: "unindexed-foreign-key", Severity: proto_db.SeverityWarning, Table: "OrderItems", Column: "product_id", Message: "foreign key column 'product_id' referencing 'Product' has no index",
+			File: "proto/order.proto", StartLine: 138, StartColumn: 5},
+		findings[2],
+	}, located)
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lint.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+  "rules": {
+    "missing-timestamps": {"enabled": false},
+    "nullable-boolean": {"severity": "error"}
+  }
+}`), 0o644))
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+
+	schema := proto_db.Schema{TableName: "Flags", Columns: []proto_db.ColumnSchema{
+		{Name: "id", Type: "INT", IsPrimaryKey: true},
+		{Name: "enabled", Type: "BOOLEAN"},
+	}}
+	findings, err := Lint([]proto_db.Schema{schema}, config)
+	require.NoError(t, err)
+	require.Equal(t, []Finding{
+		{Rule: "nullable-boolean", Severity: proto_db.SeverityError, Table: "Flags", Column: "enabled", Message: "boolean column 'enabled' is nullable"},
+	}, findings)
+
+	_, err = LoadConfig(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	_, err := Lint(nil, Config{Rules: map[string]RuleConfig{"no-such-rule": disabled()}})
+	require.EqualError(t, err, "unknown lint rule 'no-such-rule'")
+
+	_, err = Lint(nil, Config{Rules: map[string]RuleConfig{"float-money": {Severity: "fatal"}}})
+	require.EqualError(t, err, "invalid severity 'fatal' for lint rule 'float-money'")
+}
+
+func TestWriteJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteJSON(&out, nil))
+	require.Equal(t, "[]\n", out.String())
+
+	out.Reset()
+	finding := Finding{Rule: "float-money", Severity: proto_db.SeverityWarning, Table: "Accounts", Column: "balance", Message: "money column 'balance' is DOUBLE, use DECIMAL"}
+	require.NoError(t, WriteJSON(&out, []Finding{finding}))
+	var decoded []Finding
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	require.Equal(t, []Finding{finding}, decoded)
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteSARIF(&out, []Finding{
+		{Rule: "no-primary-key", Severity: proto_db.SeverityError, Table: "Accounts", Message: "table 'Accounts' has no primary key"},
+		{Rule: "float-money", Severity: proto_db.SeverityWarning, Table: "Accounts", Column: "balance", Message: "money column 'balance' is DOUBLE, use DECIMAL",
+			File: "proto/accounts.proto", StartLine: 12, StartColumn: 3},
+		{Rule: "nullable-boolean", Severity: proto_db.SeverityWarning, Table: "Accounts", Column: "active", Message: "boolean column 'active' is nullable",
+			File: "proto/accounts.proto"},
+	}))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	require.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	require.Len(t, log.Runs[0].Tool.Driver.Rules, len(Rules))
+	require.Equal(t, []sarifResult{
+		{
+			RuleID:    "no-primary-key",
+			RuleIndex: 0,
+			Level:     "error",
+			Message:   sarifMessage{Text: "table 'Accounts' has no primary key"},
+			Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: "Accounts", Kind: "type"}}}},
+		},
+		{
+			RuleID:    "float-money",
+			RuleIndex: 3,
+			Level:     "warning",
+			Message:   sarifMessage{Text: "money column 'balance' is DOUBLE, use DECIMAL"},
+			Locations: []sarifLocation{{
+				PhysicalLocation: &sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: "proto/accounts.proto"},
+					Region:           &sarifRegion{StartLine: 12, StartColumn: 3},
+				},
+				LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: "Accounts.balance", Kind: "member"}},
+			}},
+		},
+		{
+			RuleID:    "nullable-boolean",
+			RuleIndex: 6,
+			Level:     "warning",
+			Message:   sarifMessage{Text: "boolean column 'active' is nullable"},
+			Locations: []sarifLocation{{
+				PhysicalLocation: &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "proto/accounts.proto"}},
+				LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: "Accounts.active", Kind: "member"}},
+			}},
+		},
+	}, log.Runs[0].Results)
+}
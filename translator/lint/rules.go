@@ -0,0 +1,313 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	proto_db "github.com/imran31415/proto-db-translator/translator"
+	"github.com/imran31415/proto-db-translator/translator/internal/schemautil"
+)
+
+// innodbMaxKeyLength is the maximum index key length in bytes of InnoDB tables with the DYNAMIC row format
+const innodbMaxKeyLength = 3072
+
+var (
+	snakeCase      = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+	pascalCase     = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+	characterType  = regexp.MustCompile(`^(?i)(VAR)?CHAR\((\d+)\)`)
+	moneyColumn    = regexp.MustCompile(`(?i)(price|amount|cost|total|balance|fee|salary|money)`)
+	floatingPoints = map[string]bool{"FLOAT": true, "DOUBLE": true, "REAL": true}
+)
+
+// Rules are the built-in lint rules
+var Rules = []Rule{
+	{
+		ID:          "no-primary-key",
+		Description: "Tables should have a primary key",
+		Severity:    proto_db.SeverityError,
+		Check:       checkPrimaryKey,
+	},
+	{
+		ID:          "unindexed-foreign-key",
+		Description: "Foreign key columns should lead an index, SQLite doesn't create one",
+		Severity:    proto_db.SeverityWarning,
+		Check:       checkForeignKeyIndexes,
+	},
+	{
+		ID:          "unique-key-too-long",
+		Description: "Unique string keys must fit the InnoDB key length of 3072 bytes with utf8mb4",
+		Severity:    proto_db.SeverityError,
+		Check:       checkUniqueKeyLength,
+	},
+	{
+		ID:          "float-money",
+		Description: "Money should be stored in DECIMAL columns, floating point numbers can't represent cents exactly",
+		Severity:    proto_db.SeverityWarning,
+		Check:       checkFloatMoney,
+	},
+	{
+		ID:          "missing-timestamps",
+		Description: "Tables should have created_at and updated_at columns",
+		Severity:    proto_db.SeverityWarning,
+		Check:       checkTimestamps,
+	},
+	{
+		ID:          "naming-case",
+		Description: "Columns should be snake_case and tables should share one naming case",
+		Severity:    proto_db.SeverityWarning,
+		Check:       checkNamingCase,
+	},
+	{
+		ID:          "nullable-boolean",
+		Description: "Boolean columns should be NOT NULL, a nullable boolean has three states",
+		Severity:    proto_db.SeverityWarning,
+		Check:       checkNullableBoolean,
+	},
+}
+
+func checkPrimaryKey(schema proto_db.Schema, _ []proto_db.Schema) []Finding {
+	if schema.CompositePrimaryKeys != "" {
+		return nil
+	}
+	for _, column := range schema.Columns {
+		if column.IsPrimaryKey {
+			return nil
+		}
+	}
+	return []Finding{{Message: fmt.Sprintf("table '%s' has no primary key", schema.TableName)}}
+}
+
+func checkForeignKeyIndexes(schema proto_db.Schema, _ []proto_db.Schema) []Finding {
+	var findings []Finding
+	for _, column := range schema.Columns {
+		if column.ForeignKeyTable == "" || column.ForeignKeyColumn == "" || leadsIndex(schema, []string{column.Name}) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Column:  column.Name,
+			Message: fmt.Sprintf("foreign key column '%s' referencing '%s' has no index", column.Name, column.ForeignKeyTable),
+		})
+	}
+	for _, foreignKey := range schema.ForeignKeys {
+		// Foreign keys without columns are invalid, they are reported by the schema validation
+		if len(foreignKey.Columns) == 0 || leadsIndex(schema, foreignKey.Columns) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Column:  foreignKey.Columns[0],
+			Message: fmt.Sprintf("foreign key '%s' on (%s) has no index", foreignKey.Name, strings.Join(foreignKey.Columns, ", ")),
+		})
+	}
+	return findings
+}
+
+// leadsIndex reports whether the columns are the leading columns of the primary key, a unique constraint or an index
+func leadsIndex(schema proto_db.Schema, columns []string) bool {
+	var keys [][]string
+	for _, column := range schema.Columns {
+		if column.IsPrimaryKey || slices.Contains(column.Constraints, "UNIQUE") {
+			keys = append(keys, []string{column.Name})
+		}
+	}
+	if schema.CompositePrimaryKeys != "" {
+		keys = append(keys, schemautil.SplitColumns(schema.CompositePrimaryKeys))
+	}
+	for _, unique := range schema.UniqueConstraints {
+		keys = append(keys, schemautil.SplitColumns(unique))
+	}
+	for _, index := range append(append([]proto_db.IndexSchema{}, schema.Indexes...), schema.CompositeIndexes...) {
+		if index.Where != "" {
+			continue
+		}
+		var names []string
+		for _, column := range index.Columns {
+			names = append(names, column.Name)
+		}
+		keys = append(keys, names)
+	}
+
+	for _, key := range keys {
+		if len(key) < len(columns) {
+			continue
+		}
+		leading := true
+		for i, column := range columns {
+			if key[i] != column {
+				leading = false
+				break
+			}
+		}
+		if leading {
+			return true
+		}
+	}
+	return false
+}
+
+func checkUniqueKeyLength(schema proto_db.Schema, _ []proto_db.Schema) []Finding {
+	type uniqueKey struct {
+		name    string
+		columns []proto_db.IndexColumn
+	}
+	var keys []uniqueKey
+	for _, column := range schema.Columns {
+		if slices.Contains(column.Constraints, "UNIQUE") {
+			keys = append(keys, uniqueKey{name: column.Name, columns: []proto_db.IndexColumn{{Name: column.Name}}})
+		}
+	}
+	for _, unique := range schema.UniqueConstraints {
+		var columns []proto_db.IndexColumn
+		for _, name := range schemautil.SplitColumns(unique) {
+			columns = append(columns, proto_db.IndexColumn{Name: name})
+		}
+		keys = append(keys, uniqueKey{name: unique, columns: columns})
+	}
+	for _, index := range append(append([]proto_db.IndexSchema{}, schema.Indexes...), schema.CompositeIndexes...) {
+		if index.Unique {
+			keys = append(keys, uniqueKey{name: index.Name, columns: index.Columns})
+		}
+	}
+
+	var findings []Finding
+	for _, key := range keys {
+		length := 0
+		for _, indexColumn := range key.columns {
+			column, ok := findColumn(schema, indexColumn.Name)
+			if !ok {
+				continue
+			}
+			match := characterType.FindStringSubmatch(column.Type)
+			if match == nil {
+				continue
+			}
+			characters, _ := strconv.Atoi(match[2])
+			if indexColumn.PrefixLength > 0 && int(indexColumn.PrefixLength) < characters {
+				characters = int(indexColumn.PrefixLength)
+			}
+			length += characters * bytesPerCharacter(column.CharacterSet, schema.Options.DefaultCharset)
+		}
+		if length > innodbMaxKeyLength {
+			findings = append(findings, Finding{
+				Column:  key.columns[0].Name,
+				Message: fmt.Sprintf("unique key '%s' is %d bytes long, InnoDB allows %d bytes", key.name, length, innodbMaxKeyLength),
+			})
+		}
+	}
+	return findings
+}
+
+// bytesPerCharacter is the maximum number of bytes of a character in the column or table character set, utf8mb4 by default
+func bytesPerCharacter(charsets ...string) int {
+	for _, charset := range charsets {
+		switch strings.ToLower(charset) {
+		case "":
+			continue
+		case "latin1", "ascii", "binary":
+			return 1
+		case "ucs2":
+			return 2
+		case "utf8", "utf8mb3":
+			return 3
+		default:
+			return 4
+		}
+	}
+	return 4
+}
+
+func checkFloatMoney(schema proto_db.Schema, _ []proto_db.Schema) []Finding {
+	var findings []Finding
+	for _, column := range schema.Columns {
+		if floatingPoints[schemautil.BaseColumnType(column.Type)] && moneyColumn.MatchString(column.Name) {
+			findings = append(findings, Finding{
+				Column:  column.Name,
+				Message: fmt.Sprintf("money column '%s' is %s, use DECIMAL", column.Name, column.Type),
+			})
+		}
+	}
+	return findings
+}
+
+func checkTimestamps(schema proto_db.Schema, _ []proto_db.Schema) []Finding {
+	var missing []string
+	for _, name := range []string{"created_at", "updated_at"} {
+		if _, ok := findColumn(schema, name); !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if len(missing) == 2 {
+		return []Finding{{Message: fmt.Sprintf("table '%s' has no created_at and updated_at columns", schema.TableName)}}
+	}
+	return []Finding{{Message: fmt.Sprintf("table '%s' has no %s column", schema.TableName, missing[0])}}
+}
+
+func checkNamingCase(schema proto_db.Schema, schemas []proto_db.Schema) []Finding {
+	var findings []Finding
+
+	// The naming case of the table should match the one used by most tables
+	counts := make(map[string]int)
+	for _, other := range schemas {
+		counts[namingCase(other.TableName)]++
+	}
+	majority := ""
+	for _, style := range []string{"PascalCase", "snake_case", "mixed"} {
+		if counts[style] > counts[majority] {
+			majority = style
+		}
+	}
+	if style := namingCase(schema.TableName); style != majority {
+		findings = append(findings, Finding{
+			Message: fmt.Sprintf("table '%s' is %s while most tables are %s", schema.TableName, style, majority),
+		})
+	}
+
+	for _, column := range schema.Columns {
+		if !snakeCase.MatchString(column.Name) {
+			findings = append(findings, Finding{
+				Column:  column.Name,
+				Message: fmt.Sprintf("column '%s' is not snake_case", column.Name),
+			})
+		}
+	}
+	return findings
+}
+
+// namingCase classifies a name as PascalCase, snake_case or mixed
+func namingCase(name string) string {
+	switch {
+	case pascalCase.MatchString(name):
+		return "PascalCase"
+	case snakeCase.MatchString(name):
+		return "snake_case"
+	}
+	return "mixed"
+}
+
+func checkNullableBoolean(schema proto_db.Schema, _ []proto_db.Schema) []Finding {
+	var findings []Finding
+	for _, column := range schema.Columns {
+		if schemautil.BaseColumnType(column.Type) != "BOOLEAN" || column.IsPrimaryKey || slices.Contains(column.Constraints, "NOT NULL") {
+			continue
+		}
+		findings = append(findings, Finding{
+			Column:  column.Name,
+			Message: fmt.Sprintf("boolean column '%s' is nullable", column.Name),
+		})
+	}
+	return findings
+}
+
+func findColumn(schema proto_db.Schema, name string) (proto_db.ColumnSchema, bool) {
+	for _, column := range schema.Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+	return proto_db.ColumnSchema{}, false
+}
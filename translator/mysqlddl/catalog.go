@@ -0,0 +1,262 @@
+// Package mysqlddl validates MySQL DDL without a MySQL server. Statements are parsed with the TiDB MySQL
+// grammar and applied to an in-memory catalog enforcing the rules of a MySQL 8 server, a rejected statement
+// returns the error number and message the server would return.
+package mysqlddl
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	_ "github.com/pingcap/tidb/pkg/parser/test_driver" // Literal values of the parsed expressions
+)
+
+// Catalog is an in-memory MySQL database holding the definitions of the tables created in it.
+// It isn't safe for concurrent use.
+type Catalog struct {
+	parser      *parser.Parser
+	tables      map[string]*table
+	checks      map[string]string // Lowercased CHECK constraint names to their table, they are unique per database
+	foreignKeys map[string]string // Lowercased foreign key names to their table, they are unique per database
+}
+
+// NewCatalog returns an empty catalog. The first call registers the MySQL character sets TiDB doesn't
+// support in the character set registry of the TiDB parser, which is global.
+func NewCatalog() *Catalog {
+	registerCharsets()
+	return &Catalog{
+		parser:      parser.New(),
+		tables:      make(map[string]*table),
+		checks:      make(map[string]string),
+		foreignKeys: make(map[string]string),
+	}
+}
+
+// Exec applies the statements of the query to the catalog, like a MySQL connection with multi statements
+// enabled. It stops at the first rejected statement and returns its error as a *mysql.MySQLError.
+// CREATE TABLE, CREATE INDEX and DROP TABLE statements are supported.
+func (c *Catalog) Exec(query string) error {
+	stmts, _, err := c.parser.Parse(query, "", "")
+	if err != nil {
+		return parseError(err)
+	}
+	for _, stmt := range stmts {
+		var err error
+		switch stmt := stmt.(type) {
+		case *ast.CreateTableStmt:
+			err = c.createTable(stmt, query)
+		case *ast.CreateIndexStmt:
+			err = c.createIndex(stmt)
+		case *ast.DropTableStmt:
+			err = c.dropTable(stmt)
+		default:
+			err = newError(ErrNotSupportedYet, strings.TrimPrefix(fmt.Sprintf("%T", stmt), "*ast."))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Tables returns the names of the tables of the catalog, sorted
+func (c *Catalog) Tables() []string {
+	names := make([]string, 0, len(c.tables))
+	for name := range c.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Syntax errors of the parser, e.g. `line 1 column 26 near ");"`
+var syntaxErrorPattern = regexp.MustCompile(`(?s)^line (\d+) column \d+ near "(.*)"`)
+
+// parseError converts a parser error to the error MySQL returns for the statement
+func parseError(err error) error {
+	var parserErr *terror.Error
+	if errors.As(err, &parserErr) {
+		number := uint16(parserErr.Code())
+		mysqlErr := &mysql.MySQLError{Number: number, Message: parserErr.GetMsg()}
+		sqlState := "HY000"
+		if message, ok := errorMessages[number]; ok {
+			sqlState = message.sqlState
+		}
+		copy(mysqlErr.SQLState[:], sqlState)
+		return mysqlErr
+	}
+	if match := syntaxErrorPattern.FindStringSubmatch(err.Error()); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		return newError(ErrParse, match[2], line)
+	}
+	return newError(ErrParse, err.Error(), 1)
+}
+
+// definitions collects the keys, checks and foreign keys of a CREATE TABLE statement, declared on the
+// columns or the table, which are added once all the columns are known
+type definitions struct {
+	keys        []keyDef
+	checks      []checkDef
+	foreignKeys []*ast.Constraint
+}
+
+type keyDef struct {
+	name     string
+	primary  bool
+	unique   bool
+	fulltext bool
+	parts    []*ast.IndexPartSpecification
+}
+
+type checkDef struct {
+	name string
+	expr ast.ExprNode
+}
+
+func (c *Catalog) createTable(stmt *ast.CreateTableStmt, query string) error {
+	name := stmt.Table.Name.O
+	if _, exists := c.tables[name]; exists {
+		if stmt.IfNotExists {
+			return nil
+		}
+		return newError(ErrTableExists, name)
+	}
+	if stmt.ReferTable != nil || stmt.Select != nil || stmt.Partition != nil {
+		return newError(ErrNotSupportedYet, "CREATE TABLE ... LIKE, SELECT or PARTITION BY")
+	}
+	if err := checkIdentifier(name); err != nil {
+		return err
+	}
+	t := newTable(name)
+	if err := t.applyOptions(stmt.Options); err != nil {
+		return err
+	}
+
+	// Duplicate names are reported before the column definitions are checked, like MySQL does.
+	// Generated columns and checks may reference columns defined later in the statement.
+	for _, def := range stmt.Cols {
+		if err := t.addColumn(def.Name.Name.O); err != nil {
+			return err
+		}
+	}
+	var defs definitions
+	for _, def := range stmt.Cols {
+		if err := t.defineColumn(def, query, &defs); err != nil {
+			return err
+		}
+	}
+	for _, constraint := range stmt.Constraints {
+		switch constraint.Tp {
+		case ast.ConstraintPrimaryKey:
+			defs.keys = append(defs.keys, keyDef{primary: true, parts: constraint.Keys})
+		case ast.ConstraintKey, ast.ConstraintIndex:
+			defs.keys = append(defs.keys, keyDef{name: constraint.Name, parts: constraint.Keys})
+		case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+			defs.keys = append(defs.keys, keyDef{name: constraint.Name, unique: true, parts: constraint.Keys})
+		case ast.ConstraintFulltext:
+			defs.keys = append(defs.keys, keyDef{name: constraint.Name, fulltext: true, parts: constraint.Keys})
+		case ast.ConstraintCheck:
+			defs.checks = append(defs.checks, checkDef{name: constraint.Name, expr: constraint.Expr})
+		case ast.ConstraintForeignKey:
+			defs.foreignKeys = append(defs.foreignKeys, constraint)
+		default:
+			return newError(ErrNotSupportedYet, "vector and columnar indexes")
+		}
+	}
+
+	for _, key := range defs.keys {
+		if err := t.addIndex(key); err != nil {
+			return err
+		}
+	}
+	if err := t.checkAutoIncrement(); err != nil {
+		return err
+	}
+	if err := t.checkRowSize(); err != nil {
+		return err
+	}
+	for i, check := range defs.checks {
+		if err := c.addCheck(t, check, i+1); err != nil {
+			return err
+		}
+	}
+	// MySQL parses the foreign keys of the other storage engines but ignores them
+	if t.engine == "innodb" {
+		for i, constraint := range defs.foreignKeys {
+			if err := c.addForeignKey(t, constraint, i+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	c.tables[name] = t
+	for _, check := range t.checks {
+		c.checks[strings.ToLower(check.name)] = name
+	}
+	for _, foreignKey := range t.foreignKeys {
+		c.foreignKeys[strings.ToLower(foreignKey.name)] = name
+	}
+	return nil
+}
+
+func (c *Catalog) createIndex(stmt *ast.CreateIndexStmt) error {
+	t, exists := c.tables[stmt.Table.Name.O]
+	if !exists {
+		return newError(ErrNoSuchTable, stmt.Table.Name.O)
+	}
+	if stmt.IfNotExists && t.index(stmt.IndexName) != nil {
+		return nil
+	}
+	key := keyDef{
+		name:     stmt.IndexName,
+		unique:   stmt.KeyType == ast.IndexKeyTypeUnique,
+		fulltext: stmt.KeyType == ast.IndexKeyTypeFullText,
+		parts:    stmt.IndexPartSpecifications,
+	}
+	if stmt.KeyType != ast.IndexKeyTypeNone && !key.unique && !key.fulltext {
+		return newError(ErrNotSupportedYet, "SPATIAL, VECTOR and COLUMNAR indexes")
+	}
+	return t.addIndex(key)
+}
+
+func (c *Catalog) dropTable(stmt *ast.DropTableStmt) error {
+	if stmt.IsView {
+		return newError(ErrNotSupportedYet, "DROP VIEW")
+	}
+	dropped := make(map[string]bool)
+	for _, name := range stmt.Tables {
+		if _, exists := c.tables[name.Name.O]; exists {
+			dropped[name.Name.O] = true
+		} else if !stmt.IfExists {
+			return newError(ErrBadTable, name.Name.O)
+		}
+	}
+	// Tables referenced by a foreign key can only be dropped along with the referencing tables
+	for _, name := range c.Tables() {
+		if dropped[name] {
+			continue
+		}
+		for _, foreignKey := range c.tables[name].foreignKeys {
+			if dropped[foreignKey.refTable] {
+				return newError(ErrForeignKeyCannotDropParent, foreignKey.refTable, foreignKey.name, name)
+			}
+		}
+	}
+	for name := range dropped {
+		for _, check := range c.tables[name].checks {
+			delete(c.checks, strings.ToLower(check.name))
+		}
+		for _, foreignKey := range c.tables[name].foreignKeys {
+			delete(c.foreignKeys, strings.ToLower(foreignKey.name))
+		}
+		delete(c.tables, name)
+	}
+	return nil
+}
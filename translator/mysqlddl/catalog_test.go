@@ -0,0 +1,177 @@
+package mysqlddl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	customerTable = "CREATE TABLE `Customer` (customer_id INT NOT NULL PRIMARY KEY, email VARCHAR(255) NOT NULL UNIQUE);"
+	ordersTable   = "CREATE TABLE `Orders` (order_id INT NOT NULL PRIMARY KEY, customer_id INT NOT NULL, total DECIMAL(10,2) NOT NULL, FOREIGN KEY (customer_id) REFERENCES `Customer` (customer_id) ON DELETE CASCADE);"
+)
+
+func TestCatalogAcceptsValidStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   []string
+		query   string
+		indexes []string
+	}{
+		{
+			name:    "Keys And Defaults",
+			query:   "CREATE TABLE `User` (\n  id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,\n  email VARCHAR(255) NOT NULL UNIQUE,\n  bio TEXT,\n  enabled BOOLEAN NOT NULL DEFAULT FALSE,\n  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),\n  UNIQUE KEY `User_email_ci_idx` ((LOWER(email))),\n  FULLTEXT KEY `User_bio_idx` (bio)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;",
+			indexes: []string{"PRIMARY", "email", "User_email_ci_idx", "User_bio_idx"},
+		},
+		{
+			name:    "Expression Defaults",
+			query:   "CREATE TABLE `Token` (token VARCHAR(36) NOT NULL DEFAULT (UUID()) PRIMARY KEY, payload JSON DEFAULT (JSON_OBJECT()), status ENUM('new', 'used') NOT NULL DEFAULT 'new');",
+			indexes: []string{"PRIMARY"},
+		},
+		{
+			name:    "Character Sets Unsupported By TiDB",
+			query:   "CREATE TABLE `Legacy` (name VARCHAR(64) CHARACTER SET cp1250 COLLATE cp1250_general_ci, code CHAR(2) CHARACTER SET ucs2, KEY (name));",
+			indexes: []string{"name"},
+		},
+		{
+			name:    "Foreign Key Indexes Referencing Columns",
+			setup:   []string{customerTable},
+			query:   ordersTable,
+			indexes: []string{"PRIMARY", "customer_id"},
+		},
+		{
+			name:    "Composite Foreign Key Referencing Primary Key Prefix",
+			query:   "CREATE TABLE `Detail` (order_id INT NOT NULL, product_id INT NOT NULL, PRIMARY KEY (order_id, product_id)); CREATE TABLE `Shipment` (id INT NOT NULL PRIMARY KEY, order_id INT NOT NULL, product_id INT NOT NULL, CONSTRAINT `shipment_detail_fk` FOREIGN KEY (order_id, product_id) REFERENCES `Detail` (order_id, product_id) ON DELETE CASCADE ON UPDATE CASCADE);",
+			indexes: []string{"PRIMARY", "shipment_detail_fk"},
+		},
+		{
+			name:    "Self Reference",
+			query:   "CREATE TABLE `Role` (role_id INT NOT NULL AUTO_INCREMENT PRIMARY KEY, parent_role_id INT, FOREIGN KEY (parent_role_id) REFERENCES `Role` (role_id) ON DELETE SET NULL);",
+			indexes: []string{"PRIMARY", "parent_role_id"},
+		},
+		{
+			name:    "Standalone Index",
+			setup:   []string{customerTable},
+			query:   "CREATE INDEX `Customer_email_prefix_idx` ON `Customer` (email(16));",
+			indexes: []string{"PRIMARY", "email", "Customer_email_prefix_idx"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			catalog := NewCatalog()
+			for _, statement := range test.setup {
+				require.NoError(t, catalog.Exec(statement))
+			}
+			require.NoError(t, catalog.Exec(test.query))
+
+			tableName := catalog.Tables()[len(catalog.Tables())-1]
+			if strings.Contains(test.query, "CREATE INDEX") {
+				tableName = "Customer"
+			}
+			var indexes []string
+			for _, idx := range catalog.tables[tableName].indexes {
+				indexes = append(indexes, idx.name)
+			}
+			require.Equal(t, test.indexes, indexes)
+		})
+	}
+}
+
+func TestCatalogRejectsInvalidStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   []string
+		query   string
+		number  uint16
+		message string
+	}{
+		{"Syntax Error", nil, "CREATE TABLE t (id INTT);", ErrParse, "You have an error in your SQL syntax"},
+		{"Unparenthesized Expression Default", nil, "CREATE TABLE t (\n  created_at DATETIME NOT NULL DEFAULT UUID()\n);", ErrParse, "near 'UUID()\n);' at line 2"},
+		{"Unknown Character Set", nil, "CREATE TABLE t (name VARCHAR(10) CHARACTER SET unsupported_charset);", ErrUnknownCharacterSet, "Unknown character set: 'unsupported_charset'"},
+		{"Collation Of Another Character Set", nil, "CREATE TABLE t (name VARCHAR(10) CHARACTER SET latin1 COLLATE utf8mb4_bin);", ErrCollationCharsetMismatch, "COLLATION 'utf8mb4_bin' is not valid for CHARACTER SET 'latin1'"},
+		{"Unknown Storage Engine", nil, "CREATE TABLE t (id INT) ENGINE=Nope;", ErrUnknownStorageEngine, "Unknown storage engine 'Nope'"},
+		{"Table Exists", []string{customerTable}, customerTable, ErrTableExists, "Table 'Customer' already exists"},
+		{"Identifier Too Long", nil, "CREATE TABLE t (" + strings.Repeat("a", 65) + " INT);", ErrTooLongIdent, "is too long"},
+		{"Duplicate Column", nil, "CREATE TABLE t (code VARCHAR(255) AUTO_INCREMENT PRIMARY KEY, code INT);", ErrDupFieldName, "Duplicate column name 'code'"},
+		{"Auto Increment String", nil, "CREATE TABLE t (code VARCHAR(255) AUTO_INCREMENT PRIMARY KEY);", ErrWrongFieldSpec, "Incorrect column specifier for column 'code'"},
+		{"Auto Increment Without Key", nil, "CREATE TABLE t (id INT AUTO_INCREMENT);", ErrWrongAutoKey, "there can be only one auto column"},
+		{"Multiple Primary Keys", nil, "CREATE TABLE t (a INT PRIMARY KEY, b INT PRIMARY KEY);", ErrMultiplePriKey, "Multiple primary key defined"},
+		{"Nullable Primary Key", nil, "CREATE TABLE t (a INT NULL, PRIMARY KEY (a));", ErrPrimaryCantHaveNull, "All parts of a PRIMARY KEY must be NOT NULL"},
+		{"String Default On Integer", nil, "CREATE TABLE t (quantity INT DEFAULT 'many');", ErrInvalidDefault, "Invalid default value for 'quantity'"},
+		{"Null Default On Not Null", nil, "CREATE TABLE t (quantity INT NOT NULL DEFAULT NULL);", ErrInvalidDefault, "Invalid default value for 'quantity'"},
+		{"Current Timestamp On Integer", nil, "CREATE TABLE t (created INT DEFAULT CURRENT_TIMESTAMP);", ErrInvalidDefault, "Invalid default value for 'created'"},
+		{"Current Timestamp Precision", nil, "CREATE TABLE t (created DATETIME DEFAULT CURRENT_TIMESTAMP(3));", ErrInvalidDefault, "Invalid default value for 'created'"},
+		{"Invalid Date Default", nil, "CREATE TABLE t (created DATETIME DEFAULT '0000-00-00 00:00:00');", ErrInvalidDefault, "Invalid default value for 'created'"},
+		{"Default Longer Than Column", nil, "CREATE TABLE t (code CHAR(2) DEFAULT 'abc');", ErrInvalidDefault, "Invalid default value for 'code'"},
+		{"Literal Default On Text", nil, "CREATE TABLE t (bio TEXT DEFAULT 'none');", ErrBlobCantHaveDefault, "column 'bio' can't have a default value"},
+		{"On Update On Integer", nil, "CREATE TABLE t (updated INT ON UPDATE CURRENT_TIMESTAMP);", ErrInvalidOnUpdate, "Invalid ON UPDATE clause for 'updated' column"},
+		{"Varchar Too Long", nil, "CREATE TABLE t (name VARCHAR(20000));", ErrTooBigFieldLength, "(max = 16383)"},
+		{"Decimal Scale Above Precision", nil, "CREATE TABLE t (price DECIMAL(4,6));", ErrMBiggerThanD, "column 'price'"},
+		{"Row Too Large", nil, "CREATE TABLE t (a VARCHAR(10000), b VARCHAR(10000));", ErrTooBigRowSize, "Row size too large"},
+		{"Unknown Key Column", nil, "CREATE TABLE t (id INT NOT NULL, PRIMARY KEY (id, missing_column));", ErrKeyColumnDoesNotExist, "Key column 'missing_column' doesn't exist in table"},
+		{"Duplicate Key Name", nil, "CREATE TABLE t (a INT, b INT, KEY k (a), KEY k (b));", ErrDupKeyName, "Duplicate key name 'k'"},
+		{"Text Key Without Length", nil, "CREATE TABLE t (bio TEXT, KEY (bio));", ErrBlobKeyWithoutLength, "BLOB/TEXT column 'bio' used in key specification without a key length"},
+		{"Key Too Long", nil, "CREATE TABLE t (a VARCHAR(500), b VARCHAR(500), UNIQUE (a, b));", ErrTooLongKey, "max key length is 3072 bytes"},
+		{"Fulltext On Integer", nil, "CREATE TABLE t (a INT, FULLTEXT KEY (a));", ErrBadFulltextColumn, "Column 'a' cannot be part of FULLTEXT index"},
+		{"Generated Column Unknown Column", nil, "CREATE TABLE t (a VARCHAR(10) GENERATED ALWAYS AS (LOWER(b)) STORED);", ErrBadField, "Unknown column 'b' in 'generated column function'"},
+		{"Check Unknown Column", nil, "CREATE TABLE t (a INT, CONSTRAINT t_chk CHECK (b > 0));", ErrCheckUnknownColumn, "Check constraint 't_chk' refers to non-existing column 'b'."},
+		{"Check Nondeterministic Function", nil, "CREATE TABLE t (a DATETIME, CONSTRAINT t_chk CHECK (a < NOW()));", ErrCheckFunctionDisallowed, "contains disallowed function: now."},
+		{"Check Auto Increment Column", nil, "CREATE TABLE t (id INT AUTO_INCREMENT PRIMARY KEY, CONSTRAINT t_chk CHECK (id > 0));", ErrCheckAutoIncrementColumn, "Check constraint 't_chk' cannot refer to an auto-increment column."},
+		{"Check Name Used By Another Table", []string{"CREATE TABLE a (x INT, CONSTRAINT same_chk CHECK (x > 0));"}, "CREATE TABLE b (y INT, CONSTRAINT same_chk CHECK (y > 0));", ErrCheckDupName, "Duplicate check constraint name 'same_chk'."},
+		{"Missing Referenced Table", nil, "CREATE TABLE t (parent_id INT NOT NULL, FOREIGN KEY (parent_id) REFERENCES `NonExistentTable` (id) ON DELETE CASCADE);", ErrForeignKeyCannotOpenParent, "Failed to open the referenced table 'NonExistentTable'"},
+		{"Missing Referenced Column", []string{customerTable}, "CREATE TABLE t (customer_id INT, CONSTRAINT t_fk FOREIGN KEY (customer_id) REFERENCES `Customer` (id));", ErrForeignKeyNoColumnInParent, "Missing column 'id' for constraint 't_fk' in the referenced table 'Customer'"},
+		{"Unindexed Referenced Column", []string{"CREATE TABLE p (id INT, code INT);"}, "CREATE TABLE t (code INT, CONSTRAINT t_fk FOREIGN KEY (code) REFERENCES p (code));", ErrForeignKeyNoIndexInParent, "Missing index for constraint 't_fk' in the referenced table 'p'"},
+		{"Incompatible Columns", []string{customerTable}, "CREATE TABLE t (customer_id BIGINT, CONSTRAINT t_fk FOREIGN KEY (customer_id) REFERENCES `Customer` (customer_id));", ErrForeignKeyIncompatible, "Referencing column 'customer_id' and referenced column 'customer_id' in foreign key constraint 't_fk' are incompatible."},
+		{"Column Count Mismatch", []string{customerTable}, "CREATE TABLE t (a INT, b INT, CONSTRAINT t_fk FOREIGN KEY (a, b) REFERENCES `Customer` (customer_id));", ErrWrongForeignKeyDef, "Key reference and table reference don't match"},
+		{"Set Null On Not Null Column", []string{customerTable}, "CREATE TABLE t (customer_id INT NOT NULL, CONSTRAINT t_fk FOREIGN KEY (customer_id) REFERENCES `Customer` (customer_id) ON DELETE SET NULL);", ErrForeignKeyColumnNotNull, "Column 'customer_id' cannot be NOT NULL: needed in a foreign key constraint 't_fk' SET NULL"},
+		{"Cascading Column In Check", []string{customerTable}, "CREATE TABLE t (customer_id INT, CONSTRAINT t_chk CHECK (customer_id > 0), CONSTRAINT t_fk FOREIGN KEY (customer_id) REFERENCES `Customer` (customer_id) ON DELETE CASCADE);", ErrCheckForeignKeyActionColumn, "Column 'customer_id' cannot be used in a check constraint 't_chk'"},
+		{"Duplicate Foreign Key Name", []string{customerTable, "CREATE TABLE a (customer_id INT, CONSTRAINT same_fk FOREIGN KEY (customer_id) REFERENCES `Customer` (customer_id));"}, "CREATE TABLE b (customer_id INT, CONSTRAINT same_fk FOREIGN KEY (customer_id) REFERENCES `Customer` (customer_id));", ErrForeignKeyDupName, "Duplicate foreign key constraint name 'same_fk'"},
+		{"Index On Missing Table", nil, "CREATE INDEX idx ON missing (a);", ErrNoSuchTable, "Table 'missing' doesn't exist"},
+		{"Drop Referenced Table", []string{customerTable, ordersTable}, "DROP TABLE `Customer`;", ErrForeignKeyCannotDropParent, "Cannot drop table 'Customer' referenced by a foreign key constraint 'Orders_ibfk_1' on table 'Orders'."},
+		{"Unsupported Statement", []string{customerTable}, "ALTER TABLE `Customer` ADD COLUMN phone VARCHAR(32);", ErrNotSupportedYet, "AlterTableStmt"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			catalog := NewCatalog()
+			for _, statement := range test.setup {
+				require.NoError(t, catalog.Exec(statement))
+			}
+			tables := catalog.Tables()
+
+			err := catalog.Exec(test.query)
+			var mysqlErr *mysql.MySQLError
+			require.True(t, errors.As(err, &mysqlErr), "expected a MySQL error, got %v", err)
+			require.Equal(t, test.number, mysqlErr.Number, mysqlErr.Message)
+			require.Contains(t, mysqlErr.Message, test.message)
+			require.NotEqual(t, [5]byte{}, mysqlErr.SQLState)
+			// Rejected statements leave the catalog unchanged
+			require.Equal(t, tables, catalog.Tables())
+		})
+	}
+}
+
+func TestCatalogDropTable(t *testing.T) {
+	catalog := NewCatalog()
+	require.NoError(t, catalog.Exec(customerTable+ordersTable))
+	require.Equal(t, []string{"Customer", "Orders"}, catalog.Tables())
+
+	// Dropping the referencing table along with the referenced table is allowed
+	require.NoError(t, catalog.Exec("DROP TABLE `Orders`, `Customer`;"))
+	require.Empty(t, catalog.Tables())
+	require.NoError(t, catalog.Exec("DROP TABLE IF EXISTS `Orders`;"))
+
+	// The constraint names are released with their table
+	require.NoError(t, catalog.Exec(customerTable+ordersTable))
+}
+
+func TestSupportedCharset(t *testing.T) {
+	require.True(t, SupportedCharset("utf8mb4"))
+	require.True(t, SupportedCharset("CP1250"), "character sets TiDB doesn't support")
+	require.False(t, SupportedCharset("unsupported_charset"))
+	require.False(t, SupportedCharset("utf16be"), "SQLite only")
+}
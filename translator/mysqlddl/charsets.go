@@ -0,0 +1,77 @@
+package mysqlddl
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pingcap/tidb/pkg/parser/charset"
+)
+
+// Character set of the tables without a DEFAULT CHARSET, the default of MySQL 8
+const defaultCharset = "utf8mb4"
+
+// Character sets supported by MySQL 8
+var mysqlCharsets = []string{
+	"armscii8", "ascii", "big5", "binary", "cp1250", "cp1251", "cp1256", "cp1257", "cp850", "cp852", "cp866",
+	"cp932", "dec8", "eucjpms", "euckr", "gb18030", "gb2312", "gbk", "geostd8", "greek", "hebrew", "hp8",
+	"keybcs2", "koi8r", "koi8u", "latin1", "latin2", "latin5", "latin7", "macce", "macroman", "sjis", "swe7",
+	"tis620", "ucs2", "ujis", "utf16", "utf16le", "utf32", "utf8", "utf8mb3", "utf8mb4",
+}
+
+// SupportedCharset reports whether MySQL supports the character set
+func SupportedCharset(name string) bool {
+	name = strings.ToLower(name)
+	for _, cs := range mysqlCharsets {
+		if cs == name {
+			return true
+		}
+	}
+	return false
+}
+
+var registerCharsetsOnce sync.Once
+
+// registerCharsets registers the MySQL character sets the TiDB parser knows but rejects as unknown because
+// TiDB doesn't support them. The parser only accepts the character sets registered as supported, so
+// statements are then rejected for the character sets MySQL rejects.
+func registerCharsets() {
+	registerCharsetsOnce.Do(func() {
+		for _, name := range mysqlCharsets {
+			if cs, err := charset.GetCharsetInfo(name); err != nil && cs != nil {
+				charset.AddCharset(cs)
+			}
+		}
+	})
+}
+
+// maxBytesPerCharacter returns the maximum length in bytes of a character of the character set
+func maxBytesPerCharacter(name string) int {
+	cs, err := charset.GetCharsetInfo(name)
+	if err != nil || cs == nil {
+		return 4
+	}
+	return cs.Maxlen
+}
+
+// collationCharset returns the character set of a collation, or "" for unknown collations
+func collationCharset(collation string) string {
+	info, err := charset.GetCollationByName(collation)
+	if err != nil {
+		return ""
+	}
+	return info.CharsetName
+}
+
+// validCollation reports whether the collation belongs to the character set
+func validCollation(cs, collation string) bool {
+	if strings.EqualFold(cs, "binary") || strings.EqualFold(collation, "binary") {
+		return strings.EqualFold(cs, collation)
+	}
+	return charset.ValidCharsetAndCollation(strings.ToLower(cs), collation)
+}
+
+// Storage engines of MySQL 8, lowercased
+var storageEngines = map[string]bool{
+	"innodb": true, "myisam": true, "memory": true, "csv": true, "archive": true, "blackhole": true,
+	"mrg_myisam": true, "federated": true, "performance_schema": true, "ndbcluster": true,
+}
@@ -0,0 +1,127 @@
+package mysqlddl
+
+import (
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Error numbers of the statements rejected by the catalog, the numbers a MySQL 8 server returns
+const (
+	ErrTableExists                 uint16 = 1050
+	ErrBadTable                    uint16 = 1051
+	ErrBadField                    uint16 = 1054
+	ErrTooLongIdent                uint16 = 1059
+	ErrDupFieldName                uint16 = 1060
+	ErrDupKeyName                  uint16 = 1061
+	ErrWrongFieldSpec              uint16 = 1063
+	ErrParse                       uint16 = 1064
+	ErrInvalidDefault              uint16 = 1067
+	ErrMultiplePriKey              uint16 = 1068
+	ErrTooLongKey                  uint16 = 1071
+	ErrKeyColumnDoesNotExist       uint16 = 1072
+	ErrTooBigFieldLength           uint16 = 1074
+	ErrWrongAutoKey                uint16 = 1075
+	ErrWrongSubKey                 uint16 = 1089
+	ErrBlobCantHaveDefault         uint16 = 1101
+	ErrUnknownCharacterSet         uint16 = 1115
+	ErrTooBigRowSize               uint16 = 1118
+	ErrNoSuchTable                 uint16 = 1146
+	ErrBlobKeyWithoutLength        uint16 = 1170
+	ErrPrimaryCantHaveNull         uint16 = 1171
+	ErrCannotAddForeignKey         uint16 = 1215
+	ErrNotSupportedYet             uint16 = 1235
+	ErrWrongForeignKeyDef          uint16 = 1239
+	ErrCollationCharsetMismatch    uint16 = 1253
+	ErrUnknownCollation            uint16 = 1273
+	ErrBadFulltextColumn           uint16 = 1283
+	ErrUnknownStorageEngine        uint16 = 1286
+	ErrInvalidOnUpdate             uint16 = 1294
+	ErrTooBigScale                 uint16 = 1425
+	ErrTooBigPrecision             uint16 = 1426
+	ErrMBiggerThanD                uint16 = 1427
+	ErrTooLongTableComment         uint16 = 1628
+	ErrTooLongFieldComment         uint16 = 1629
+	ErrForeignKeyNoIndexInParent   uint16 = 1822
+	ErrForeignKeyCannotOpenParent  uint16 = 1824
+	ErrForeignKeyDupName           uint16 = 1826
+	ErrForeignKeyColumnNotNull     uint16 = 1830
+	ErrGeneratedColumnFunction     uint16 = 3102
+	ErrJSONUsedAsKey               uint16 = 3152
+	ErrForeignKeyCannotDropParent  uint16 = 3730
+	ErrForeignKeyNoColumnInParent  uint16 = 3734
+	ErrForeignKeyIncompatible      uint16 = 3780
+	ErrCheckFunctionDisallowed     uint16 = 3814
+	ErrCheckAutoIncrementColumn    uint16 = 3818
+	ErrCheckUnknownColumn          uint16 = 3820
+	ErrCheckDupName                uint16 = 3822
+	ErrCheckForeignKeyActionColumn uint16 = 3823
+)
+
+type errorMessage struct {
+	sqlState string
+	format   string
+}
+
+// Messages of the error numbers, as formatted by MySQL 8
+var errorMessages = map[uint16]errorMessage{
+	ErrTableExists:                 {"42S01", "Table '%s' already exists"},
+	ErrBadTable:                    {"42S02", "Unknown table '%s'"},
+	ErrBadField:                    {"42S22", "Unknown column '%s' in '%s'"},
+	ErrTooLongIdent:                {"42000", "Identifier name '%s' is too long"},
+	ErrDupFieldName:                {"42S21", "Duplicate column name '%s'"},
+	ErrDupKeyName:                  {"42000", "Duplicate key name '%s'"},
+	ErrWrongFieldSpec:              {"42000", "Incorrect column specifier for column '%s'"},
+	ErrParse:                       {"42000", "You have an error in your SQL syntax; check the manual that corresponds to your MySQL server version for the right syntax to use near '%.80s' at line %d"},
+	ErrInvalidDefault:              {"42000", "Invalid default value for '%s'"},
+	ErrMultiplePriKey:              {"42000", "Multiple primary key defined"},
+	ErrTooLongKey:                  {"42000", "Specified key was too long; max key length is %d bytes"},
+	ErrKeyColumnDoesNotExist:       {"42000", "Key column '%s' doesn't exist in table"},
+	ErrTooBigFieldLength:           {"42000", "Column length too big for column '%s' (max = %d); use BLOB or TEXT instead"},
+	ErrWrongAutoKey:                {"42000", "Incorrect table definition; there can be only one auto column and it must be defined as a key"},
+	ErrWrongSubKey:                 {"HY000", "Incorrect prefix key; the used key part isn't a string, the used length is longer than the key part, or the storage engine doesn't support unique prefix keys"},
+	ErrBlobCantHaveDefault:         {"42000", "BLOB, TEXT, GEOMETRY or JSON column '%s' can't have a default value"},
+	ErrUnknownCharacterSet:         {"42000", "Unknown character set: '%s'"},
+	ErrTooBigRowSize:               {"42000", "Row size too large. The maximum row size for the used table type, not counting BLOBs, is %d. This includes storage overhead, check the manual. You have to change some columns to TEXT or BLOBs"},
+	ErrNoSuchTable:                 {"42S02", "Table '%s' doesn't exist"},
+	ErrBlobKeyWithoutLength:        {"42000", "BLOB/TEXT column '%s' used in key specification without a key length"},
+	ErrPrimaryCantHaveNull:         {"42000", "All parts of a PRIMARY KEY must be NOT NULL; if you need NULL in a key, use UNIQUE instead"},
+	ErrCannotAddForeignKey:         {"HY000", "Cannot add foreign key constraint"},
+	ErrNotSupportedYet:             {"42000", "The offline catalog doesn't support '%s'"},
+	ErrWrongForeignKeyDef:          {"42000", "Incorrect foreign key definition for '%s': %s"},
+	ErrCollationCharsetMismatch:    {"42000", "COLLATION '%s' is not valid for CHARACTER SET '%s'"},
+	ErrUnknownCollation:            {"HY000", "Unknown collation: '%s'"},
+	ErrBadFulltextColumn:           {"HY000", "Column '%s' cannot be part of FULLTEXT index"},
+	ErrUnknownStorageEngine:        {"42000", "Unknown storage engine '%s'"},
+	ErrInvalidOnUpdate:             {"HY000", "Invalid ON UPDATE clause for '%s' column"},
+	ErrTooBigScale:                 {"42000", "Too big scale %d specified for column '%s'. Maximum is %d."},
+	ErrTooBigPrecision:             {"42000", "Too-big precision %d specified for '%s'. Maximum is %d."},
+	ErrMBiggerThanD:                {"42000", "For float(M,D), double(M,D) or decimal(M,D), M must be >= D (column '%s')."},
+	ErrTooLongTableComment:         {"HY000", "Comment for table '%s' is too long (max = %d)"},
+	ErrTooLongFieldComment:         {"HY000", "Comment for field '%s' is too long (max = %d)"},
+	ErrForeignKeyNoIndexInParent:   {"HY000", "Failed to add the foreign key constraint. Missing index for constraint '%s' in the referenced table '%s'"},
+	ErrForeignKeyCannotOpenParent:  {"HY000", "Failed to open the referenced table '%s'"},
+	ErrForeignKeyDupName:           {"HY000", "Duplicate foreign key constraint name '%s'"},
+	ErrForeignKeyColumnNotNull:     {"HY000", "Column '%s' cannot be NOT NULL: needed in a foreign key constraint '%s' SET NULL"},
+	ErrGeneratedColumnFunction:     {"HY000", "Expression of generated column '%s' contains a disallowed function."},
+	ErrJSONUsedAsKey:               {"42000", "JSON column '%s' supports indexing only via generated columns on a specified JSON path."},
+	ErrForeignKeyCannotDropParent:  {"HY000", "Cannot drop table '%s' referenced by a foreign key constraint '%s' on table '%s'."},
+	ErrForeignKeyNoColumnInParent:  {"HY000", "Failed to add the foreign key constraint. Missing column '%s' for constraint '%s' in the referenced table '%s'"},
+	ErrForeignKeyIncompatible:      {"HY000", "Referencing column '%s' and referenced column '%s' in foreign key constraint '%s' are incompatible."},
+	ErrCheckFunctionDisallowed:     {"HY000", "An expression of a check constraint '%s' contains disallowed function: %s."},
+	ErrCheckAutoIncrementColumn:    {"HY000", "Check constraint '%s' cannot refer to an auto-increment column."},
+	ErrCheckUnknownColumn:          {"HY000", "Check constraint '%s' refers to non-existing column '%s'."},
+	ErrCheckDupName:                {"HY000", "Duplicate check constraint name '%s'."},
+	ErrCheckForeignKeyActionColumn: {"HY000", "Column '%s' cannot be used in a check constraint '%s': needed in a foreign key constraint '%s' referential action."},
+}
+
+// newError builds the error a MySQL server returns for the error number, the way the MySQL driver reports it
+func newError(number uint16, args ...interface{}) error {
+	message, ok := errorMessages[number]
+	if !ok {
+		message = errorMessage{"HY000", "%v"}
+	}
+	err := &mysql.MySQLError{Number: number, Message: fmt.Sprintf(message.format, args...)}
+	copy(err.SQLState[:], message.sqlState)
+	return err
+}
@@ -0,0 +1,498 @@
+package mysqlddl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	parsermysql "github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/parser/types"
+)
+
+// Functions returning a different value on each call, not allowed in CHECK constraints and generated columns
+var nondeterministicFunctions = map[string]bool{
+	"connection_id": true, "current_user": true, "curdate": true, "current_date": true, "current_time": true,
+	"current_timestamp": true, "curtime": true, "database": true, "found_rows": true, "get_lock": true,
+	"last_insert_id": true, "localtime": true, "localtimestamp": true, "now": true, "rand": true,
+	"random_bytes": true, "release_lock": true, "row_count": true, "schema": true, "session_user": true,
+	"sleep": true, "sysdate": true, "system_user": true, "unix_timestamp": true, "user": true, "utc_date": true,
+	"utc_time": true, "utc_timestamp": true, "uuid": true, "uuid_short": true, "version": true,
+}
+
+// Functions allowed without parentheses as the default value and ON UPDATE value of DATETIME and TIMESTAMP columns
+var timestampFunctions = map[string]bool{
+	"current_timestamp": true, "now": true, "localtime": true, "localtimestamp": true,
+}
+
+// refs are the columns and functions used by an expression
+type refs struct {
+	columns   []string
+	functions []string
+}
+
+func (r *refs) Enter(n ast.Node) (ast.Node, bool) {
+	switch n := n.(type) {
+	case *ast.ColumnNameExpr:
+		r.columns = append(r.columns, n.Name.Name.O)
+	case *ast.FuncCallExpr:
+		r.functions = append(r.functions, n.FnName.L)
+	}
+	return n, false
+}
+
+func (r *refs) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+func expressionRefs(expr ast.ExprNode) refs {
+	var r refs
+	if expr != nil {
+		expr.Accept(&r)
+	}
+	return r
+}
+
+// literal returns the text of a literal value and whether it's a quoted string
+func literal(expr ast.ExprNode) (text string, quoted bool, ok bool) {
+	switch expr := expr.(type) {
+	case ast.ValueExpr:
+		switch value := expr.GetValue().(type) {
+		case nil:
+			return "", false, false
+		case string:
+			return value, true, true
+		default:
+			return fmt.Sprint(value), false, true
+		}
+	case *ast.UnaryOperationExpr:
+		if expr.Op != opcode.Minus && expr.Op != opcode.Plus {
+			return "", false, false
+		}
+		text, quoted, ok := literal(expr.V)
+		if !ok || quoted {
+			return "", false, false
+		}
+		if expr.Op == opcode.Minus {
+			text = "-" + text
+		}
+		return text, false, true
+	}
+	return "", false, false
+}
+
+func isNullLiteral(expr ast.ExprNode) bool {
+	value, ok := expr.(ast.ValueExpr)
+	return ok && value.GetValue() == nil
+}
+
+// parenthesized reports whether the expression is enclosed in parentheses in the query. The parser drops
+// the parentheses, which MySQL requires around expression default values. The position of the expression
+// is either the opening parenthesis or the first token of the expression.
+func parenthesized(expr ast.ExprNode, query string) bool {
+	position := expr.OriginTextPosition()
+	if position <= 0 || position >= len(query) {
+		return false
+	}
+	if query[position] == '(' {
+		return true
+	}
+	before := strings.TrimRight(query[:position], " \t\r\n")
+	return strings.HasSuffix(before, "(")
+}
+
+// syntaxError returns the error MySQL reports for an unexpected token at the position of the query
+func syntaxError(query string, position int) error {
+	if position < 0 || position > len(query) {
+		position = 0
+	}
+	return newError(ErrParse, query[position:], 1+strings.Count(query[:position], "\n"))
+}
+
+// checkDefault checks the default value of a column is allowed for its type
+func checkDefault(col *column, expr ast.ExprNode, query string) error {
+	// Expression defaults, allowed on every type since MySQL 8.0.13
+	if parenthesized(expr, query) {
+		return nil
+	}
+	if isNullLiteral(expr) {
+		if col.notNull {
+			return newError(ErrInvalidDefault, col.name)
+		}
+		return nil
+	}
+	if col.autoIncrement || col.generated {
+		return newError(ErrInvalidDefault, col.name)
+	}
+	if function, ok := expr.(*ast.FuncCallExpr); ok && timestampFunctions[function.FnName.L] {
+		if !isTimestampType(col.tp) || functionPrecision(function) != fractionalSeconds(col.tp) {
+			return newError(ErrInvalidDefault, col.name)
+		}
+		return nil
+	}
+	text, quoted, ok := literal(expr)
+	if !ok {
+		return syntaxError(query, expr.OriginTextPosition())
+	}
+
+	tp := col.tp
+	switch {
+	case isBlobType(tp), tp.GetType() == parsermysql.TypeJSON, tp.GetType() == parsermysql.TypeGeometry:
+		return newError(ErrBlobCantHaveDefault, col.name)
+	case isIntegerType(tp), isFloatType(tp), tp.GetType() == parsermysql.TypeNewDecimal, tp.GetType() == parsermysql.TypeYear:
+		if _, err := strconv.ParseFloat(strings.TrimSpace(text), 64); quoted && err != nil {
+			return newError(ErrInvalidDefault, col.name)
+		}
+	case isTemporalType(tp):
+		if !quoted || !validTemporal(tp, text) {
+			return newError(ErrInvalidDefault, col.name)
+		}
+	case tp.GetType() == parsermysql.TypeEnum:
+		if !containsFold(tp.GetElems(), text) {
+			return newError(ErrInvalidDefault, col.name)
+		}
+	case tp.GetType() == parsermysql.TypeSet:
+		for _, element := range strings.Split(text, ",") {
+			if text != "" && !containsFold(tp.GetElems(), element) {
+				return newError(ErrInvalidDefault, col.name)
+			}
+		}
+	case isStringType(tp):
+		if utf8.RuneCountInString(text) > stringLength(tp) {
+			return newError(ErrInvalidDefault, col.name)
+		}
+	}
+	return nil
+}
+
+// functionPrecision returns the fractional seconds precision requested from CURRENT_TIMESTAMP(fsp)
+func functionPrecision(function *ast.FuncCallExpr) int {
+	if len(function.Args) == 0 {
+		return 0
+	}
+	text, _, _ := literal(function.Args[0])
+	precision, _ := strconv.Atoi(text)
+	return precision
+}
+
+func fractionalSeconds(tp *types.FieldType) int {
+	return max(tp.GetDecimal(), 0)
+}
+
+func validTemporal(tp *types.FieldType, text string) bool {
+	layouts := []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+	switch tp.GetType() {
+	case parsermysql.TypeDate:
+		layouts = []string{"2006-01-02"}
+	case parsermysql.TypeDuration:
+		layouts = []string{"15:04:05", "15:04"}
+	}
+	for _, layout := range layouts {
+		if _, err := time.Parse(layout, text); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Catalog) addCheck(t *table, def checkDef, number int) error {
+	name := def.name
+	if name == "" {
+		name = fmt.Sprintf("%s_chk_%d", t.name, number)
+	}
+	if err := checkIdentifier(name); err != nil {
+		return err
+	}
+	if _, exists := c.checks[strings.ToLower(name)]; exists {
+		return newError(ErrCheckDupName, name)
+	}
+	for _, other := range t.checks {
+		if strings.EqualFold(other.name, name) {
+			return newError(ErrCheckDupName, name)
+		}
+	}
+
+	r := expressionRefs(def.expr)
+	for _, function := range r.functions {
+		if nondeterministicFunctions[function] {
+			return newError(ErrCheckFunctionDisallowed, name, function)
+		}
+	}
+	added := &check{name: name}
+	for _, columnName := range r.columns {
+		col := t.column(columnName)
+		if col == nil {
+			return newError(ErrCheckUnknownColumn, name, columnName)
+		}
+		if col.autoIncrement {
+			return newError(ErrCheckAutoIncrementColumn, name)
+		}
+		added.columns = append(added.columns, strings.ToLower(col.name))
+	}
+	t.checks = append(t.checks, added)
+	return nil
+}
+
+func (c *Catalog) addForeignKey(t *table, constraint *ast.Constraint, number int) error {
+	name := constraint.Name
+	if name == "" {
+		name = fmt.Sprintf("%s_ibfk_%d", t.name, number)
+	}
+	if err := checkIdentifier(name); err != nil {
+		return err
+	}
+	if _, exists := c.foreignKeys[strings.ToLower(name)]; exists {
+		return newError(ErrForeignKeyDupName, name)
+	}
+	for _, other := range t.foreignKeys {
+		if strings.EqualFold(other.name, name) {
+			return newError(ErrForeignKeyDupName, name)
+		}
+	}
+
+	refer := constraint.Refer
+	if len(constraint.Keys) != len(refer.IndexPartSpecifications) {
+		return newError(ErrWrongForeignKeyDef, name, "Key reference and table reference don't match")
+	}
+	fk := &foreignKey{name: name, refTable: refer.Table.Name.O}
+	if refer.OnDelete != nil {
+		fk.onDelete = refer.OnDelete.ReferOpt
+	}
+	if refer.OnUpdate != nil {
+		fk.onUpdate = refer.OnUpdate.ReferOpt
+	}
+	var columns []*column
+	for _, part := range constraint.Keys {
+		col := t.column(part.Column.Name.O)
+		if col == nil {
+			return newError(ErrKeyColumnDoesNotExist, part.Column.Name.O)
+		}
+		columns = append(columns, col)
+		fk.columns = append(fk.columns, col.name)
+	}
+
+	// Tables may reference themselves, the table being created isn't in the catalog yet
+	parent := c.tables[fk.refTable]
+	if fk.refTable == t.name {
+		parent = t
+	}
+	if parent == nil {
+		return newError(ErrForeignKeyCannotOpenParent, fk.refTable)
+	}
+	for i, part := range refer.IndexPartSpecifications {
+		refColumn := parent.column(part.Column.Name.O)
+		if refColumn == nil {
+			return newError(ErrForeignKeyNoColumnInParent, part.Column.Name.O, name, fk.refTable)
+		}
+		if !compatibleColumns(columns[i], refColumn) {
+			return newError(ErrForeignKeyIncompatible, columns[i].name, refColumn.name, name)
+		}
+		fk.refColumns = append(fk.refColumns, refColumn.name)
+	}
+
+	for _, action := range []ast.ReferOptionType{fk.onDelete, fk.onUpdate} {
+		if action == ast.ReferOptionSetDefault {
+			// Parsed by MySQL, but rejected by InnoDB
+			return newError(ErrCannotAddForeignKey)
+		}
+		for _, col := range columns {
+			if action == ast.ReferOptionSetNull && col.notNull {
+				return newError(ErrForeignKeyColumnNotNull, col.name, name)
+			}
+			if action != ast.ReferOptionCascade && action != ast.ReferOptionSetNull {
+				continue
+			}
+			for _, check := range t.checks {
+				for _, checkColumn := range check.columns {
+					if checkColumn == strings.ToLower(col.name) {
+						return newError(ErrCheckForeignKeyActionColumn, col.name, check.name, name)
+					}
+				}
+			}
+		}
+	}
+	if !parent.hasIndexPrefix(fk.refColumns) {
+		return newError(ErrForeignKeyNoIndexInParent, name, fk.refTable)
+	}
+
+	// InnoDB indexes the referencing columns when no index starts with them
+	if !t.hasIndexPrefix(fk.columns) {
+		indexName := constraint.Name
+		if indexName == "" || t.index(indexName) != nil {
+			indexName = t.defaultIndexName(constraint.Keys)
+		}
+		idx := &index{name: indexName}
+		for _, col := range fk.columns {
+			idx.columns = append(idx.columns, strings.ToLower(col))
+		}
+		t.indexes = append(t.indexes, idx)
+	}
+	t.foreignKeys = append(t.foreignKeys, fk)
+	return nil
+}
+
+// compatibleColumns reports whether a column can reference another one. Integer and decimal types need
+// the same size and sign, strings of any length need the same character set.
+func compatibleColumns(col, refColumn *column) bool {
+	tp, refTp := col.tp, refColumn.tp
+	switch {
+	case isStringType(tp) && isStringType(refTp):
+		return strings.EqualFold(col.charset, refColumn.charset)
+	case tp.GetType() != refTp.GetType():
+		return false
+	case tp.GetType() == parsermysql.TypeNewDecimal:
+		precision, scale := decimalPrecision(tp)
+		refPrecision, refScale := decimalPrecision(refTp)
+		if precision != refPrecision || scale != refScale {
+			return false
+		}
+	case isTemporalType(tp):
+		return fractionalSeconds(tp) == fractionalSeconds(refTp)
+	}
+	return parsermysql.HasUnsignedFlag(tp.GetFlag()) == parsermysql.HasUnsignedFlag(refTp.GetFlag())
+}
+
+func isIntegerType(tp *types.FieldType) bool {
+	switch tp.GetType() {
+	case parsermysql.TypeTiny, parsermysql.TypeShort, parsermysql.TypeInt24, parsermysql.TypeLong, parsermysql.TypeLonglong:
+		return true
+	}
+	return false
+}
+
+func isFloatType(tp *types.FieldType) bool {
+	return tp.GetType() == parsermysql.TypeFloat || tp.GetType() == parsermysql.TypeDouble
+}
+
+// isTimestampType reports whether the column accepts CURRENT_TIMESTAMP defaults and ON UPDATE clauses
+func isTimestampType(tp *types.FieldType) bool {
+	return tp.GetType() == parsermysql.TypeDatetime || tp.GetType() == parsermysql.TypeTimestamp
+}
+
+func isTemporalType(tp *types.FieldType) bool {
+	return isTimestampType(tp) || tp.GetType() == parsermysql.TypeDate || tp.GetType() == parsermysql.TypeDuration
+}
+
+// isStringType reports whether the column is a CHAR, VARCHAR, BINARY or VARBINARY column
+func isStringType(tp *types.FieldType) bool {
+	switch tp.GetType() {
+	case parsermysql.TypeString, parsermysql.TypeVarchar, parsermysql.TypeVarString:
+		return true
+	}
+	return false
+}
+
+// isBlobType reports whether the column is a BLOB or TEXT column, stored off the row
+func isBlobType(tp *types.FieldType) bool {
+	switch tp.GetType() {
+	case parsermysql.TypeTinyBlob, parsermysql.TypeBlob, parsermysql.TypeMediumBlob, parsermysql.TypeLongBlob:
+		return true
+	}
+	return false
+}
+
+// isTextType reports whether the column holds characters, the columns FULLTEXT indexes accept
+func isTextType(col *column) bool {
+	return (isStringType(col.tp) || isBlobType(col.tp)) && col.charset != "binary"
+}
+
+func isVariableLengthType(tp *types.FieldType) bool {
+	return tp.GetType() == parsermysql.TypeVarchar || tp.GetType() == parsermysql.TypeVarString
+}
+
+func stringLength(tp *types.FieldType) int {
+	if tp.GetFlen() == types.UnspecifiedLength {
+		return 1
+	}
+	return tp.GetFlen()
+}
+
+func decimalPrecision(tp *types.FieldType) (precision, scale int) {
+	precision, scale = tp.GetFlen(), tp.GetDecimal()
+	if precision == types.UnspecifiedLength {
+		precision = 10
+	}
+	if scale == types.UnspecifiedLength {
+		scale = 0
+	}
+	return precision, scale
+}
+
+// Bytes used to store the leftover digits of a DECIMAL, groups of 9 digits take 4 bytes
+var decimalDigitBytes = [9]int{0, 1, 1, 2, 2, 3, 3, 4, 4}
+
+func decimalBytes(digits int) int {
+	return digits/9*4 + decimalDigitBytes[digits%9]
+}
+
+// columnBytes returns the maximum size of a column value stored in the row or in a key
+func columnBytes(col *column) int {
+	tp := col.tp
+	fsp := (fractionalSeconds(tp) + 1) / 2
+	switch tp.GetType() {
+	case parsermysql.TypeTiny, parsermysql.TypeYear:
+		return 1
+	case parsermysql.TypeShort:
+		return 2
+	case parsermysql.TypeInt24, parsermysql.TypeDate:
+		return 3
+	case parsermysql.TypeLong, parsermysql.TypeFloat:
+		return 4
+	case parsermysql.TypeLonglong, parsermysql.TypeDouble:
+		return 8
+	case parsermysql.TypeNewDecimal:
+		precision, scale := decimalPrecision(tp)
+		return decimalBytes(precision-scale) + decimalBytes(scale)
+	case parsermysql.TypeDuration:
+		return 3 + fsp
+	case parsermysql.TypeDatetime:
+		return 5 + fsp
+	case parsermysql.TypeTimestamp:
+		return 4 + fsp
+	case parsermysql.TypeBit:
+		return (stringLength(tp) + 7) / 8
+	case parsermysql.TypeEnum:
+		if len(tp.GetElems()) > 255 {
+			return 2
+		}
+		return 1
+	case parsermysql.TypeSet:
+		if size := (len(tp.GetElems()) + 7) / 8; size <= 4 {
+			return size
+		}
+		return 8
+	case parsermysql.TypeString, parsermysql.TypeVarchar, parsermysql.TypeVarString:
+		return stringLength(tp) * maxBytesPerCharacter(col.charset)
+	}
+	// BLOB, TEXT, JSON and GEOMETRY values are stored off the row
+	return 0
+}
+
+// keyPartBytes returns the size of a key part, the prefix length is -1 when the whole column is indexed
+func keyPartBytes(col *column, prefix int) (int, error) {
+	if isBlobType(col.tp) {
+		if prefix <= 0 {
+			return 0, newError(ErrBlobKeyWithoutLength, col.name)
+		}
+		return prefix * maxBytesPerCharacter(col.charset), nil
+	}
+	if prefix > 0 {
+		if !isStringType(col.tp) || prefix > stringLength(col.tp) {
+			return 0, newError(ErrWrongSubKey)
+		}
+		return prefix * maxBytesPerCharacter(col.charset), nil
+	}
+	return columnBytes(col), nil
+}
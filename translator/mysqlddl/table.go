@@ -0,0 +1,397 @@
+package mysqlddl
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	parsermysql "github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/types"
+)
+
+// Limits of MySQL 8 with InnoDB
+const (
+	maxIdentifierLength   = 64
+	maxKeyBytes           = 3072
+	maxRowBytes           = 65535
+	maxCharLength         = 255
+	maxDecimalPrecision   = 65
+	maxDecimalScale       = 30
+	maxFractionalSeconds  = 6
+	maxTableCommentLength = 2048
+	maxFieldCommentLength = 1024
+)
+
+type table struct {
+	name        string
+	engine      string // Lowercased storage engine
+	charset     string // Default character set of the string columns
+	columns     []*column
+	indexes     []*index
+	checks      []*check
+	foreignKeys []*foreignKey
+}
+
+type column struct {
+	name          string
+	tp            *types.FieldType
+	charset       string // Character set of string columns, "binary" for binary strings
+	notNull       bool
+	explicitNull  bool
+	autoIncrement bool
+	generated     bool
+}
+
+type index struct {
+	name     string
+	columns  []string // Lowercased column names, "" for the expressions of functional key parts
+	primary  bool
+	unique   bool
+	fulltext bool
+}
+
+type check struct {
+	name    string
+	columns []string // Lowercased names of the columns of the expression
+}
+
+type foreignKey struct {
+	name       string
+	columns    []string
+	refTable   string
+	refColumns []string
+	onDelete   ast.ReferOptionType
+	onUpdate   ast.ReferOptionType
+}
+
+func newTable(name string) *table {
+	return &table{name: name, engine: "innodb", charset: defaultCharset}
+}
+
+func checkIdentifier(name string) error {
+	if utf8.RuneCountInString(name) > maxIdentifierLength {
+		return newError(ErrTooLongIdent, name)
+	}
+	return nil
+}
+
+// column returns the column, names of columns aren't case sensitive
+func (t *table) column(name string) *column {
+	for _, col := range t.columns {
+		if strings.EqualFold(col.name, name) {
+			return col
+		}
+	}
+	return nil
+}
+
+// index returns the index, names of indexes aren't case sensitive
+func (t *table) index(name string) *index {
+	for _, idx := range t.indexes {
+		if strings.EqualFold(idx.name, name) {
+			return idx
+		}
+	}
+	return nil
+}
+
+// hasIndexPrefix reports whether an index starts with the columns, in order
+func (t *table) hasIndexPrefix(columns []string) bool {
+	for _, idx := range t.indexes {
+		if idx.fulltext || len(idx.columns) < len(columns) {
+			continue
+		}
+		matches := true
+		for i, col := range columns {
+			if idx.columns[i] != strings.ToLower(col) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *table) applyOptions(options []*ast.TableOption) error {
+	var collation string
+	charsetSet := false
+	for _, option := range options {
+		switch option.Tp {
+		case ast.TableOptionEngine:
+			if option.StrValue != "" && !storageEngines[strings.ToLower(option.StrValue)] {
+				return newError(ErrUnknownStorageEngine, option.StrValue)
+			}
+			if option.StrValue != "" {
+				t.engine = strings.ToLower(option.StrValue)
+			}
+		case ast.TableOptionCharset:
+			t.charset = strings.ToLower(option.StrValue)
+			charsetSet = true
+		case ast.TableOptionCollate:
+			collation = option.StrValue
+		case ast.TableOptionComment:
+			if utf8.RuneCountInString(option.StrValue) > maxTableCommentLength {
+				return newError(ErrTooLongTableComment, t.name, maxTableCommentLength)
+			}
+		}
+	}
+	if collation != "" {
+		if !charsetSet {
+			t.charset = collationCharset(collation)
+		} else if !validCollation(t.charset, collation) {
+			return newError(ErrCollationCharsetMismatch, collation, t.charset)
+		}
+	}
+	return nil
+}
+
+func (t *table) addColumn(name string) error {
+	if err := checkIdentifier(name); err != nil {
+		return err
+	}
+	if t.column(name) != nil {
+		return newError(ErrDupFieldName, name)
+	}
+	t.columns = append(t.columns, &column{name: name})
+	return nil
+}
+
+// defineColumn checks the type and options of a column, the keys and checks declared on the column
+// are added to the definitions of the table
+func (t *table) defineColumn(def *ast.ColumnDef, query string, defs *definitions) error {
+	col := t.column(def.Name.Name.O)
+	col.tp = def.Tp
+	collation := def.Tp.GetCollate()
+	var defaultValue, onUpdate *ast.ColumnOption
+	for _, option := range def.Options {
+		switch option.Tp {
+		case ast.ColumnOptionPrimaryKey:
+			defs.keys = append(defs.keys, keyDef{primary: true, parts: []*ast.IndexPartSpecification{{Column: def.Name}}})
+		case ast.ColumnOptionUniqKey:
+			defs.keys = append(defs.keys, keyDef{name: col.name, unique: true, parts: []*ast.IndexPartSpecification{{Column: def.Name}}})
+		case ast.ColumnOptionNotNull:
+			col.notNull = true
+		case ast.ColumnOptionNull:
+			col.explicitNull = true
+		case ast.ColumnOptionAutoIncrement:
+			col.autoIncrement = true
+		case ast.ColumnOptionDefaultValue:
+			defaultValue = option
+		case ast.ColumnOptionOnUpdate:
+			onUpdate = option
+		case ast.ColumnOptionCollate:
+			collation = option.StrValue
+		case ast.ColumnOptionComment:
+			if comment, _, ok := literal(option.Expr); ok && utf8.RuneCountInString(comment) > maxFieldCommentLength {
+				return newError(ErrTooLongFieldComment, col.name, maxFieldCommentLength)
+			}
+		case ast.ColumnOptionGenerated:
+			col.generated = true
+			if err := t.checkGeneratedColumn(col, option.Expr); err != nil {
+				return err
+			}
+		case ast.ColumnOptionCheck:
+			defs.checks = append(defs.checks, checkDef{name: option.ConstraintName, expr: option.Expr})
+		}
+	}
+	if err := t.checkType(col, collation); err != nil {
+		return err
+	}
+	if col.autoIncrement && !isIntegerType(col.tp) && !isFloatType(col.tp) {
+		return newError(ErrWrongFieldSpec, col.name)
+	}
+	if defaultValue != nil {
+		if err := checkDefault(col, defaultValue.Expr, query); err != nil {
+			return err
+		}
+	}
+	if onUpdate != nil && !isTimestampType(col.tp) {
+		return newError(ErrInvalidOnUpdate, col.name)
+	}
+	return nil
+}
+
+// checkType checks the length, precision and character set of a column
+func (t *table) checkType(col *column, collation string) error {
+	tp := col.tp
+	if isStringType(tp) || isBlobType(tp) {
+		col.charset = strings.ToLower(tp.GetCharset())
+		if parsermysql.HasBinaryFlag(tp.GetFlag()) && col.charset == "" {
+			col.charset = charset.CharsetBin
+		}
+		if col.charset != "" && collation != "" && !validCollation(col.charset, collation) {
+			return newError(ErrCollationCharsetMismatch, collation, col.charset)
+		}
+		if col.charset == "" && collation != "" {
+			col.charset = collationCharset(collation)
+		}
+		if col.charset == "" {
+			col.charset = t.charset
+		}
+	}
+
+	switch tp.GetType() {
+	case parsermysql.TypeVarchar, parsermysql.TypeVarString:
+		maxLength := maxRowBytes / maxBytesPerCharacter(col.charset)
+		if tp.GetFlen() > maxLength {
+			return newError(ErrTooBigFieldLength, col.name, maxLength)
+		}
+	case parsermysql.TypeString:
+		if tp.GetFlen() > maxCharLength {
+			return newError(ErrTooBigFieldLength, col.name, maxCharLength)
+		}
+	case parsermysql.TypeNewDecimal:
+		precision, scale := decimalPrecision(tp)
+		if precision > maxDecimalPrecision {
+			return newError(ErrTooBigPrecision, precision, col.name, maxDecimalPrecision)
+		}
+		if scale > maxDecimalScale {
+			return newError(ErrTooBigScale, scale, col.name, maxDecimalScale)
+		}
+		if scale > precision {
+			return newError(ErrMBiggerThanD, col.name)
+		}
+	case parsermysql.TypeDatetime, parsermysql.TypeTimestamp, parsermysql.TypeDuration:
+		if tp.GetDecimal() > maxFractionalSeconds {
+			return newError(ErrTooBigPrecision, tp.GetDecimal(), col.name, maxFractionalSeconds)
+		}
+	}
+	return nil
+}
+
+// addIndex checks the key parts of an index and adds it to the table
+func (t *table) addIndex(key keyDef) error {
+	name := key.name
+	switch {
+	case key.primary:
+		for _, idx := range t.indexes {
+			if idx.primary {
+				return newError(ErrMultiplePriKey)
+			}
+		}
+		name = "PRIMARY"
+	case name == "":
+		name = t.defaultIndexName(key.parts)
+	default:
+		if err := checkIdentifier(name); err != nil {
+			return err
+		}
+		if t.index(name) != nil || strings.EqualFold(name, "PRIMARY") {
+			return newError(ErrDupKeyName, name)
+		}
+	}
+
+	idx := &index{name: name, primary: key.primary, unique: key.unique || key.primary, fulltext: key.fulltext}
+	keyBytes := 0
+	for _, part := range key.parts {
+		if part.Expr != nil {
+			for _, name := range expressionRefs(part.Expr).columns {
+				if t.column(name) == nil {
+					return newError(ErrBadField, name, "functional index")
+				}
+			}
+			idx.columns = append(idx.columns, "")
+			continue
+		}
+		col := t.column(part.Column.Name.O)
+		if col == nil {
+			return newError(ErrKeyColumnDoesNotExist, part.Column.Name.O)
+		}
+		idx.columns = append(idx.columns, strings.ToLower(col.name))
+		if key.fulltext {
+			if !isTextType(col) {
+				return newError(ErrBadFulltextColumn, col.name)
+			}
+			continue
+		}
+		if col.tp.GetType() == parsermysql.TypeJSON {
+			return newError(ErrJSONUsedAsKey, col.name)
+		}
+		partBytes, err := keyPartBytes(col, part.Length)
+		if err != nil {
+			return err
+		}
+		keyBytes += partBytes
+		if key.primary {
+			if col.explicitNull {
+				return newError(ErrPrimaryCantHaveNull)
+			}
+			col.notNull = true
+		}
+	}
+	if keyBytes > maxKeyBytes {
+		return newError(ErrTooLongKey, maxKeyBytes)
+	}
+	t.indexes = append(t.indexes, idx)
+	return nil
+}
+
+// defaultIndexName names an unnamed index after its first column, like MySQL does
+func (t *table) defaultIndexName(parts []*ast.IndexPartSpecification) string {
+	base := "functional_index"
+	if len(parts) > 0 && parts[0].Column != nil {
+		base = parts[0].Column.Name.O
+	}
+	name := base
+	for i := 2; t.index(name) != nil || strings.EqualFold(name, "PRIMARY"); i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	return name
+}
+
+// checkAutoIncrement checks a table has at most one AUTO_INCREMENT column, leading an index
+func (t *table) checkAutoIncrement() error {
+	var autoIncrement *column
+	for _, col := range t.columns {
+		if !col.autoIncrement {
+			continue
+		}
+		if autoIncrement != nil {
+			return newError(ErrWrongAutoKey)
+		}
+		autoIncrement = col
+	}
+	if autoIncrement != nil && !t.hasIndexPrefix([]string{autoIncrement.name}) {
+		return newError(ErrWrongAutoKey)
+	}
+	return nil
+}
+
+// checkRowSize checks the columns stored in the row fit in the maximum row size, BLOB and TEXT values
+// are stored off the row
+func (t *table) checkRowSize() error {
+	rowBytes := 0
+	for _, col := range t.columns {
+		rowBytes += columnBytes(col)
+		if isVariableLengthType(col.tp) {
+			rowBytes++
+			if columnBytes(col) > 255 {
+				rowBytes++
+			}
+		}
+	}
+	if rowBytes > maxRowBytes {
+		return newError(ErrTooBigRowSize, maxRowBytes)
+	}
+	return nil
+}
+
+func (t *table) checkGeneratedColumn(col *column, expr ast.ExprNode) error {
+	refs := expressionRefs(expr)
+	for _, name := range refs.columns {
+		if t.column(name) == nil {
+			return newError(ErrBadField, name, "generated column function")
+		}
+	}
+	for _, function := range refs.functions {
+		if nondeterministicFunctions[function] {
+			return newError(ErrGeneratedColumnFunction, col.name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package proto_db
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	dbExt "github.com/imran31415/proto-db-translator/annotations"
+	"github.com/imran31415/proto-db-translator/translator/internal/schemautil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var (
+	typeLengthPattern  = regexp.MustCompile(`\(.*\)`)
+	decimalTypePattern = regexp.MustCompile(`^DECIMAL\s*(?:\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\))?`)
+)
+
+// fieldReference returns the db_references annotation of a field, e.g. userauth.Customer.customer_id
+func fieldReference(field protoreflect.FieldDescriptor) string {
+	options, ok := field.Options().(*descriptorpb.FieldOptions)
+	if !ok || options == nil {
+		return ""
+	}
+	reference, _ := proto.GetExtension(options, dbExt.E_DbReferences).(string)
+	return reference
+}
+
+// findMessageDescriptor looks a message up by its full name in the registered descriptors
+func (t Translator) findMessageDescriptor(name string) (protoreflect.MessageDescriptor, error) {
+	for _, files := range []*protoregistry.Files{protoregistry.GlobalFiles, t.sourceFiles} {
+		if files == nil {
+			continue
+		}
+		descriptor, err := files.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			continue
+		}
+		md, ok := descriptor.(protoreflect.MessageDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("'%s' is not a message", name)
+		}
+		return md, nil
+	}
+	return nil, fmt.Errorf("unknown message '%s'", name)
+}
+
+// resolveFieldReference resolves a reference like userauth.Customer.customer_id to the table and column it names
+func (t Translator) resolveFieldReference(reference string, column ColumnSchema) (string, string, error) {
+	separator := strings.LastIndex(reference, ".")
+	if separator <= 0 || separator == len(reference)-1 {
+		return "", "", fmt.Errorf("invalid reference '%s', expected <message>.<field>", reference)
+	}
+	table, columns, err := t.resolveReferences(reference[:separator], []string{reference[separator+1:]}, []ColumnSchema{column})
+	if err != nil {
+		return "", "", err
+	}
+	return table, columns[0], nil
+}
+
+// resolveReferences resolves fields of the referenced message to its table and columns. The fields must form
+// the primary key or a unique key of the table, and match the types of the referencing columns.
+func (t Translator) resolveReferences(messageName string, fieldNames []string, columns []ColumnSchema) (string, []string, error) {
+	md, err := t.findMessageDescriptor(messageName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var referencedColumns []string
+	for i, fieldName := range fieldNames {
+		field := md.Fields().ByName(protoreflect.Name(fieldName))
+		if field == nil {
+			return "", nil, fmt.Errorf("message '%s' has no field '%s'", md.FullName(), fieldName)
+		}
+		referenced, err := extractFieldSchema(field, t.dbConnection.DbType)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid referenced field '%s': %w", field.FullName(), err)
+		}
+		if !compatibleColumnTypes(columns[i], referenced) {
+			return "", nil, fmt.Errorf("column '%s' of type %s is incompatible with '%s' of type %s",
+				columns[i].Name, columns[i].Type, field.FullName(), referenced.Type)
+		}
+		referencedColumns = append(referencedColumns, referenced.Name)
+	}
+
+	keys, err := t.keyColumns(md)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, key := range keys {
+		if sameColumns(key, referencedColumns) {
+			return string(md.Name()), referencedColumns, nil
+		}
+	}
+	return "", nil, fmt.Errorf("referenced columns (%s) are not the primary key or a unique key of '%s'",
+		strings.Join(referencedColumns, ", "), md.FullName())
+}
+
+// keyColumns lists the column sets of a message uniquely identifying a row: its primary key,
+// unique columns, unique constraints and unique indexes
+func (t Translator) keyColumns(md protoreflect.MessageDescriptor) ([][]string, error) {
+	var keys [][]string
+	var primaryKey []string
+	for i := 0; i < md.Fields().Len(); i++ {
+		column, err := extractFieldSchema(md.Fields().Get(i), t.dbConnection.DbType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid referenced message '%s': %w", md.FullName(), err)
+		}
+		if column.IsPrimaryKey {
+			primaryKey = append(primaryKey, column.Name)
+		}
+		if contains(column.Constraints, "UNIQUE") {
+			keys = append(keys, []string{column.Name})
+		}
+	}
+	if len(primaryKey) > 0 {
+		keys = append(keys, primaryKey)
+	}
+	if compositePrimaryKey := parseCompositePrimaryKeys(md); compositePrimaryKey != "" {
+		keys = append(keys, splitColumns(compositePrimaryKey))
+	}
+	uniqueConstraints, _ := parseTableLevelConstraints(md)
+	for _, unique := range uniqueConstraints {
+		keys = append(keys, splitColumns(unique))
+	}
+	tableIndexes, err := parseTableIndexes(md)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range tableIndexes {
+		if !index.Unique || index.Where != "" {
+			continue
+		}
+		var names []string
+		for _, column := range index.Columns {
+			if column.Expression != "" {
+				names = nil
+				break
+			}
+			names = append(names, column.Name)
+		}
+		if len(names) > 0 {
+			keys = append(keys, names)
+		}
+	}
+	return keys, nil
+}
+
+// splitColumns splits a comma separated column list
+func splitColumns(columns string) []string {
+	return schemautil.SplitColumns(columns)
+}
+
+// sameColumns reports whether both lists hold the same columns, in any order
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// compatibleColumnTypes reports whether a foreign key column can reference a column of the other type.
+// The base type and signedness must match, DECIMAL columns need the same precision and scale and string columns
+// the same character set, lengths may differ.
+func compatibleColumnTypes(a, b ColumnSchema) bool {
+	normalize := func(col ColumnSchema) string {
+		columnType := strings.ToUpper(columnTypeSQL(col))
+		if match := decimalTypePattern.FindStringSubmatch(columnType); match != nil {
+			// A bare DECIMAL is DECIMAL(10,0)
+			precision, scale := cmp.Or(match[1], "10"), cmp.Or(match[2], "0")
+			columnType = fmt.Sprintf("DECIMAL(%s,%s) %s", precision, scale, columnType[len(match[0]):])
+		} else {
+			columnType = typeLengthPattern.ReplaceAllString(columnType, "")
+		}
+		columnType = strings.Join(strings.Fields(columnType), " ")
+		return strings.Replace(columnType, "INTEGER", "INT", 1)
+	}
+	if normalize(a) != normalize(b) {
+		return false
+	}
+	return !isStringType(a.Type) || strings.EqualFold(a.CharacterSet, b.CharacterSet)
+}
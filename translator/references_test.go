@@ -0,0 +1,105 @@
+package proto_db
+
+import (
+	"testing"
+
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveFieldReference(t *testing.T) {
+	intColumn := ColumnSchema{Name: "customer_id", Type: "INT"}
+	tests := []struct {
+		name           string
+		reference      string
+		column         ColumnSchema
+		expectedTable  string
+		expectedColumn string
+		expectedErr    string
+	}{
+		{name: "primary key", reference: "userauth.Customer.customer_id", column: intColumn, expectedTable: "Customer", expectedColumn: "customer_id"},
+		{name: "unique column", reference: "proto_db_translator.User.username", column: ColumnSchema{Name: "owner", Type: "VARCHAR(64)"}, expectedTable: "User", expectedColumn: "username"},
+		{
+			name:        "not a key",
+			reference:   "userauth.Customer.customer_name",
+			column:      ColumnSchema{Name: "customer_name", Type: "VARCHAR(255)"},
+			expectedErr: "referenced columns (customer_name) are not the primary key or a unique key of 'userauth.Customer'",
+		},
+		{
+			name:        "incompatible type",
+			reference:   "userauth.Customer.customer_id",
+			column:      ColumnSchema{Name: "customer_id", Type: "VARCHAR(255)"},
+			expectedErr: "column 'customer_id' of type VARCHAR(255) is incompatible with 'userauth.Customer.customer_id' of type INT",
+		},
+		{name: "unknown field", reference: "userauth.Customer.id", column: intColumn, expectedErr: "message 'userauth.Customer' has no field 'id'"},
+		{name: "unknown message", reference: "userauth.Client.customer_id", column: intColumn, expectedErr: "unknown message 'userauth.Client'"},
+		{name: "missing field", reference: "customer_id", column: intColumn, expectedErr: "invalid reference 'customer_id', expected <message>.<field>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, column, err := NewSqliteTranslator().resolveFieldReference(tt.reference, tt.column)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedTable, table)
+			require.Equal(t, tt.expectedColumn, column)
+		})
+	}
+}
+
+func TestResolveCompositeReferences(t *testing.T) {
+	columns := []ColumnSchema{{Name: "order_id", Type: "INT"}, {Name: "product_id", Type: "INT"}}
+	translator := NewSqliteTranslator()
+
+	table, referenced, err := translator.resolveReferences("userauth.OrderDetails", []string{"product_id", "order_id"}, columns)
+	require.NoError(t, err)
+	require.Equal(t, "OrderDetails", table)
+	require.Equal(t, []string{"product_id", "order_id"}, referenced)
+
+	_, _, err = translator.resolveReferences("userauth.OrderDetails", []string{"order_id"}, columns)
+	require.EqualError(t, err, "referenced columns (order_id) are not the primary key or a unique key of 'userauth.OrderDetails'")
+}
+
+func TestGenerateSchemaResolvesReferences(t *testing.T) {
+	schema, err := NewSqliteTranslator().GenerateSchema(&userauth.OrderItems{})
+	require.NoError(t, err)
+	orderID, ok := findColumnInSchema("order_id", schema.Columns)
+	require.True(t, ok)
+	require.Equal(t, "Orders", orderID.ForeignKeyTable)
+	require.Equal(t, "order_id", orderID.ForeignKeyColumn)
+	require.Equal(t, "CASCADE", orderID.OnDelete)
+
+	schema, err = NewSqliteTranslator().GenerateSchema(&userauth.OrderDetailShipments{})
+	require.NoError(t, err)
+	require.Equal(t, "OrderDetails", schema.ForeignKeys[0].ReferencesTable)
+	require.Equal(t, []string{"order_id", "product_id"}, schema.ForeignKeys[0].ReferencesColumns)
+}
+
+func TestCompatibleColumnTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     ColumnSchema
+		expected bool
+	}{
+		{"Same Type", ColumnSchema{Type: "INT"}, ColumnSchema{Type: "INT"}, true},
+		{"Integer Alias", ColumnSchema{Type: "INTEGER"}, ColumnSchema{Type: "INT"}, true},
+		{"String Lengths", ColumnSchema{Type: "VARCHAR(64)"}, ColumnSchema{Type: "VARCHAR(255)"}, true},
+		{"Decimal Precision", ColumnSchema{Type: "DECIMAL(10,2)"}, ColumnSchema{Type: "decimal(12, 4)"}, false},
+		{"Decimal Scale", ColumnSchema{Type: "DECIMAL(10,2)"}, ColumnSchema{Type: "DECIMAL(10,4)"}, false},
+		{"Same Decimal", ColumnSchema{Type: "DECIMAL(10,2)"}, ColumnSchema{Type: "decimal(10, 2)"}, true},
+		{"Decimal Default", ColumnSchema{Type: "DECIMAL"}, ColumnSchema{Type: "DECIMAL(10)"}, true},
+		{"Decimal Columns", ColumnSchema{Type: "DECIMAL", Precision: 12, Scale: 4}, ColumnSchema{Type: "DECIMAL(12,4)"}, true},
+		{"Character Sets", ColumnSchema{Type: "VARCHAR(36)", CharacterSet: "latin1"}, ColumnSchema{Type: "VARCHAR(36)", CharacterSet: "utf8mb4"}, false},
+		{"Same Character Set", ColumnSchema{Type: "CHAR(36)", CharacterSet: "utf8mb4"}, ColumnSchema{Type: "CHAR(36)", CharacterSet: "UTF8MB4"}, true},
+		{"Signedness", ColumnSchema{Type: "INT UNSIGNED"}, ColumnSchema{Type: "INT"}, false},
+		{"Integer Sizes", ColumnSchema{Type: "BIGINT"}, ColumnSchema{Type: "INT"}, false},
+		{"String Types", ColumnSchema{Type: "CHAR(36)"}, ColumnSchema{Type: "VARCHAR(36)"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, compatibleColumnTypes(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package proto_db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrNotFound is returned by Repository.Get and Repository.Delete when no row has the given primary key
+var ErrNotFound = errors.New("row not found")
+
+// Repository reads and writes the rows of the table of an annotated message, scanning rows directly into
+// messages of type T. It works on MySQL and SQLite without generated code, the MySQL connection must be opened
+// with parseTime=true for timestamp columns.
+type Repository[T proto.Message] struct {
+	translator Translator
+	database   *sql.DB
+	schema     Schema
+	primaryKey []string
+}
+
+// Filter restricts the rows returned by Repository.List to those where the column compares to the value.
+// Operator is one of =, !=, <>, <, <=, >, >=, LIKE, IN (with a []interface{} value), IS NULL and IS NOT NULL.
+type Filter struct {
+	Column   string
+	Operator string
+	Value    interface{}
+}
+
+// ListOptions selects a page of rows. Rows are ordered by the OrderBy columns followed by the primary key,
+// After continues after the row with those values, as returned in Page.Next.
+type ListOptions struct {
+	Filters    []Filter
+	OrderBy    []string
+	Descending bool
+	Limit      int
+	After      []interface{}
+}
+
+// Page is a page of rows returned by Repository.List. Next holds the values of the ordering columns of the last
+// row when the page is full, nil on the last page.
+type Page[T proto.Message] struct {
+	Messages []T
+	Next     []interface{}
+}
+
+var filterOperators = map[string]bool{
+	"=": true, "!=": true, "<>": true, "<": true, "<=": true, ">": true, ">=": true,
+	"LIKE": true, "IN": true, "IS NULL": true, "IS NOT NULL": true,
+}
+
+// NewRepository creates the repository of the table of the message type T, e.g.
+// NewRepository[*userauth.Role](translator, database). The table must have a primary key.
+func NewRepository[T proto.Message](translator Translator, database *sql.DB) (*Repository[T], error) {
+	var zero T
+	schema, err := translator.GenerateSchema(zero.ProtoReflect().Type().New().Interface())
+	if err != nil {
+		return nil, err
+	}
+	r := &Repository[T]{translator: translator, database: database, schema: schema}
+	if keys := schemaUniqueKeys(schema); len(keys) > 0 && schemaHasPrimaryKey(schema) {
+		r.primaryKey = keys[0]
+	}
+	if len(r.primaryKey) == 0 {
+		return nil, fmt.Errorf("table '%s' has no primary key", schema.TableName)
+	}
+	return r, nil
+}
+
+// Schema returns the schema of the table of the repository
+func (r *Repository[T]) Schema() Schema {
+	return r.schema
+}
+
+// Insert writes the message as a new row, its fields are written like Update writes them. A zero auto-increment
+// primary key is left out and set on the message from the inserted row.
+func (r *Repository[T]) Insert(ctx context.Context, message T) error {
+	row, err := r.translator.fixtureRow(r.schema, message)
+	if err != nil {
+		return err
+	}
+	placeholders := make([]string, len(row.values))
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	statement := r.translator.fixtureInsertSQL(row, placeholders, FixtureModeInsert)
+	result, err := r.exec(ctx, statement, row.values...)
+	if err != nil {
+		return err
+	}
+
+	for _, col := range r.schema.Columns {
+		if col.AutoIncrement && !contains(row.columns, col.Name) {
+			id, err := result.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("failed to read the id of the inserted row: %w", err)
+			}
+			return r.translator.ColumnsToMessage(map[string]interface{}{col.Name: id}, message)
+		}
+	}
+	return nil
+}
+
+// Get reads the row with the given primary key values, in the order of the primary key columns
+func (r *Repository[T]) Get(ctx context.Context, key ...interface{}) (T, error) {
+	var zero T
+	condition, err := r.keyCondition(key)
+	if err != nil {
+		return zero, err
+	}
+	messages, _, err := r.query(ctx, fmt.Sprintf("%s WHERE %s", r.selectSQL(), condition), key...)
+	if err != nil {
+		return zero, err
+	}
+	if len(messages) == 0 {
+		return zero, ErrNotFound
+	}
+	return messages[0], nil
+}
+
+// Update writes the fields of the message to the row with its primary key, every column but the primary key and
+// generated columns. Zero values are written as is, only fields tracking presence (optional, message and oneof
+// fields) can be unset: unset fields of nullable columns are written as NULL, unset fields of other columns with a
+// default keep their value. MySQL doesn't report whether the row exists when nothing changed, so a missing row isn't
+// an error.
+func (r *Repository[T]) Update(ctx context.Context, message T) error {
+	columns, err := r.translator.MessageToColumns(message)
+	if err != nil {
+		return err
+	}
+	m := message.ProtoReflect()
+	oneofColumns := schemaOneofColumns(r.schema)
+
+	var assignments []string
+	var values []interface{}
+	for _, col := range r.schema.Columns {
+		if col.GeneratedExpression != "" || contains(r.primaryKey, col.Name) {
+			continue
+		}
+		value := columns[col.Name]
+		if !oneofColumns[col.Name] {
+			field := columnField(m.Descriptor(), col.Name)
+			if field == nil {
+				continue
+			}
+			if field.HasPresence() && !m.Has(field) {
+				switch {
+				case !contains(col.Constraints, "NOT NULL"):
+					value = nil
+				case hasDefault(col):
+					continue
+				}
+			}
+		}
+		assignments = append(assignments, fmt.Sprintf("`%s` = ?", col.Name))
+		values = append(values, value)
+	}
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	var key []interface{}
+	for _, column := range r.primaryKey {
+		key = append(key, columns[column])
+	}
+	condition, _ := r.keyCondition(key)
+	statement := fmt.Sprintf("UPDATE `%s` SET %s WHERE %s", r.schema.TableName, strings.Join(assignments, ", "), condition)
+	_, err = r.exec(ctx, statement, append(values, key...)...)
+	return err
+}
+
+// Delete removes the row with the given primary key values
+func (r *Repository[T]) Delete(ctx context.Context, key ...interface{}) error {
+	condition, err := r.keyCondition(key)
+	if err != nil {
+		return err
+	}
+	result, err := r.exec(ctx, fmt.Sprintf("DELETE FROM `%s` WHERE %s", r.schema.TableName, condition), key...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List reads the rows matching the filters, a page of at most Limit rows when Limit is set
+func (r *Repository[T]) List(ctx context.Context, options ListOptions) (Page[T], error) {
+	order := append(append([]string{}, options.OrderBy...), r.primaryKey...)
+	for _, column := range order {
+		if !r.hasColumn(column) {
+			return Page[T]{}, fmt.Errorf("table '%s' has no column '%s'", r.schema.TableName, column)
+		}
+	}
+
+	var conditions []string
+	var args []interface{}
+	for _, filter := range options.Filters {
+		condition, filterArgs, err := r.filterCondition(filter)
+		if err != nil {
+			return Page[T]{}, err
+		}
+		conditions = append(conditions, condition)
+		args = append(args, filterArgs...)
+	}
+
+	direction, comparison := "ASC", ">"
+	if options.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	var quoted, orderBy []string
+	for _, column := range order {
+		quoted = append(quoted, fmt.Sprintf("`%s`", column))
+		orderBy = append(orderBy, fmt.Sprintf("`%s` %s", column, direction))
+	}
+	if options.After != nil {
+		if len(options.After) != len(order) {
+			return Page[T]{}, fmt.Errorf("after must hold %d values (%s), got %d", len(order), strings.Join(order, ", "), len(options.After))
+		}
+		// Row value comparisons are supported by MySQL and SQLite 3.15+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(order)), ", ")
+		conditions = append(conditions, fmt.Sprintf("(%s) %s (%s)", strings.Join(quoted, ", "), comparison, placeholders))
+		args = append(args, options.After...)
+	}
+
+	statement := r.selectSQL()
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	statement += " ORDER BY " + strings.Join(orderBy, ", ")
+	if options.Limit > 0 {
+		statement += fmt.Sprintf(" LIMIT %d", options.Limit)
+	}
+
+	messages, rows, err := r.query(ctx, statement, args...)
+	if err != nil {
+		return Page[T]{}, err
+	}
+	page := Page[T]{Messages: messages}
+	if options.Limit > 0 && len(messages) == options.Limit {
+		last := rows[len(rows)-1]
+		for _, column := range order {
+			page.Next = append(page.Next, last[column])
+		}
+	}
+	return page, nil
+}
+
+// filterCondition renders the condition of a filter with its arguments
+func (r *Repository[T]) filterCondition(filter Filter) (string, []interface{}, error) {
+	if !r.hasColumn(filter.Column) {
+		return "", nil, fmt.Errorf("table '%s' has no column '%s'", r.schema.TableName, filter.Column)
+	}
+	operator := strings.ToUpper(strings.TrimSpace(filter.Operator))
+	if !filterOperators[operator] {
+		return "", nil, fmt.Errorf("unsupported filter operator '%s'", filter.Operator)
+	}
+	switch operator {
+	case "IS NULL", "IS NOT NULL":
+		return fmt.Sprintf("`%s` %s", filter.Column, operator), nil, nil
+	case "IN":
+		values, ok := filter.Value.([]interface{})
+		if !ok || len(values) == 0 {
+			return "", nil, fmt.Errorf("IN filter of column '%s' needs a non-empty []interface{} value", filter.Column)
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+		return fmt.Sprintf("`%s` IN (%s)", filter.Column, placeholders), values, nil
+	}
+	return fmt.Sprintf("`%s` %s ?", filter.Column, operator), []interface{}{filter.Value}, nil
+}
+
+// keyCondition renders the condition selecting a row by its primary key
+func (r *Repository[T]) keyCondition(key []interface{}) (string, error) {
+	if len(key) != len(r.primaryKey) {
+		return "", fmt.Errorf("primary key of table '%s' has %d columns (%s), got %d values",
+			r.schema.TableName, len(r.primaryKey), strings.Join(r.primaryKey, ", "), len(key))
+	}
+	var conditions []string
+	for _, column := range r.primaryKey {
+		conditions = append(conditions, fmt.Sprintf("`%s` = ?", column))
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+func (r *Repository[T]) selectSQL() string {
+	var quoted []string
+	for _, col := range r.schema.Columns {
+		quoted = append(quoted, fmt.Sprintf("`%s`", col.Name))
+	}
+	return fmt.Sprintf("SELECT %s FROM `%s`", strings.Join(quoted, ", "), r.schema.TableName)
+}
+
+// query scans the rows of a SELECT of all columns into messages, returning the column values of each row as well
+func (r *Repository[T]) query(ctx context.Context, statement string, args ...interface{}) ([]T, []map[string]interface{}, error) {
+	rows, err := r.database.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, nil, r.statementError(statement, err)
+	}
+	defer rows.Close()
+
+	var messages []T
+	var columnValues []map[string]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(r.schema.Columns))
+		pointers := make([]interface{}, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, err
+		}
+		columns := make(map[string]interface{}, len(values))
+		for i, col := range r.schema.Columns {
+			columns[col.Name] = values[i]
+		}
+		message := r.newMessage()
+		if err := r.translator.ColumnsToMessage(columns, message); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row of table '%s': %w", r.schema.TableName, err)
+		}
+		messages = append(messages, message)
+		columnValues = append(columnValues, columns)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return messages, columnValues, nil
+}
+
+func (r *Repository[T]) exec(ctx context.Context, statement string, args ...interface{}) (sql.Result, error) {
+	result, err := r.database.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return nil, r.statementError(statement, err)
+	}
+	return result, nil
+}
+
+func (r *Repository[T]) statementError(statement string, err error) error {
+	return StatementError{
+		TableName:  r.schema.TableName,
+		Statement:  statement,
+		DriverCode: driverErrorCode(err),
+		Err:        err,
+	}
+}
+
+func (r *Repository[T]) newMessage() T {
+	var zero T
+	return zero.ProtoReflect().Type().New().Interface().(T)
+}
+
+// schemaHasPrimaryKey reports whether the first key of schemaUniqueKeys is the primary key
+func schemaHasPrimaryKey(schema Schema) bool {
+	if schema.CompositePrimaryKeys != "" {
+		return true
+	}
+	for _, col := range schema.Columns {
+		if col.IsPrimaryKey {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Repository[T]) hasColumn(name string) bool {
+	_, ok := findColumnInSchema(name, r.schema.Columns)
+	return ok
+}
@@ -0,0 +1,174 @@
+package proto_db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newRepositoryDatabase(t *testing.T, translator Translator, messages ...proto.Message) *sql.DB {
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	database.SetMaxOpenConns(1)
+	_, err = database.Exec("PRAGMA foreign_keys = ON;")
+	require.NoError(t, err)
+	for _, message := range messages {
+		schema, err := translator.GenerateSchema(message)
+		require.NoError(t, err)
+		_, err = database.Exec(translator.GenerateCreateTableSQL(schema))
+		require.NoError(t, err)
+	}
+	return database
+}
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	translator := NewSqliteTranslator()
+	database := newRepositoryDatabase(t, translator, &userauth.Role{})
+	roles, err := NewRepository[*userauth.Role](translator, database)
+	require.NoError(t, err)
+
+	// parent_role_id isn't optional, the root role is its own parent
+	admin := &userauth.Role{RoleId: 1, RoleName: "admin", ParentRoleId: 1}
+	require.NoError(t, roles.Insert(ctx, admin))
+	editor := &userauth.Role{RoleName: "editor", ParentRoleId: admin.RoleId, Description: "Edits"}
+	require.NoError(t, roles.Insert(ctx, editor))
+	require.Equal(t, int32(2), editor.RoleId, "auto-increment key is set on the message")
+
+	got, err := roles.Get(ctx, editor.RoleId)
+	require.NoError(t, err)
+	require.Equal(t, "editor", got.RoleName)
+	require.Equal(t, int32(1), got.ParentRoleId)
+	require.NotNil(t, got.CreatedAt, "defaults are read back")
+
+	// The unset created_at message field keeps its default
+	got.Description = "Edits everything"
+	got.CreatedAt = nil
+	require.NoError(t, roles.Update(ctx, got))
+	updated, err := roles.Get(ctx, editor.RoleId)
+	require.NoError(t, err)
+	require.Equal(t, "Edits everything", updated.Description)
+	require.Equal(t, int32(1), updated.ParentRoleId)
+	require.NotNil(t, updated.CreatedAt)
+
+	var statementErr StatementError
+	require.ErrorAs(t, roles.Insert(ctx, &userauth.Role{RoleName: "admin", ParentRoleId: 1}), &statementErr)
+	require.Contains(t, []int{2067, 1555}, statementErr.DriverCode)
+
+	require.NoError(t, roles.Delete(ctx, editor.RoleId))
+	_, err = roles.Get(ctx, editor.RoleId)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorIs(t, roles.Delete(ctx, editor.RoleId), ErrNotFound)
+	_, err = roles.Get(ctx, 1, 2)
+	require.ErrorContains(t, err, "primary key of table 'Role' has 1 columns (role_id), got 2 values")
+}
+
+func TestRepositoryUpdateZeroValues(t *testing.T) {
+	ctx := context.Background()
+	translator := NewSqliteTranslator()
+	database := newRepositoryDatabase(t, translator, &userauth.User{})
+	users, err := NewRepository[*userauth.User](translator, database)
+	require.NoError(t, err)
+
+	user := &userauth.User{Id: 1, Username: "ada", Email: "ada@example.com", HashedPassword: "hash", Is_2FaEnabled: true, TwoFactorSecret: "secret"}
+	require.NoError(t, users.Insert(ctx, user))
+	got, err := users.Get(ctx, user.Id)
+	require.NoError(t, err)
+	require.True(t, got.Is_2FaEnabled)
+
+	// Zero values are written, false to a NOT NULL DEFAULT FALSE column and "" to a nullable column
+	got.Is_2FaEnabled = false
+	got.TwoFactorSecret = ""
+	require.NoError(t, users.Update(ctx, got))
+	updated, err := users.Get(ctx, user.Id)
+	require.NoError(t, err)
+	require.False(t, updated.Is_2FaEnabled)
+	require.Empty(t, updated.TwoFactorSecret)
+	var secret sql.NullString
+	require.NoError(t, database.QueryRow("SELECT two_factor_secret FROM `User` WHERE id = ?", user.Id).Scan(&secret))
+	require.Equal(t, sql.NullString{String: "", Valid: true}, secret)
+
+	// Insert writes zero values like Update does
+	other := &userauth.User{Id: 2, Username: "grace", Email: "grace@example.com", HashedPassword: "hash"}
+	require.NoError(t, users.Insert(ctx, other))
+	require.NoError(t, database.QueryRow("SELECT two_factor_secret FROM `User` WHERE id = ?", other.Id).Scan(&secret))
+	require.Equal(t, sql.NullString{String: "", Valid: true}, secret)
+}
+
+func TestRepositoryList(t *testing.T) {
+	ctx := context.Background()
+	translator := NewSqliteTranslator()
+	database := newRepositoryDatabase(t, translator, &userauth.Product{})
+	products, err := NewRepository[*userauth.Product](translator, database)
+	require.NoError(t, err)
+	for i := 1; i <= 7; i++ {
+		require.NoError(t, products.Insert(ctx, &userauth.Product{Name: fmt.Sprintf("product %d", i), Price: float32(10 * (i % 3)), StockQuantity: int32(i)}))
+	}
+
+	names := func(page Page[*userauth.Product]) []string {
+		var names []string
+		for _, product := range page.Messages {
+			names = append(names, product.Name)
+		}
+		return names
+	}
+
+	// Pages follow each other by the ordering columns and the primary key
+	options := ListOptions{OrderBy: []string{"price"}, Limit: 3}
+	var pages [][]string
+	for {
+		page, err := products.List(ctx, options)
+		require.NoError(t, err)
+		pages = append(pages, names(page))
+		if page.Next == nil {
+			break
+		}
+		options.After = page.Next
+	}
+	require.Equal(t, [][]string{
+		{"product 3", "product 6", "product 1"},
+		{"product 4", "product 7", "product 2"},
+		{"product 5"},
+	}, pages)
+
+	page, err := products.List(ctx, ListOptions{
+		Filters: []Filter{
+			{Column: "stock_quantity", Operator: ">=", Value: 2},
+			{Column: "product_id", Operator: "in", Value: []interface{}{1, 2, 3, 4}},
+			{Column: "description", Operator: "IS NOT NULL"},
+		},
+		Descending: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"product 4", "product 3", "product 2"}, names(page))
+	require.Nil(t, page.Next)
+
+	tests := []struct {
+		name     string
+		options  ListOptions
+		expected string
+	}{
+		{"Unknown Column", ListOptions{Filters: []Filter{{Column: "sku", Operator: "=", Value: 1}}}, "table 'Product' has no column 'sku'"},
+		{"Unknown Operator", ListOptions{Filters: []Filter{{Column: "name", Operator: "; DROP", Value: 1}}}, "unsupported filter operator '; DROP'"},
+		{"Empty IN", ListOptions{Filters: []Filter{{Column: "name", Operator: "IN"}}}, "needs a non-empty []interface{} value"},
+		{"Unknown Order", ListOptions{OrderBy: []string{"rank"}}, "table 'Product' has no column 'rank'"},
+		{"Short After", ListOptions{OrderBy: []string{"price"}, After: []interface{}{10}}, "after must hold 2 values (price, product_id), got 1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := products.List(ctx, test.options)
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+}
+
+func TestNewRepositoryWithoutPrimaryKey(t *testing.T) {
+	_, err := NewRepository[*userauth.RoleHierarchy](NewSqliteTranslator(), nil)
+	require.ErrorContains(t, err, "has no primary key")
+}
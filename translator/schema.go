@@ -1,9 +1,13 @@
 package proto_db
 
-import "github.com/imran31415/proto-db-translator/translator/db"
+import (
+	"github.com/imran31415/proto-db-translator/translator/db"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
 
 type Translator struct {
 	dbConnection db.DbConnection
+	sourceFiles  *protoregistry.Files // Descriptors carrying source info, used to read proto comments
 }
 
 func NewTranslator(in db.DbConnection) Translator {
@@ -20,13 +24,77 @@ func NewSqliteTranslator() Translator {
 
 // Schema represents the structure of a table for versioning
 type Schema struct {
-	TableName            string         `json:"table_name"`
-	Columns              []ColumnSchema `json:"columns"`
-	CompositePrimaryKeys string         `json:"composite_primary_keys"` // Composite primary keys
-	Indexes              []string       `json:"indexes"`                // Index definitions
-	UniqueConstraints    []string       `json:"unique_constraints,omitempty"`
-	CheckConstraints     []string       `json:"check_constraints,omitempty"`
-	CompositeIndexes     []string       `json:"composite_indexes,omitempty"`
+	TableName            string             `json:"table_name"`
+	Comment              string             `json:"comment,omitempty"` // Leading comment of the proto message
+	Columns              []ColumnSchema     `json:"columns"`
+	CompositePrimaryKeys string             `json:"composite_primary_keys"` // Composite primary keys
+	Indexes              []IndexSchema      `json:"indexes"`                // Single column indexes
+	UniqueConstraints    []string           `json:"unique_constraints,omitempty"`
+	CheckConstraints     []CheckConstraint  `json:"check_constraints,omitempty"`
+	ForeignKeys          []ForeignKeySchema `json:"foreign_keys,omitempty"`
+	CompositeIndexes     []IndexSchema      `json:"composite_indexes,omitempty"` // Table level indexes
+	Oneofs               []OneofSchema      `json:"oneofs,omitempty"`            // Oneof groups stored as discriminator + variant columns
+	Options              TableOptions       `json:"options,omitempty"`
+}
+
+// CheckConstraint represents a named CHECK constraint
+type CheckConstraint struct {
+	Name       string `json:"name,omitempty"`
+	Expression string `json:"expression"`
+}
+
+// ForeignKeySchema represents a named foreign key spanning one or more columns
+type ForeignKeySchema struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencesTable   string   `json:"references_table"`
+	ReferencesColumns []string `json:"references_columns"`
+	OnDelete          string   `json:"on_delete,omitempty"`
+	OnUpdate          string   `json:"on_update,omitempty"`
+}
+
+// IndexSchema represents an index over one or more ordered columns
+type IndexSchema struct {
+	Name    string        `json:"name"`
+	Columns []IndexColumn `json:"columns"`
+	Unique  bool          `json:"unique,omitempty"`
+	Type    string        `json:"type,omitempty"`   // FULLTEXT or SPATIAL, MySQL only
+	Method  string        `json:"method,omitempty"` // BTREE or HASH, MySQL only
+	Where   string        `json:"where,omitempty"`  // Predicate of a partial index, not supported by MySQL
+}
+
+// IndexColumn represents a column of an index, or an expression for functional indexes
+type IndexColumn struct {
+	Name         string `json:"name,omitempty"`
+	Expression   string `json:"expression,omitempty"`
+	Desc         bool   `json:"desc,omitempty"`
+	PrefixLength uint32 `json:"prefix_length,omitempty"` // MySQL only
+}
+
+// TableOptions represents the table level options of a table.
+// Engine, charset, collation, row format and AUTO_INCREMENT apply to MySQL, Strict and WithoutRowid to SQLite.
+type TableOptions struct {
+	Engine         string `json:"engine,omitempty"`
+	DefaultCharset string `json:"default_charset,omitempty"`
+	Collation      string `json:"collation,omitempty"`
+	RowFormat      string `json:"row_format,omitempty"`
+	AutoIncrement  uint64 `json:"auto_increment,omitempty"` // Initial AUTO_INCREMENT value
+	Strict         bool   `json:"strict,omitempty"`
+	WithoutRowid   bool   `json:"without_rowid,omitempty"`
+}
+
+// OneofSchema represents a proto oneof group mapped to a discriminator column
+// holding the set case and one nullable column per variant
+type OneofSchema struct {
+	Name                string         `json:"name"`
+	DiscriminatorColumn string         `json:"discriminator_column"`
+	Variants            []OneofVariant `json:"variants"`
+}
+
+// OneofVariant represents a single case of a oneof group
+type OneofVariant struct {
+	Case   string `json:"case"`   // Proto field name stored in the discriminator column
+	Column string `json:"column"` // Column holding the variant value
 }
 
 // ColumnSchema represents the definition of a table column
@@ -45,7 +113,11 @@ type ColumnSchema struct {
 	CharacterSet     string   `json:"character_set,omitempty"`    // New field
 	Collation        string   `json:"collation,omitempty"`        // New field
 	DefaultFunction  string   `json:"default_function,omitempty"` // New field for default functions
-
+	// Expression and storage (VIRTUAL or STORED) of a generated column, such columns are read-only
+	GeneratedExpression string `json:"generated_expression,omitempty"`
+	GeneratedStorage    string `json:"generated_storage,omitempty"`
+	Comment             string `json:"comment,omitempty"` // Leading comment of the proto field
+	Fake                string `json:"fake,omitempty"`    // Fake data generator used when seeding, see FakeGenerator
 }
 
 type SqlStatement struct {
@@ -0,0 +1,468 @@
+package proto_db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSeedRows  = 10
+	maxSeedAttempts  = 100
+	seedTimeInterval = 5 * 365 * 24 * time.Hour
+)
+
+var seedTimeStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SeedConfig configures the fake data generated by GenerateSeedData
+type SeedConfig struct {
+	Rows      int            `json:"rows,omitempty"`       // Rows per table, 10 when unset
+	TableRows map[string]int `json:"table_rows,omitempty"` // Rows of specific tables, overriding Rows
+	Seed      int64          `json:"seed"`                 // Seed of the random values, the same seed generates the same rows
+	// Generators of columns by "table.column", e.g. {"Customer.customer_name": "company"}. They override the
+	// generators set with db_ext.db_fake.
+	Generators map[string]string `json:"generators,omitempty"`
+}
+
+// LoadSeedConfig reads a JSON config file, e.g. {"rows": 50, "seed": 42, "generators": {"Customer.phone": "phone"}}
+func LoadSeedConfig(path string) (SeedConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return SeedConfig{}, fmt.Errorf("failed to read seed config: %w", err)
+	}
+	var config SeedConfig
+	if err := json.Unmarshal(b, &config); err != nil {
+		return SeedConfig{}, fmt.Errorf("failed to parse seed config '%s': %w", path, err)
+	}
+	return config, nil
+}
+
+// SeedTable holds the generated rows of a table, values are in the order of the columns
+type SeedTable struct {
+	TableName string
+	Columns   []string
+	Rows      [][]interface{}
+}
+
+// GenerateSeedData generates fake rows for the tables, referenced tables first. Values fit the type and length
+// of their column, unique keys get distinct values, foreign keys reference generated rows of the referenced
+// table, CHECK constraints comparing a column to a number and the constraints of oneof groups are satisfied.
+// Columns use the generator selected in the config or with db_ext.db_fake, or inferred from the column name
+// (e.g. email, phone, *_name, price), random values of the column type otherwise.
+// The rows only depend on the schemas and the config, the same seed generates the same rows.
+func GenerateSeedData(schemas []Schema, config SeedConfig) ([]SeedTable, error) {
+	sorted, cyclic := sortByDependencies(schemas)
+	if len(cyclic) > 0 {
+		var names []string
+		for _, schema := range cyclic {
+			names = append(names, schema.TableName)
+		}
+		return nil, fmt.Errorf("tables %s reference each other and can't be seeded", strings.Join(names, ", "))
+	}
+
+	seeder := seeder{
+		rand:   rand.New(rand.NewSource(config.Seed)),
+		config: config,
+		rows:   make(map[string][]map[string]interface{}),
+	}
+	var tables []SeedTable
+	for _, schema := range sorted {
+		table, err := seeder.seedTable(schema)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// InsertSeedData inserts the rows of the tables in a single transaction
+func InsertSeedData(database *sql.DB, tables []SeedTable) error {
+	tx, err := database.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for _, table := range tables {
+		for _, row := range table.Rows {
+			if err := insertValues(tx, table.TableName, table.Columns, row); err != nil {
+				return fmt.Errorf("failed to seed table '%s': %w", table.TableName, err)
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// Seed generates fake rows for the tables and inserts them
+func Seed(database *sql.DB, schemas []Schema, config SeedConfig) error {
+	tables, err := GenerateSeedData(schemas, config)
+	if err != nil {
+		return err
+	}
+	return InsertSeedData(database, tables)
+}
+
+type seeder struct {
+	rand   *rand.Rand
+	config SeedConfig
+	rows   map[string][]map[string]interface{} // Generated rows by table
+}
+
+func (s seeder) rowCount(table string) int {
+	if rows, ok := s.config.TableRows[table]; ok {
+		return rows
+	}
+	if s.config.Rows > 0 {
+		return s.config.Rows
+	}
+	return defaultSeedRows
+}
+
+func (s seeder) seedTable(schema Schema) (SeedTable, error) {
+	table := SeedTable{TableName: schema.TableName}
+	for _, col := range schema.Columns {
+		if col.GeneratedExpression == "" {
+			table.Columns = append(table.Columns, col.Name)
+		}
+	}
+	for _, foreignKey := range foreignKeys(schema) {
+		if _, ok := s.rows[foreignKey.ReferencesTable]; !ok && foreignKey.ReferencesTable != schema.TableName {
+			return SeedTable{}, fmt.Errorf("table '%s' references table '%s' which isn't seeded", schema.TableName, foreignKey.ReferencesTable)
+		}
+	}
+
+	keys := schemaUniqueKeys(schema)
+	seen := make([]map[string]bool, len(keys))
+	for i := range seen {
+		seen[i] = make(map[string]bool)
+	}
+	singleKeys := make(map[string]bool)
+	for _, key := range keys {
+		if len(key) == 1 {
+			singleKeys[key[0]] = true
+		}
+	}
+	comparisons := make(map[string]comparison)
+	for _, check := range schema.CheckConstraints {
+		if column, comparison, ok := parseComparisonCheck(schema, check.Expression); ok {
+			comparisons[column.Name] = comparison
+		}
+	}
+
+	count := s.rowCount(schema.TableName)
+	for n := 1; n <= count; n++ {
+		var row map[string]interface{}
+		for attempt := 0; ; attempt++ {
+			var err error
+			row, err = s.row(schema, n, attempt, singleKeys, comparisons)
+			if err != nil {
+				return SeedTable{}, err
+			}
+			collision := -1
+			for i, key := range keys {
+				if value, ok := keyValue(row, key); ok && seen[i][value] {
+					collision = i
+					break
+				}
+			}
+			if collision < 0 {
+				break
+			}
+			if attempt == maxSeedAttempts {
+				return SeedTable{}, fmt.Errorf("failed to generate %d rows of table '%s' with distinct values of UNIQUE (%s)",
+					count, schema.TableName, strings.Join(keys[collision], ", "))
+			}
+		}
+		for i, key := range keys {
+			if value, ok := keyValue(row, key); ok {
+				seen[i][value] = true
+			}
+		}
+		s.rows[schema.TableName] = append(s.rows[schema.TableName], row)
+
+		values := make([]interface{}, len(table.Columns))
+		for i, column := range table.Columns {
+			values[i] = row[column]
+		}
+		table.Rows = append(table.Rows, values)
+	}
+	return table, nil
+}
+
+// row generates row n of a table, attempts after the first one generate the row again with unique single
+// column string values made distinct
+func (s seeder) row(schema Schema, n, attempt int, singleKeys map[string]bool, comparisons map[string]comparison) (map[string]interface{}, error) {
+	row := make(map[string]interface{})
+	unset := make(map[string]bool) // Variant columns of the oneof groups, true when the variant isn't set
+	for _, oneof := range schema.Oneofs {
+		if len(oneof.Variants) == 0 {
+			continue
+		}
+		set := oneof.Variants[s.rand.Intn(len(oneof.Variants))]
+		row[oneof.DiscriminatorColumn] = set.Case
+		for _, variant := range oneof.Variants {
+			unset[variant.Column] = variant.Column != set.Column
+		}
+	}
+	for _, col := range schema.Columns {
+		if _, ok := row[col.Name]; ok || col.GeneratedExpression != "" {
+			continue
+		}
+		if unset[col.Name] {
+			row[col.Name] = nil
+			continue
+		}
+		_, compared := comparisons[col.Name]
+		_, variant := unset[col.Name]
+		nullable := !contains(col.Constraints, "NOT NULL") && !col.IsPrimaryKey && !singleKeys[col.Name] && !compared && !variant
+		if nullable && s.rand.Intn(10) == 0 {
+			row[col.Name] = nil
+			continue
+		}
+		value, err := s.value(schema.TableName, col, n, attempt, singleKeys[col.Name], comparisons)
+		if err != nil {
+			return nil, err
+		}
+		row[col.Name] = value
+	}
+
+	for _, foreignKey := range foreignKeys(schema) {
+		nullable := true
+		for _, column := range foreignKey.Columns {
+			col, _ := findColumnInSchema(column, schema.Columns)
+			nullable = nullable && !contains(col.Constraints, "NOT NULL") && !col.IsPrimaryKey
+		}
+		parents := s.rows[foreignKey.ReferencesTable]
+		var parent map[string]interface{}
+		switch {
+		case foreignKey.ReferencesTable == schema.TableName && (len(parents) == 0 || (nullable && s.rand.Intn(4) == 0)):
+			// The first rows of a self-referencing table reference nothing, or themselves
+			if !nullable {
+				parent = row
+			}
+		case len(parents) == 0:
+			if !nullable {
+				return nil, fmt.Errorf("table '%s' references table '%s' which has no rows", schema.TableName, foreignKey.ReferencesTable)
+			}
+		default:
+			parent = parents[s.rand.Intn(len(parents))]
+		}
+		for i, column := range foreignKey.Columns {
+			if parent == nil {
+				row[column] = nil
+			} else {
+				row[column] = parent[foreignKey.ReferencesColumns[i]]
+			}
+		}
+	}
+	return row, nil
+}
+
+// value generates the value of a column in row n
+func (s seeder) value(table string, col ColumnSchema, n, attempt int, unique bool, comparisons map[string]comparison) (interface{}, error) {
+	comparison, compared := comparisons[col.Name]
+	if isIntegerType(col.Type) && (col.IsPrimaryKey || col.AutoIncrement || unique) && !compared {
+		// Sequential keys
+		return int64(n), nil
+	}
+	generator, err := s.generator(table, col)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if generator != "" {
+		value = fakeGenerators[generator](s.rand, n)
+	} else {
+		value = s.randomValue(col, n)
+	}
+	if compared {
+		number, _ := value.(float64)
+		if integer, ok := value.(int64); ok {
+			number = float64(integer)
+		}
+		if !comparison.satisfies(number) {
+			value = comparison.satisfying(1 + s.rand.Intn(100))
+		} else {
+			value = number
+		}
+	}
+
+	value = fitColumnValue(col, value)
+	if text, ok := value.(string); ok && unique && attempt > 0 {
+		value = withSuffix(text, fmt.Sprintf("-%d", n), columnSize(col))
+	}
+	return value, nil
+}
+
+// generator returns the name of the generator of a column, "" for random values of the column type
+func (s seeder) generator(table string, col ColumnSchema) (string, error) {
+	name := s.config.Generators[table+"."+col.Name]
+	if name == "" {
+		name = col.Fake
+	}
+	if name == "" {
+		return inferFakeGenerator(col), nil
+	}
+	if _, ok := fakeGenerators[name]; !ok {
+		return "", fmt.Errorf("unknown fake data generator '%s' of column '%s.%s'", name, table, col.Name)
+	}
+	if numericFakeGenerators[name] != isNumericType(col.Type) {
+		return "", fmt.Errorf("fake data generator '%s' can't fill column '%s.%s' of type %s", name, table, col.Name, col.Type)
+	}
+	return name, nil
+}
+
+// randomValue generates a random value of the column type
+func (s seeder) randomValue(col ColumnSchema, n int) interface{} {
+	sqlType := baseColumnType(col.Type)
+	switch {
+	case sqlType == "BOOLEAN" || sqlType == "BOOL":
+		return s.rand.Intn(2) == 1
+	case sqlType == "TINYINT":
+		return int64(s.rand.Intn(100))
+	case isIntegerType(sqlType):
+		return int64(1 + s.rand.Intn(1000))
+	case isNumericType(sqlType):
+		return float64(s.rand.Intn(100000)) / 100
+	case sqlType == "DATE":
+		return s.randomTime().Format("2006-01-02")
+	case sqlType == "TIME":
+		return s.randomTime().Format("15:04:05")
+	case isTemporalType(sqlType):
+		return s.randomTime().Format("2006-01-02 15:04:05")
+	case sqlType == "JSON":
+		return fmt.Sprintf(`{"id": %d, "tag": "%s"}`, n, pick(s.rand, words))
+	case strings.Contains(sqlType, "BLOB") || strings.Contains(sqlType, "BINARY"):
+		b := make([]byte, 16)
+		s.rand.Read(b)
+		return b
+	case isTextType(sqlType):
+		return fakeGenerators["sentence"](s.rand, n)
+	}
+	return pick(s.rand, words)
+}
+
+func (s seeder) randomTime() time.Time {
+	return seedTimeStart.Add(time.Duration(s.rand.Int63n(int64(seedTimeInterval/time.Second))) * time.Second)
+}
+
+// fitColumnValue converts a value to fit the type of the column: numbers are rounded to the scale and the
+// precision of the column, strings and bytes are truncated to its length
+func fitColumnValue(col ColumnSchema, value interface{}) interface{} {
+	size := columnSize(col)
+	switch v := value.(type) {
+	case float64:
+		if isIntegerType(col.Type) {
+			return int64(math.Round(v))
+		}
+		if col.Precision > 0 {
+			scale := math.Pow(10, float64(col.Scale))
+			limit := math.Pow(10, float64(col.Precision-col.Scale)) - 1/scale
+			v = math.Max(-limit, math.Min(limit, math.Round(v*scale)/scale))
+		}
+		return v
+	case string:
+		if size > 0 && len([]rune(v)) > size {
+			return string([]rune(v)[:size])
+		}
+	case []byte:
+		if size > 0 && len(v) > size {
+			return v[:size]
+		}
+	}
+	return value
+}
+
+// columnSize returns the length of a sized column type, e.g. 32 for VARCHAR(32), 0 for unsized types
+func columnSize(col ColumnSchema) int {
+	if isNumericType(col.Type) {
+		return 0
+	}
+	match := typeSizePattern.FindStringSubmatch(col.Type)
+	if match == nil {
+		return 0
+	}
+	size, _ := strconv.Atoi(match[1])
+	return size
+}
+
+// withSuffix appends a suffix to a value, truncating the value to keep the suffix within the size
+func withSuffix(value, suffix string, size int) string {
+	if size > 0 && len(value)+len(suffix) > size {
+		if len(suffix) >= size {
+			return suffix[len(suffix)-size:]
+		}
+		value = value[:size-len(suffix)]
+	}
+	return value + suffix
+}
+
+// keyValue renders the values of a key, keys with a NULL value never collide
+func keyValue(row map[string]interface{}, key []string) (string, bool) {
+	var values []string
+	for _, column := range key {
+		if row[column] == nil {
+			return "", false
+		}
+		values = append(values, fmt.Sprintf("%v", row[column]))
+	}
+	return strings.Join(values, "\x00"), true
+}
+
+// schemaUniqueKeys lists the column sets of a table that must be unique: its primary key first, then its unique
+// columns, unique constraints and unique table indexes (see Schema.CompositeIndexes) without expressions or conditions
+func schemaUniqueKeys(schema Schema) [][]string {
+	var keys [][]string
+	add := func(key []string) {
+		for _, other := range keys {
+			if sameColumns(key, other) {
+				return
+			}
+		}
+		keys = append(keys, key)
+	}
+
+	var primaryKey []string
+	for _, col := range schema.Columns {
+		if col.IsPrimaryKey {
+			primaryKey = append(primaryKey, col.Name)
+		}
+	}
+	if schema.CompositePrimaryKeys != "" {
+		primaryKey = splitColumns(schema.CompositePrimaryKeys)
+	}
+	if len(primaryKey) > 0 {
+		add(primaryKey)
+	}
+	for _, col := range schema.Columns {
+		if contains(col.Constraints, "UNIQUE") {
+			add([]string{col.Name})
+		}
+	}
+	for _, unique := range schema.UniqueConstraints {
+		add(splitColumns(unique))
+	}
+	for _, index := range schema.CompositeIndexes {
+		if !index.Unique || index.Where != "" {
+			continue
+		}
+		var columns []string
+		for _, column := range index.Columns {
+			if column.Expression != "" {
+				columns = nil
+				break
+			}
+			columns = append(columns, column.Name)
+		}
+		if len(columns) > 0 {
+			add(columns)
+		}
+	}
+	return keys
+}
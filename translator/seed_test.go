@@ -0,0 +1,211 @@
+package proto_db
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// seedSchemas returns the schemas of the example tables and an SQLite database holding them
+func seedSchemas(t *testing.T) ([]Schema, *sql.DB) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	database.SetMaxOpenConns(1)
+	_, err = database.Exec("PRAGMA foreign_keys = ON;")
+	require.NoError(t, err)
+
+	translator := NewSqliteTranslator()
+	var schemas []Schema
+	for _, message := range []proto.Message{
+		&userauth.Payment{},
+		&userauth.OrderItems{},
+		&userauth.Orders{},
+		&userauth.Customer{},
+		&userauth.Product{},
+		&userauth.Role{},
+		&userauth.User{},
+	} {
+		schema, err := translator.GenerateSchema(message)
+		require.NoError(t, err)
+		schemas = append(schemas, schema)
+	}
+	sorted, _ := sortByDependencies(schemas)
+	for _, schema := range sorted {
+		_, err := database.Exec(translator.GenerateCreateTableSQL(schema))
+		require.NoError(t, err)
+	}
+	return schemas, database
+}
+
+func seedTable(t *testing.T, tables []SeedTable, name string) SeedTable {
+	for _, table := range tables {
+		if table.TableName == name {
+			return table
+		}
+	}
+	require.Fail(t, "table not seeded", name)
+	return SeedTable{}
+}
+
+func seedColumn(table SeedTable, column string, row []interface{}) interface{} {
+	for i, name := range table.Columns {
+		if name == column {
+			return row[i]
+		}
+	}
+	return nil
+}
+
+func TestSeed(t *testing.T) {
+	schemas, database := seedSchemas(t)
+	config := SeedConfig{Rows: 30, TableRows: map[string]int{"Customer": 5}, Seed: 42}
+	require.NoError(t, Seed(database, schemas, config))
+
+	for table, expected := range map[string]int{"Customer": 5, "Orders": 30, "OrderItems": 30, "Payment": 30, "Role": 30} {
+		var count int
+		require.NoError(t, database.QueryRow("SELECT COUNT(*) FROM `"+table+"`").Scan(&count))
+		require.Equal(t, expected, count, table)
+	}
+
+	// Referenced tables are generated first, foreign keys reference their rows
+	tables, err := GenerateSeedData(schemas, config)
+	require.NoError(t, err)
+	var order []string
+	for _, table := range tables {
+		order = append(order, table.TableName)
+	}
+	require.Equal(t, []string{"Customer", "Product", "Role", "User", "Orders", "Payment", "OrderItems"}, order)
+	customers := make(map[interface{}]bool)
+	for _, row := range seedTable(t, tables, "Customer").Rows {
+		customers[seedColumn(seedTable(t, tables, "Customer"), "customer_id", row)] = true
+	}
+	orders := seedTable(t, tables, "Orders")
+	for _, row := range orders.Rows {
+		require.True(t, customers[seedColumn(orders, "customer_id", row)])
+		require.Greater(t, seedColumn(orders, "total_amount", row), 0.0, "CHECK (total_amount > 0)")
+		require.LessOrEqual(t, len(seedColumn(orders, "status", row).(string)), 32)
+	}
+}
+
+func TestGenerateSeedDataIsReproducible(t *testing.T) {
+	schemas, _ := seedSchemas(t)
+	first, err := GenerateSeedData(schemas, SeedConfig{Seed: 7})
+	require.NoError(t, err)
+	second, err := GenerateSeedData(schemas, SeedConfig{Seed: 7})
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	other, err := GenerateSeedData(schemas, SeedConfig{Seed: 8})
+	require.NoError(t, err)
+	require.NotEqual(t, first, other)
+}
+
+func TestGenerateSeedDataGenerators(t *testing.T) {
+	schemas, _ := seedSchemas(t)
+	tables, err := GenerateSeedData(schemas, SeedConfig{
+		Seed:       1,
+		Generators: map[string]string{"Customer.customer_name": "company"},
+	})
+	require.NoError(t, err)
+
+	customers := seedTable(t, tables, "Customer")
+	products := seedTable(t, tables, "Product")
+	for i := range customers.Rows {
+		// Configured generator
+		name := seedColumn(customers, "customer_name", customers.Rows[i]).(string)
+		require.Contains(t, companySuffixes, name[strings.LastIndex(name, " ")+1:])
+		// Generator inferred from the column name
+		require.Contains(t, seedColumn(customers, "email", customers.Rows[i]), "@example.com")
+		// Generator set with db_ext.db_fake
+		product := seedColumn(products, "name", products.Rows[i]).(string)
+		require.Contains(t, adjectives, strings.Fields(product)[0])
+	}
+
+	_, err = GenerateSeedData(schemas, SeedConfig{Generators: map[string]string{"Customer.phone": "unknown"}})
+	require.ErrorContains(t, err, "unknown fake data generator 'unknown' of column 'Customer.phone'")
+	_, err = GenerateSeedData(schemas, SeedConfig{Generators: map[string]string{"Orders.total_amount": "email"}})
+	require.ErrorContains(t, err, "can't fill column 'Orders.total_amount'")
+}
+
+func TestGenerateSeedDataUniqueKeys(t *testing.T) {
+	schema := Schema{
+		TableName: "Flags",
+		Columns: []ColumnSchema{
+			{Name: "id", Type: "INT", IsPrimaryKey: true},
+			{Name: "code", Type: "CHAR(4)", Constraints: []string{"NOT NULL", "UNIQUE"}, Fake: "city"},
+			{Name: "enabled", Type: "BOOLEAN", Constraints: []string{"NOT NULL"}},
+		},
+	}
+	tables, err := GenerateSeedData([]Schema{schema}, SeedConfig{Rows: 40})
+	require.NoError(t, err)
+	codes := make(map[interface{}]bool)
+	for _, row := range tables[0].Rows {
+		require.LessOrEqual(t, len(row[1].(string)), 4)
+		codes[row[1]] = true
+	}
+	require.Len(t, codes, 40)
+
+	// Two boolean values can't fill three rows
+	schema.UniqueConstraints = []string{"enabled"}
+	_, err = GenerateSeedData([]Schema{schema}, SeedConfig{Rows: 3})
+	require.ErrorContains(t, err, "distinct values of UNIQUE (enabled)")
+
+	// Nor can they fill three rows of a unique db_table_index
+	schema.UniqueConstraints = nil
+	schema.CompositeIndexes = []IndexSchema{{Name: "flags_enabled_idx", Unique: true, Columns: []IndexColumn{{Name: "enabled"}}}}
+	_, err = GenerateSeedData([]Schema{schema}, SeedConfig{Rows: 3})
+	require.ErrorContains(t, err, "distinct values of UNIQUE (enabled)")
+}
+
+func TestSchemaUniqueKeys(t *testing.T) {
+	schema := Schema{
+		TableName: "Accounts",
+		Columns: []ColumnSchema{
+			{Name: "id", Type: "INT", IsPrimaryKey: true},
+			{Name: "email", Type: "VARCHAR(255)", Constraints: []string{"UNIQUE"}},
+			{Name: "tenant_id", Type: "INT"},
+			{Name: "handle", Type: "VARCHAR(64)"},
+		},
+		Indexes: []IndexSchema{{Name: "accounts_handle_idx", Columns: []IndexColumn{{Name: "handle"}}}},
+		CompositeIndexes: []IndexSchema{
+			{Name: "accounts_tenant_handle_idx", Unique: true, Columns: []IndexColumn{{Name: "tenant_id"}, {Name: "handle"}}},
+			{Name: "accounts_tenant_idx", Columns: []IndexColumn{{Name: "tenant_id"}}},
+			{Name: "accounts_email_ci_idx", Unique: true, Columns: []IndexColumn{{Expression: "LOWER(email)"}}},
+			{Name: "accounts_active_handle_idx", Unique: true, Columns: []IndexColumn{{Name: "handle"}}, Where: "tenant_id IS NOT NULL"},
+		},
+	}
+	require.Equal(t, [][]string{{"id"}, {"email"}, {"tenant_id", "handle"}}, schemaUniqueKeys(schema))
+}
+
+func TestLoadSeedConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rows": 5, "seed": 3, "generators": {"Customer.phone": "phone"}}`), 0o644))
+	config, err := LoadSeedConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, SeedConfig{Rows: 5, Seed: 3, Generators: map[string]string{"Customer.phone": "phone"}}, config)
+}
+
+func TestInferFakeGenerator(t *testing.T) {
+	tests := []struct {
+		column    ColumnSchema
+		generator string
+	}{
+		{ColumnSchema{Name: "contact_email", Type: "VARCHAR(255)"}, "email"},
+		{ColumnSchema{Name: "customer_name", Type: "VARCHAR(255)"}, "name"},
+		{ColumnSchema{Name: "username", Type: "VARCHAR(255)"}, "username"},
+		{ColumnSchema{Name: "total_amount", Type: "DECIMAL(10,2)"}, "money"},
+		{ColumnSchema{Name: "total_count", Type: "INT"}, ""},
+		{ColumnSchema{Name: "comment", Type: "JSON"}, ""},
+		{ColumnSchema{Name: "status", Type: "VARCHAR(32)"}, ""},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.generator, inferFakeGenerator(test.column), test.column.Name)
+	}
+}
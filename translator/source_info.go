@@ -0,0 +1,90 @@
+package proto_db
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ReadDescriptorSet reads a binary FileDescriptorSet, as written by
+// `protoc --include_source_info --descriptor_set_out=<path>`
+func ReadDescriptorSet(path string) (*descriptorpb.FileDescriptorSet, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set: %w", err)
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, set); err != nil {
+		return nil, fmt.Errorf("failed to parse descriptor set '%s': %w", path, err)
+	}
+	return set, nil
+}
+
+// WithSourceInfo returns a copy of the translator reading table and column comments from the
+// source info of the descriptor set. Generated Go code drops source info, so without a descriptor
+// set the schemas carry no comments.
+// Imports missing from the set are resolved from the descriptors linked into the binary,
+// or left as placeholders as only the comments are needed.
+func (t Translator) WithSourceInfo(set *descriptorpb.FileDescriptorSet) (Translator, error) {
+	files := &protoregistry.Files{}
+	resolver := sourceResolver{local: files}
+	for _, fdp := range set.GetFile() {
+		fd, err := protodesc.FileOptions{AllowUnresolvable: true}.New(fdp, resolver)
+		if err != nil {
+			return t, fmt.Errorf("failed to load descriptor '%s': %w", fdp.GetName(), err)
+		}
+		if err := files.RegisterFile(fd); err != nil {
+			return t, fmt.Errorf("failed to register descriptor '%s': %w", fdp.GetName(), err)
+		}
+	}
+	t.sourceFiles = files
+	return t, nil
+}
+
+// sourceComment returns the comment attached to a message or field in the source info, if any
+func (t Translator) sourceComment(d protoreflect.Descriptor) string {
+	if t.sourceFiles == nil {
+		return ""
+	}
+	desc, err := t.sourceFiles.FindDescriptorByName(d.FullName())
+	if err != nil {
+		return ""
+	}
+	loc := desc.ParentFile().SourceLocations().ByDescriptor(desc)
+	comment := loc.LeadingComments
+	if strings.TrimSpace(comment) == "" {
+		comment = loc.TrailingComments
+	}
+	return normalizeComment(comment)
+}
+
+// normalizeComment joins the lines of a proto comment into a single line
+func normalizeComment(comment string) string {
+	return strings.Join(strings.Fields(comment), " ")
+}
+
+// sourceResolver resolves imports from the descriptor set first and falls back to the
+// descriptors registered by generated Go code
+type sourceResolver struct {
+	local *protoregistry.Files
+}
+
+func (r sourceResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.local.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r sourceResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.local.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
@@ -0,0 +1,101 @@
+package proto_db
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// orderDescriptorSet builds the descriptor set protoc would write for proto/order.proto with --include_source_info.
+// Generated Go code strips source info, so the comments of the Customer message are added back by hand.
+func orderDescriptorSet() *descriptorpb.FileDescriptorSet {
+	fdp := protodesc.ToFileDescriptorProto(userauth.File_proto_order_proto)
+	md := (&userauth.Customer{}).ProtoReflect().Descriptor()
+	message := int32(md.Index())
+	field := func(name string) int32 {
+		return int32(md.Fields().ByName(protoreflect.Name(name)).Index())
+	}
+	fdp.SourceCodeInfo = &descriptorpb.SourceCodeInfo{
+		Location: []*descriptorpb.SourceCodeInfo_Location{
+			{Path: []int32{4, message}, Span: []int32{154, 0, 10}, LeadingComments: proto.String(" Message for the Customer entity\n")},
+			{Path: []int32{4, message, 2, field("email")}, Span: []int32{168, 4, 10}, LeadingComments: proto.String(" Contact email, unique\n per customer\n")},
+			{Path: []int32{4, message, 2, field("phone")}, Span: []int32{175, 4, 10}, TrailingComments: proto.String(" Customer's phone number\n")},
+		},
+	}
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fdp}}
+}
+
+func TestGenerateSchemaComments(t *testing.T) {
+	translator, err := NewTranslator(db.DefaultMysqlConnection()).WithSourceInfo(orderDescriptorSet())
+	require.NoError(t, err)
+
+	schema, err := translator.GenerateSchema(&userauth.Customer{})
+	require.NoError(t, err)
+	require.Equal(t, "Message for the Customer entity", schema.Comment)
+
+	comments := make(map[string]string)
+	for _, col := range schema.Columns {
+		comments[col.Name] = col.Comment
+	}
+	require.Equal(t, "Contact email, unique per customer", comments["email"])
+	require.Equal(t, "Customer's phone number", comments["phone"])
+	require.Empty(t, comments["customer_id"])
+
+	sql := translator.GenerateCreateTableSQL(schema)
+	require.Contains(t, sql, "email VARCHAR(255) NOT NULL UNIQUE COMMENT 'Contact email, unique per customer',")
+	require.Contains(t, sql, "phone VARCHAR(32) COMMENT 'Customer''s phone number',")
+	require.Contains(t, sql, ") COMMENT='Message for the Customer entity';")
+
+	// Without source info no comments are captured
+	schema, err = NewTranslator(db.DefaultMysqlConnection()).GenerateSchema(&userauth.Customer{})
+	require.NoError(t, err)
+	require.Empty(t, schema.Comment)
+	require.NotContains(t, NewTranslator(db.DefaultMysqlConnection()).GenerateCreateTableSQL(schema), "COMMENT")
+}
+
+func TestCommentsSqlite(t *testing.T) {
+	translator, err := NewTranslator(db.DefaultSqliteConnection()).WithSourceInfo(orderDescriptorSet())
+	require.NoError(t, err)
+
+	schema, err := translator.GenerateSchema(&userauth.Customer{})
+	require.NoError(t, err)
+	statement := translator.GenerateCreateTableSQL(schema)
+	require.Contains(t, statement, "-- Customer: Message for the Customer entity\n"+
+		"-- Customer.email: Contact email, unique per customer\n"+
+		"-- Customer.phone: Customer's phone number\n"+
+		"CREATE TABLE `Customer` (\n")
+	require.NotContains(t, statement, "COMMENT")
+
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+	_, err = database.Exec(statement)
+	require.NoError(t, err)
+}
+
+func TestReadDescriptorSet(t *testing.T) {
+	b, err := proto.Marshal(orderDescriptorSet())
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "order.binpb")
+	require.NoError(t, os.WriteFile(path, b, 0o644))
+
+	set, err := ReadDescriptorSet(path)
+	require.NoError(t, err)
+	translator, err := NewSqliteTranslator().WithSourceInfo(set)
+	require.NoError(t, err)
+	schema, err := translator.GenerateSchema(&userauth.Customer{})
+	require.NoError(t, err)
+	require.Equal(t, "Message for the Customer entity", schema.Comment)
+
+	_, err = ReadDescriptorSet(filepath.Join(t.TempDir(), "missing.binpb"))
+	require.Error(t, err)
+}
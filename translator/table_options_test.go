@@ -0,0 +1,73 @@
+package proto_db
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSchemaTableOptions(t *testing.T) {
+	translator := NewTranslator(db.DefaultMysqlConnection())
+
+	schema, err := translator.GenerateSchema(&userauth.Orders{})
+	require.NoError(t, err)
+	require.Equal(t, TableOptions{
+		Engine:         "InnoDB",
+		DefaultCharset: "utf8mb4",
+		Collation:      "utf8mb4_unicode_ci",
+		RowFormat:      "DYNAMIC",
+	}, schema.Options)
+	require.Contains(t, translator.GenerateCreateTableSQL(schema), "\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC;")
+
+	// MySQL table options are ignored by SQLite
+	sqliteSchema, err := NewSqliteTranslator().GenerateSchema(&userauth.Orders{})
+	require.NoError(t, err)
+	require.NotContains(t, NewSqliteTranslator().GenerateCreateTableSQL(sqliteSchema), "ENGINE")
+}
+
+func TestSqliteStrictWithoutRowid(t *testing.T) {
+	translator := NewSqliteTranslator()
+	schema := Schema{
+		TableName: "Settings",
+		Columns: []ColumnSchema{
+			{Name: "name", Type: "VARCHAR(64)", Constraints: []string{"NOT NULL"}, IsPrimaryKey: true},
+			{Name: "value", Type: "INT", Constraints: []string{"NOT NULL"}},
+			{Name: "enabled", Type: "BOOLEAN", Constraints: []string{"NOT NULL", "DEFAULT TRUE"}},
+			{Name: "ratio", Type: "DECIMAL", Precision: 5, Scale: 2},
+		},
+		Options: TableOptions{Strict: true, WithoutRowid: true, Engine: "InnoDB"},
+	}
+
+	statement := translator.GenerateCreateTableSQL(schema)
+	require.Equal(t, "CREATE TABLE `Settings` (\n"+
+		"  name TEXT NOT NULL PRIMARY KEY,\n"+
+		"  value INT NOT NULL,\n"+
+		"  enabled INTEGER NOT NULL DEFAULT TRUE,\n"+
+		"  ratio REAL\n"+
+		") STRICT, WITHOUT ROWID;", statement)
+
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+	_, err = database.Exec(statement)
+	require.NoError(t, err)
+
+	_, err = database.Exec("INSERT INTO Settings (name, value) VALUES ('retries', 3)")
+	require.NoError(t, err)
+	// STRICT tables reject values that don't match the column type
+	_, err = database.Exec("INSERT INTO Settings (name, value) VALUES ('timeout', 'thirty')")
+	require.Error(t, err)
+}
+
+func TestGenerateMigrationSqliteTableOptions(t *testing.T) {
+	oldSchema := Schema{TableName: "Settings", Columns: []ColumnSchema{{Name: "name", Type: "TEXT"}}}
+	newSchema := Schema{TableName: "Settings", Columns: []ColumnSchema{{Name: "name", Type: "TEXT"}}, Options: TableOptions{Strict: true}}
+
+	require.Equal(t, "-- Migration for table: Settings\n"+
+		"-- SQLite can't change STRICT or WITHOUT ROWID of Settings, the table must be rebuilt\n",
+		NewSqliteTranslator().GenerateMigration(oldSchema, newSchema))
+	require.Equal(t, "-- Migration for table: Settings\n", NewSqliteTranslator().GenerateMigration(newSchema, newSchema))
+}
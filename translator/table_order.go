@@ -0,0 +1,58 @@
+package proto_db
+
+// foreignKeys lists all foreign keys of a table, the single column ones declared on the fields
+// followed by the ones declared on the message. The ones declared on the fields have no name, the DDL
+// emits them without one and the database names them.
+func foreignKeys(schema Schema) []ForeignKeySchema {
+	var keys []ForeignKeySchema
+	for _, col := range schema.Columns {
+		if col.ForeignKeyTable == "" || col.ForeignKeyColumn == "" {
+			continue
+		}
+		keys = append(keys, ForeignKeySchema{
+			Columns:           []string{col.Name},
+			ReferencesTable:   col.ForeignKeyTable,
+			ReferencesColumns: []string{col.ForeignKeyColumn},
+			OnDelete:          col.OnDelete,
+			OnUpdate:          col.OnUpdate,
+		})
+	}
+	return append(keys, schema.ForeignKeys...)
+}
+
+// sortByDependencies orders the tables so that every table comes after the tables it references.
+// Tables keep their relative order otherwise, references to the table itself or to tables outside the set
+// are ignored. The tables of a reference cycle can't be ordered, they are returned separately in their
+// original order.
+func sortByDependencies(schemas []Schema) (sorted []Schema, cyclic []Schema) {
+	tables := make(map[string]bool)
+	for _, schema := range schemas {
+		tables[schema.TableName] = true
+	}
+	placed := make(map[string]bool)
+	remaining := schemas
+	for len(remaining) > 0 {
+		var next []Schema
+		for _, schema := range remaining {
+			ready := true
+			for _, foreignKey := range foreignKeys(schema) {
+				table := foreignKey.ReferencesTable
+				if table != schema.TableName && tables[table] && !placed[table] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				sorted = append(sorted, schema)
+				placed[schema.TableName] = true
+			} else {
+				next = append(next, schema)
+			}
+		}
+		if len(next) == len(remaining) {
+			return sorted, next
+		}
+		remaining = next
+	}
+	return sorted, nil
+}
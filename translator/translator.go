@@ -4,11 +4,13 @@ import "google.golang.org/protobuf/proto"
 
 // TranslatorInterface defines the methods for the Translator struct.
 type TranslatorInterface interface {
-	GenerateSchema(message proto.Message) (Schema, error)                 // Converts the message along with annotations into a "Schema" representation
-	GenerateCreateTableSQL(schema Schema) string                          // Generates the Create Table statement based on the schema
-	ValidateSchema(protoMessage []proto.Message) ([]SqlStatement, error)  // Validates the schema by applying the Create table statement to an actual database instance to validate the annotations
-	GenerateModels(outputDir string, protoMessages []proto.Message) error // Leverages the Xo library to generate the database CRUD
-	GenerateMigration(oldSchema, newSchema Schema) string                 // Diffs 2 proto messages and determines the SQL migration to apply
+	GenerateSchema(message proto.Message) (Schema, error)                         // Converts the message along with annotations into a "Schema" representation
+	GenerateCreateTableSQL(schema Schema) string                                  // Generates the Create Table statement based on the schema
+	ValidateSchema(protoMessage []proto.Message) ([]SqlStatement, error)          // Validates the schema by applying the Create table statement to an actual database instance to validate the annotations
+	GenerateModels(outputDir string, protoMessages []proto.Message) error         // Leverages the Xo library to generate the database CRUD
+	GenerateMigration(oldSchema, newSchema Schema) string                         // Diffs 2 proto messages and determines the SQL migration to apply
+	MessageToColumns(message proto.Message) (map[string]interface{}, error)       // Converts a message into column values, flattening oneof groups
+	ColumnsToMessage(columns map[string]interface{}, message proto.Message) error // Populates a message from column values, restoring oneof groups
 
 	// TODO:
 	// ValidateMigration  // Validate the migration file produced by running the full series of migrations in a test database
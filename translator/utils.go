@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	dbExt "github.com/imran31415/proto-db-translator/annotations"
 	"github.com/imran31415/proto-db-translator/translator/db"
 	dbAn "github.com/imran31415/protobuf-db/db-annotations"
 
@@ -18,17 +19,17 @@ func extractFieldSchema(field protoreflect.FieldDescriptor, dbType db.DatabaseTy
 	// Extract field options
 	options := field.Options().(*descriptorpb.FieldOptions)
 	if options == nil {
-		return column, fmt.Errorf("missing field options for field %s", field.Name())
+		return column, newFieldError(field, CodeMissingAnnotation, "missing field options for field %s", field.Name())
 	}
 	// Extract annotations with error checking
 	dbColumn, ok := proto.GetExtension(options, dbAn.E_DbColumn).(string)
 	if !ok || dbColumn == "" {
-		return column, fmt.Errorf("missing or invalid db_column annotation")
+		return column, newFieldError(field, CodeMissingAnnotation, "missing or invalid db_column annotation")
 	}
 
 	dbColumnType, ok := proto.GetExtension(options, dbAn.E_DbColumnType).(dbAn.DbColumnType)
 	if !ok {
-		return column, fmt.Errorf("missing or invalid db_type annotation")
+		return column, newFieldError(field, CodeMissingAnnotation, "missing or invalid db_type annotation")
 	}
 
 	dbConstraints, ok := proto.GetExtension(options, dbAn.E_DbConstraints).([]dbAn.DbConstraint)
@@ -65,15 +66,21 @@ func extractFieldSchema(field protoreflect.FieldDescriptor, dbType db.DatabaseTy
 	dbPrecision, _ := proto.GetExtension(options, dbAn.E_DbPrecision).(int32)
 	dbScale, _ := proto.GetExtension(options, dbAn.E_DbScale).(int32)
 
-	// Format precision/scale for DECIMAL type
-	precisionScale := ""
-	if dbPrecision > 0 {
-		precisionScale = fmt.Sprintf("(%d", dbPrecision)
-		if dbScale > 0 {
-			precisionScale += fmt.Sprintf(",%d", dbScale)
-		}
-		precisionScale += ")"
-	}
+	// Extended type annotations
+	dbExtendedType, _ := proto.GetExtension(options, dbExt.E_DbExtendedType).(dbExt.DbExtendedColumnType)
+	dbLength, _ := proto.GetExtension(options, dbExt.E_DbLength).(int32)
+	dbUnsigned, _ := proto.GetExtension(options, dbExt.E_DbUnsigned).(bool)
+	generatedExpression, _ := proto.GetExtension(options, dbExt.E_DbGeneratedExpression).(string)
+	generatedStorage, _ := proto.GetExtension(options, dbExt.E_DbGeneratedStorage).(dbExt.DbGeneratedStorage)
+	fake, _ := proto.GetExtension(options, dbExt.E_DbFake).(string)
+	columnType := resolveColumnType(columnTypeSpec{
+		Base:      dbColumnType,
+		Extended:  dbExtendedType,
+		Length:    dbLength,
+		Precision: dbPrecision,
+		Scale:     dbScale,
+		Unsigned:  dbUnsigned,
+	}, dbType)
 
 	// Parse constraints and foreign key details
 	constraints := parseConstraints(dbConstraints, dbDefault, customDefaultValue, dbUpdateAction, dbType)
@@ -97,7 +104,7 @@ func extractFieldSchema(field protoreflect.FieldDescriptor, dbType db.DatabaseTy
 
 	column = ColumnSchema{
 		Name:             dbColumn,
-		Type:             dbColumnTypeToMySQLType(dbColumnType),
+		Type:             columnType,
 		Constraints:      constraints,
 		IsPrimaryKey:     dbPrimaryKey,
 		ForeignKeyTable:  foreignKeyTable,
@@ -110,6 +117,11 @@ func extractFieldSchema(field protoreflect.FieldDescriptor, dbType db.DatabaseTy
 		CharacterSet:     characterSet,
 		Collation:        collation,
 		DefaultFunction:  defaultFunc,
+		Fake:             fake,
+	}
+	if generatedExpression != "" {
+		column.GeneratedExpression = generatedExpression
+		column.GeneratedStorage = parseGeneratedStorage(generatedStorage)
 	}
 
 	return column, nil
@@ -140,6 +152,36 @@ func parseCompositePrimaryKeys(md protoreflect.MessageDescriptor) string {
 	return compositeKeys
 }
 
+// parseTableOptions extracts the table options set on the message
+func parseTableOptions(md protoreflect.MessageDescriptor) TableOptions {
+	options, ok := md.Options().(*descriptorpb.MessageOptions)
+	if !ok || options == nil {
+		return TableOptions{}
+	}
+
+	tableOptions := TableOptions{}
+	tableOptions.Engine, _ = proto.GetExtension(options, dbExt.E_DbEngine).(string)
+	tableOptions.DefaultCharset, _ = proto.GetExtension(options, dbExt.E_DbDefaultCharset).(string)
+	tableOptions.Collation, _ = proto.GetExtension(options, dbExt.E_DbTableCollate).(string)
+	tableOptions.AutoIncrement, _ = proto.GetExtension(options, dbExt.E_DbAutoIncrementStart).(uint64)
+	tableOptions.Strict, _ = proto.GetExtension(options, dbExt.E_DbSqliteStrict).(bool)
+	tableOptions.WithoutRowid, _ = proto.GetExtension(options, dbExt.E_DbSqliteWithoutRowid).(bool)
+
+	switch rowFormat, _ := proto.GetExtension(options, dbExt.E_DbRowFormat).(dbExt.DbRowFormat); rowFormat {
+	case dbExt.DbRowFormat_DB_ROW_FORMAT_DYNAMIC:
+		tableOptions.RowFormat = "DYNAMIC"
+	case dbExt.DbRowFormat_DB_ROW_FORMAT_COMPACT:
+		tableOptions.RowFormat = "COMPACT"
+	case dbExt.DbRowFormat_DB_ROW_FORMAT_REDUNDANT:
+		tableOptions.RowFormat = "REDUNDANT"
+	case dbExt.DbRowFormat_DB_ROW_FORMAT_COMPRESSED:
+		tableOptions.RowFormat = "COMPRESSED"
+	case dbExt.DbRowFormat_DB_ROW_FORMAT_FIXED:
+		tableOptions.RowFormat = "FIXED"
+	}
+	return tableOptions
+}
+
 // Convert DbColumnType enum to MySQL type
 func dbColumnTypeToMySQLType(dbType dbAn.DbColumnType) string {
 	switch dbType {
@@ -243,45 +285,6 @@ func equalConstraints(a, b []string) bool {
 	return true
 }
 
-func parseIndexes(field protoreflect.FieldDescriptor) (string, error) {
-	options := field.Options().(*descriptorpb.FieldOptions)
-	if options == nil {
-		return "", nil
-	}
-
-	indexType, ok := proto.GetExtension(options, dbAn.E_DbIndex).(dbAn.DbIndexType)
-	if !ok || indexType == dbAn.DbIndexType_DB_INDEX_TYPE_UNSPECIFIED {
-		return "", nil
-	}
-
-	switch indexType {
-	case dbAn.DbIndexType_DB_INDEX_TYPE_SIMPLE:
-		return "INDEX", nil
-	case dbAn.DbIndexType_DB_INDEX_TYPE_FULLTEXT:
-		return "FULLTEXT INDEX", nil
-	case dbAn.DbIndexType_DB_INDEX_TYPE_SPATIAL:
-		return "SPATIAL INDEX", nil
-	default:
-		return "", fmt.Errorf("unsupported index type: %v", indexType)
-	}
-}
-func parseCompositeIndexes(md protoreflect.MessageDescriptor) []string {
-	options, ok := md.Options().(*descriptorpb.MessageOptions)
-	if !ok || options == nil {
-		return nil
-	}
-
-	// Extract the composite index string
-	compositeIndexStr, ok := proto.GetExtension(options, dbAn.E_DbCompositeIndex).(string)
-	if !ok || compositeIndexStr == "" {
-		return nil
-	}
-
-	// Split by semicolons to get individual indexes
-	rawIndexes := strings.Split(compositeIndexStr, ";")
-	// log.Println("Found composite raw indexes: ", rawIndexes)
-	return rawIndexes
-}
 func parseForeignKeyAction(action dbAn.DbForeignKeyAction) string {
 	switch action {
 	case dbAn.DbForeignKeyAction_DB_FOREIGN_KEY_ACTION_CASCADE:
@@ -322,7 +325,7 @@ func parseTableLevelConstraints(md protoreflect.MessageDescriptor) (uniqueConstr
 
 func generateIndexName(index string, tableName string) string {
 	sanitizedIndex := strings.ReplaceAll(index, ",", "_") // Replace commas with underscores
-	return fmt.Sprintf("%s_%s_idx", tableName, sanitizedIndex)
+	return truncatedIdentifier(fmt.Sprintf("%s_%s", tableName, sanitizedIndex), "_idx")
 }
 
 // Helper: Check if a column exists in the schema
@@ -344,3 +347,76 @@ func contains(slice []string, value string) bool {
 	}
 	return false
 }
+
+// oneofDiscriminatorColumn builds the column holding the name of the set case of a oneof group
+func oneofDiscriminatorColumn(od protoreflect.OneofDescriptor) ColumnSchema {
+	// Nullable since a oneof may have no variant set
+	return ColumnSchema{
+		Name: fmt.Sprintf("%s_case", od.Name()),
+		Type: dbColumnTypeToMySQLType(dbAn.DbColumnType_DB_TYPE_VARCHAR),
+	}
+}
+
+// oneofCheckConstraint ensures at most one variant column is set and that it matches the discriminator,
+// which is NULL along with every variant column when no variant is set
+func oneofCheckConstraint(oneof OneofSchema) string {
+	unset := []string{fmt.Sprintf("%s IS NULL", oneof.DiscriminatorColumn)}
+	for _, variant := range oneof.Variants {
+		unset = append(unset, fmt.Sprintf("%s IS NULL", variant.Column))
+	}
+	var cases []string
+	for _, variant := range oneof.Variants {
+		// A NULL discriminator makes the comparison NULL, which would pass the check
+		conditions := []string{fmt.Sprintf("%s IS NOT NULL AND %s = '%s'", oneof.DiscriminatorColumn, oneof.DiscriminatorColumn, variant.Case)}
+		for _, other := range oneof.Variants {
+			if other.Column == variant.Column {
+				conditions = append(conditions, fmt.Sprintf("%s IS NOT NULL", other.Column))
+			} else {
+				conditions = append(conditions, fmt.Sprintf("%s IS NULL", other.Column))
+			}
+		}
+		cases = append(cases, fmt.Sprintf("(%s)", strings.Join(conditions, " AND ")))
+	}
+	cases = append(cases, fmt.Sprintf("(%s)", strings.Join(unset, " AND ")))
+	// Parenthesised as a whole since check constraints are combined with AND
+	return fmt.Sprintf("(%s)", strings.Join(cases, " OR "))
+}
+
+// removeConstraint returns the constraints without the given value
+func removeConstraint(constraints []string, value string) []string {
+	var result []string
+	for _, c := range constraints {
+		if c != value {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// parseGeneratedStorage maps the generated column storage annotation to SQL, defaulting to VIRTUAL
+func parseGeneratedStorage(storage dbExt.DbGeneratedStorage) string {
+	if storage == dbExt.DbGeneratedStorage_DB_GENERATED_STORAGE_STORED {
+		return "STORED"
+	}
+	return "VIRTUAL"
+}
+
+// generatedColumnSQL renders the GENERATED ALWAYS AS clause of a generated column.
+// MySQL and SQLite share the same syntax.
+func generatedColumnSQL(col ColumnSchema) string {
+	if col.GeneratedExpression == "" {
+		return ""
+	}
+	return fmt.Sprintf("GENERATED ALWAYS AS (%s) %s", col.GeneratedExpression, generatedStorage(col))
+}
+
+// generatedStorage returns the storage of a generated column, or an empty string for regular columns
+func generatedStorage(col ColumnSchema) string {
+	if col.GeneratedExpression == "" {
+		return ""
+	}
+	if col.GeneratedStorage == "" {
+		return "VIRTUAL"
+	}
+	return col.GeneratedStorage
+}
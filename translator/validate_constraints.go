@@ -0,0 +1,664 @@
+package proto_db
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ConstraintKind is the kind of constraint exercised by ValidateConstraints
+type ConstraintKind string
+
+const (
+	ConstraintKindNotNull    ConstraintKind = "not_null"
+	ConstraintKindUnique     ConstraintKind = "unique"
+	ConstraintKindForeignKey ConstraintKind = "foreign_key"
+	ConstraintKindCheck      ConstraintKind = "check"
+	ConstraintKindCascade    ConstraintKind = "on_delete_cascade"
+)
+
+// ConstraintResult is the outcome of a violation attempted against a constraint
+type ConstraintResult struct {
+	TableName  string
+	Kind       ConstraintKind
+	Constraint string   // Name of the constraint, or its definition for unnamed constraints
+	Columns    []string // Columns of the constraint
+	Enforced   bool     // The database rejected the violation, or removed the referencing rows for ON DELETE CASCADE
+	Skipped    bool     // No conclusive violation could be attempted, see Detail
+	Detail     string
+}
+
+// Synthetic rows: every table gets the valid rows 1 and 2, a violation is attempted with row 3 in a
+// transaction that is rolled back, and foreign keys pointing nowhere use the values of row 99 which
+// is never inserted
+const (
+	validRows = 2
+	probeRow  = 3
+	orphanRow = 99
+)
+
+// constraintErrorCodes are the driver codes of the constraint violations: MySQL error numbers and SQLite
+// extended result codes
+var constraintErrorCodes = map[db.DatabaseType]map[ConstraintKind][]int{
+	db.DatabaseTypeMySQL: {
+		ConstraintKindNotNull:    {1048},
+		ConstraintKindUnique:     {1062},
+		ConstraintKindForeignKey: {1452},
+		ConstraintKindCheck:      {3819},
+	},
+	db.DatabaseTypeSQLite: {
+		ConstraintKindNotNull:    {1299},
+		ConstraintKindUnique:     {2067, 1555},
+		ConstraintKindForeignKey: {787},
+		ConstraintKindCheck:      {275},
+	},
+}
+
+// comparisonCheckPattern matches CHECK expressions comparing a column to a number, e.g. quantity > 0
+var comparisonCheckPattern = regexp.MustCompile(`^\(?\s*([A-Za-z_][A-Za-z0-9_]*)\s*(>=|<=|<>|!=|>|<|=)\s*(-?[0-9]+(?:\.[0-9]+)?)\s*\)?$`)
+
+var typeSizePattern = regexp.MustCompile(`\(\s*([0-9]+)`)
+
+// ValidateConstraints applies the schema to a test database like ValidateSchema, then checks the database
+// enforces the constraints. Synthetic rows are inserted in every table, referenced tables first, then a row
+// violating each constraint is inserted in a transaction that is rolled back: NULL in NOT NULL columns, a
+// duplicate of every unique key, foreign keys referencing no row and values failing CHECK constraints
+// comparing a column to a number or of oneof groups. ON DELETE CASCADE foreign keys are checked by
+// deleting a referenced row.
+// Every attempt is returned. Constraints that didn't fire and synthetic rows the database rejected are
+// reported as ValidationErrors joined into the returned error.
+func (t Translator) ValidateConstraints(protoMessages []proto.Message) ([]ConstraintResult, error) {
+	database, closeDatabase, err := t.openValidationDatabase()
+	if err != nil {
+		return nil, err
+	}
+	defer closeDatabase()
+
+	statements, schemas, errs := t.schemaStatements(protoMessages)
+	errs = append(errs, applyStatements(func(query string) error {
+		_, err := database.Exec(query)
+		return err
+	}, statements, protoMessages)...)
+	if len(errs) > 0 {
+		return nil, t.locateErrors(errs)
+	}
+
+	descriptors := make(map[string]protoreflect.MessageDescriptor)
+	for _, protoMessage := range protoMessages {
+		md := protoMessage.ProtoReflect().Descriptor()
+		descriptors[string(md.Name())] = md
+	}
+	sorted, cyclic := sortByDependencies(schemas)
+	validator := constraintValidator{
+		translator:  t,
+		database:    database,
+		rows:        newSyntheticRows(schemas),
+		sorted:      sorted,
+		descriptors: descriptors,
+	}
+
+	for _, schema := range cyclic {
+		errs = append(errs, newMessageError(descriptors[schema.TableName], CodeRowRejected,
+			"table '%s' is part of a foreign key cycle, its rows can't be inserted", schema.TableName))
+	}
+	filled := make(map[string]bool)
+	var results []ConstraintResult
+	for _, schema := range sorted {
+		if table := unfilledReference(schema, filled); table != "" {
+			errs = append(errs, newMessageError(descriptors[schema.TableName], CodeRowRejected,
+				"no rows were inserted in table '%s', the referenced table '%s' has no rows", schema.TableName, table))
+			continue
+		}
+		if err := validator.insertValidRows(schema); err != nil {
+			errs = append(errs, newMessageError(descriptors[schema.TableName], CodeRowRejected,
+				"a valid row of table '%s' was rejected: %v", schema.TableName, err))
+			continue
+		}
+		filled[schema.TableName] = true
+	}
+	for _, schema := range sorted {
+		if filled[schema.TableName] {
+			results = append(results, validator.validateTable(schema)...)
+		}
+	}
+
+	for _, result := range results {
+		if !result.Enforced && !result.Skipped {
+			errs = append(errs, validator.notEnforcedError(result))
+		}
+	}
+	return results, t.locateErrors(errs)
+}
+
+// unfilledReference returns a table referenced by the schema that has no rows, or ""
+func unfilledReference(schema Schema, filled map[string]bool) string {
+	for _, foreignKey := range foreignKeys(schema) {
+		if foreignKey.ReferencesTable != schema.TableName && !filled[foreignKey.ReferencesTable] {
+			return foreignKey.ReferencesTable
+		}
+	}
+	return ""
+}
+
+// execer runs statements on a database or in a transaction
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+type constraintValidator struct {
+	translator  Translator
+	database    *sql.DB
+	rows        syntheticRows
+	sorted      []Schema // Tables in dependency order
+	descriptors map[string]protoreflect.MessageDescriptor
+}
+
+func (v constraintValidator) insertValidRows(schema Schema) error {
+	for n := 1; n <= validRows; n++ {
+		if err := insertRow(v.database, schema, v.rows.row(schema.TableName, n)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTable attempts to violate every constraint of a table
+func (v constraintValidator) validateTable(schema Schema) []ConstraintResult {
+	var results []ConstraintResult
+	for _, col := range schema.Columns {
+		if !contains(col.Constraints, "NOT NULL") || col.AutoIncrement || col.GeneratedExpression != "" {
+			continue
+		}
+		row := v.rows.row(schema.TableName, probeRow)
+		row[col.Name] = nil
+		results = append(results, v.probe(schema, ConstraintResult{
+			Kind:       ConstraintKindNotNull,
+			Constraint: fmt.Sprintf("%s NOT NULL", col.Name),
+			Columns:    []string{col.Name},
+		}, row))
+	}
+
+	for _, key := range v.uniqueKeys(schema) {
+		results = append(results, v.probeUnique(schema, key))
+	}
+
+	for _, foreignKey := range foreignKeys(schema) {
+		row := v.rows.row(schema.TableName, probeRow)
+		orphan := v.rows.row(foreignKey.ReferencesTable, orphanRow)
+		for i, column := range foreignKey.Columns {
+			row[column] = orphan[foreignKey.ReferencesColumns[i]]
+		}
+		results = append(results, v.probe(schema, ConstraintResult{
+			Kind:       ConstraintKindForeignKey,
+			Constraint: foreignKeyConstraint(foreignKey),
+			Columns:    foreignKey.Columns,
+		}, row))
+	}
+
+	for _, check := range schema.CheckConstraints {
+		results = append(results, v.probeCheck(schema, check))
+	}
+
+	for _, foreignKey := range foreignKeys(schema) {
+		if foreignKey.OnDelete == "CASCADE" {
+			result := v.checkCascade(schema, foreignKey)
+			result.TableName = schema.TableName
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// uniqueKeys lists the distinct unique keys of a table
+func (v constraintValidator) uniqueKeys(schema Schema) [][]string {
+	keys, err := v.translator.keyColumns(v.descriptors[schema.TableName])
+	if err != nil {
+		return nil
+	}
+	var distinct [][]string
+	for _, key := range keys {
+		duplicate := false
+		for _, other := range distinct {
+			duplicate = duplicate || sameColumns(key, other)
+		}
+		if !duplicate {
+			distinct = append(distinct, key)
+		}
+	}
+	return distinct
+}
+
+// probeUnique inserts a row with the key of row 1. Foreign keys sharing a column with the key are copied
+// as a whole so that they keep referencing an existing row.
+func (v constraintValidator) probeUnique(schema Schema, key []string) ConstraintResult {
+	result := ConstraintResult{
+		Kind:       ConstraintKindUnique,
+		Constraint: fmt.Sprintf("UNIQUE (%s)", strings.Join(key, ", ")),
+		Columns:    key,
+	}
+	first := v.rows.row(schema.TableName, 1)
+	row := v.rows.row(schema.TableName, probeRow)
+	copied := append([]string{}, key...)
+	for _, foreignKey := range foreignKeys(schema) {
+		for _, column := range foreignKey.Columns {
+			if contains(key, column) {
+				copied = append(copied, foreignKey.Columns...)
+				break
+			}
+		}
+	}
+	for _, column := range copied {
+		row[column] = first[column]
+	}
+	for _, column := range key {
+		if first[column] == nil {
+			result.TableName = schema.TableName
+			result.Skipped = true
+			result.Detail = fmt.Sprintf("column '%s' of the existing row is NULL", column)
+			return result
+		}
+	}
+	return v.probe(schema, result, row)
+}
+
+// probeCheck inserts a row failing a CHECK constraint. Only expressions comparing a column to a number
+// and the constraints of oneof groups can be violated on purpose.
+func (v constraintValidator) probeCheck(schema Schema, check CheckConstraint) ConstraintResult {
+	result := ConstraintResult{Kind: ConstraintKindCheck, Constraint: check.Name}
+	row := v.rows.row(schema.TableName, probeRow)
+	if column, comparison, ok := parseComparisonCheck(schema, check.Expression); ok {
+		result.Columns = []string{column.Name}
+		row[column.Name] = numericValue(column, comparison.violating())
+		return v.probe(schema, result, row)
+	}
+	for _, oneof := range schema.Oneofs {
+		if check.Expression != oneofCheckConstraint(oneof) {
+			continue
+		}
+		// The discriminator names a case but no variant is set
+		result.Columns = []string{oneof.DiscriminatorColumn}
+		for _, variant := range oneof.Variants {
+			result.Columns = append(result.Columns, variant.Column)
+			row[variant.Column] = nil
+		}
+		return v.probe(schema, result, row)
+	}
+	result.TableName = schema.TableName
+	result.Skipped = true
+	result.Detail = "no violating row can be derived from the expression"
+	return result
+}
+
+// probe inserts a row violating the constraint of the result in a transaction that is rolled back.
+// The referenced tables get their own row 3 first, so the row only collides with the existing rows
+// where the constraint is violated.
+func (v constraintValidator) probe(schema Schema, result ConstraintResult, row map[string]interface{}) ConstraintResult {
+	result.TableName = schema.TableName
+	tx, err := v.database.Begin()
+	if err != nil {
+		result.Skipped = true
+		result.Detail = err.Error()
+		return result
+	}
+	defer tx.Rollback()
+
+	for _, referenced := range v.referencedTables(schema) {
+		if err := insertRow(tx, referenced, v.rows.row(referenced.TableName, probeRow)); err != nil {
+			result.Skipped = true
+			result.Detail = fmt.Sprintf("a row of the referenced table '%s' was rejected: %v", referenced.TableName, err)
+			return result
+		}
+	}
+
+	err = insertRow(tx, schema, row)
+	switch {
+	case err == nil:
+		result.Detail = "the violating row was accepted"
+	case v.violates(result.Kind, err):
+		result.Enforced = true
+		result.Detail = err.Error()
+	default:
+		result.Skipped = true
+		result.Detail = fmt.Sprintf("the row was rejected for another reason: %v", err)
+	}
+	return result
+}
+
+// violates reports whether the error is the violation of a constraint of the kind
+func (v constraintValidator) violates(kind ConstraintKind, err error) bool {
+	code := driverErrorCode(err)
+	for _, expected := range constraintErrorCodes[v.translator.dbConnection.DbType][kind] {
+		if code == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// referencedTables lists the tables a table references directly or indirectly, in dependency order
+func (v constraintValidator) referencedTables(schema Schema) []Schema {
+	referenced := make(map[string]bool)
+	var visit func(Schema)
+	visit = func(schema Schema) {
+		for _, foreignKey := range foreignKeys(schema) {
+			table := foreignKey.ReferencesTable
+			if referenced[table] || table == schema.TableName {
+				continue
+			}
+			referenced[table] = true
+			if parent, ok := v.rows.schemas[table]; ok {
+				visit(parent)
+			}
+		}
+	}
+	visit(schema)
+
+	var tables []Schema
+	for _, sorted := range v.sorted {
+		if referenced[sorted.TableName] {
+			tables = append(tables, sorted)
+		}
+	}
+	return tables
+}
+
+// foreignKeyConstraint returns the name of a foreign key, or its definition when it's unnamed
+func foreignKeyConstraint(foreignKey ForeignKeySchema) string {
+	if foreignKey.Name != "" {
+		return foreignKey.Name
+	}
+	return fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)", strings.Join(foreignKey.Columns, ", "),
+		foreignKey.ReferencesTable, strings.Join(foreignKey.ReferencesColumns, ", "))
+}
+
+// checkCascade deletes the row referenced by row 1 in a transaction that is rolled back, and checks the
+// referencing rows were deleted with it
+func (v constraintValidator) checkCascade(schema Schema, foreignKey ForeignKeySchema) ConstraintResult {
+	result := ConstraintResult{Kind: ConstraintKindCascade, Constraint: foreignKeyConstraint(foreignKey), Columns: foreignKey.Columns}
+	parent := v.rows.row(foreignKey.ReferencesTable, 1)
+	var values []interface{}
+	for _, column := range foreignKey.ReferencesColumns {
+		values = append(values, parent[column])
+	}
+
+	tx, err := v.database.Begin()
+	if err != nil {
+		result.Skipped = true
+		result.Detail = err.Error()
+		return result
+	}
+	defer tx.Rollback()
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM `%s` WHERE %s", schema.TableName, equalsCondition(foreignKey.Columns))
+	var before, after int
+	if err := tx.QueryRow(countQuery, values...).Scan(&before); err != nil || before == 0 {
+		result.Skipped = true
+		result.Detail = fmt.Sprintf("no row references the row of table '%s'", foreignKey.ReferencesTable)
+		return result
+	}
+	deleteQuery := fmt.Sprintf("DELETE FROM `%s` WHERE %s", foreignKey.ReferencesTable, equalsCondition(foreignKey.ReferencesColumns))
+	if _, err := tx.Exec(deleteQuery, values...); err != nil {
+		result.Skipped = true
+		result.Detail = fmt.Sprintf("the referenced row can't be deleted: %v", err)
+		return result
+	}
+	if err := tx.QueryRow(countQuery, values...).Scan(&after); err != nil {
+		result.Skipped = true
+		result.Detail = err.Error()
+		return result
+	}
+	if after > 0 {
+		result.Detail = fmt.Sprintf("%d of %d referencing rows remain after deleting the referenced row", after, before)
+		return result
+	}
+	result.Enforced = true
+	result.Detail = fmt.Sprintf("%d referencing rows were deleted", before)
+	return result
+}
+
+// notEnforcedError reports a constraint that didn't fire, on its field for single column constraints
+func (v constraintValidator) notEnforcedError(result ConstraintResult) ValidationError {
+	md := v.descriptors[result.TableName]
+	format, args := "%s constraint '%s' of table '%s' isn't enforced: %s", []interface{}{result.Kind, result.Constraint, result.TableName, result.Detail}
+	if len(result.Columns) == 1 {
+		if field := columnField(md, result.Columns[0]); field != nil {
+			return newFieldError(field, CodeConstraintNotEnforced, format, args...)
+		}
+	}
+	return newMessageError(md, CodeConstraintNotEnforced, format, args...)
+}
+
+// insertRow inserts the values of the writable columns of a table
+func insertRow(database execer, schema Schema, row map[string]interface{}) error {
+	var columns []string
+	var values []interface{}
+	for _, col := range schema.Columns {
+		if col.GeneratedExpression != "" {
+			continue
+		}
+		columns = append(columns, col.Name)
+		values = append(values, row[col.Name])
+	}
+	return insertValues(database, schema.TableName, columns, values)
+}
+
+// insertValues inserts a row, the values are passed as arguments of the statement
+func insertValues(database execer, table string, columns []string, values []interface{}) error {
+	var quoted, placeholders []string
+	for _, column := range columns {
+		quoted = append(quoted, fmt.Sprintf("`%s`", column))
+		placeholders = append(placeholders, "?")
+	}
+	query := fmt.Sprintf("INSERT INTO `%s` (%s) VALUES (%s)", table, strings.Join(quoted, ", "), strings.Join(placeholders, ", "))
+	_, err := database.Exec(query, values...)
+	return err
+}
+
+// equalsCondition renders a WHERE condition matching the columns to placeholders
+func equalsCondition(columns []string) string {
+	var conditions []string
+	for _, column := range columns {
+		conditions = append(conditions, fmt.Sprintf("`%s` = ?", column))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// comparison is a CHECK constraint comparing a column to a number
+type comparison struct {
+	operator string
+	bound    float64
+}
+
+// satisfying returns the n-th value satisfying the comparison, distinct for every n unless it's an equality
+func (c comparison) satisfying(n int) float64 {
+	switch c.operator {
+	case "<", "<=":
+		return c.bound - float64(n)
+	case "=":
+		return c.bound
+	}
+	return c.bound + float64(n)
+}
+
+// satisfies reports whether a value satisfies the comparison
+func (c comparison) satisfies(value float64) bool {
+	switch c.operator {
+	case ">":
+		return value > c.bound
+	case ">=":
+		return value >= c.bound
+	case "<":
+		return value < c.bound
+	case "<=":
+		return value <= c.bound
+	case "=":
+		return value == c.bound
+	}
+	return value != c.bound
+}
+
+// violating returns a value failing the comparison
+func (c comparison) violating() float64 {
+	switch c.operator {
+	case ">=":
+		return c.bound - 1
+	case "<=", "=":
+		return c.bound + 1
+	}
+	return c.bound
+}
+
+// parseComparisonCheck parses a CHECK expression comparing a column of the table to a number.
+// Integer columns must be compared to integers.
+func parseComparisonCheck(schema Schema, expression string) (ColumnSchema, comparison, bool) {
+	match := comparisonCheckPattern.FindStringSubmatch(strings.TrimSpace(expression))
+	if match == nil {
+		return ColumnSchema{}, comparison{}, false
+	}
+	column, ok := findColumnInSchema(match[1], schema.Columns)
+	if !ok || column.GeneratedExpression != "" || !isNumericType(column.Type) {
+		return ColumnSchema{}, comparison{}, false
+	}
+	bound, err := strconv.ParseFloat(match[3], 64)
+	if err != nil || (isIntegerType(column.Type) && bound != math.Trunc(bound)) {
+		return ColumnSchema{}, comparison{}, false
+	}
+	return column, comparison{operator: match[2], bound: bound}, true
+}
+
+// numericValue converts a number to the Go type of the column
+func numericValue(column ColumnSchema, value float64) interface{} {
+	if isIntegerType(column.Type) {
+		return int64(value)
+	}
+	return value
+}
+
+// syntheticRows generates deterministic rows: row n of a table holds distinct values from the other rows,
+// references row n of the referenced tables and satisfies the CHECK constraints comparing a column to a
+// number and the constraints of the oneof groups
+type syntheticRows struct {
+	schemas     map[string]Schema
+	comparisons map[string]map[string]comparison // Comparison checks by table and column
+}
+
+func newSyntheticRows(schemas []Schema) syntheticRows {
+	rows := syntheticRows{schemas: make(map[string]Schema), comparisons: make(map[string]map[string]comparison)}
+	for _, schema := range schemas {
+		rows.schemas[schema.TableName] = schema
+		rows.comparisons[schema.TableName] = make(map[string]comparison)
+		for _, check := range schema.CheckConstraints {
+			if column, comparison, ok := parseComparisonCheck(schema, check.Expression); ok {
+				rows.comparisons[schema.TableName][column.Name] = comparison
+			}
+		}
+	}
+	return rows
+}
+
+// row returns the values of row n by column, NULL values are nil
+func (r syntheticRows) row(table string, n int) map[string]interface{} {
+	schema := r.schemas[table]
+	row := make(map[string]interface{})
+	unset := make(map[string]bool)
+	for _, oneof := range schema.Oneofs {
+		// The first case is set
+		if len(oneof.Variants) == 0 {
+			continue
+		}
+		row[oneof.DiscriminatorColumn] = oneof.Variants[0].Case
+		for _, variant := range oneof.Variants[1:] {
+			unset[variant.Column] = true
+		}
+	}
+	for _, col := range schema.Columns {
+		if _, ok := row[col.Name]; ok || col.GeneratedExpression != "" {
+			continue
+		}
+		if unset[col.Name] {
+			row[col.Name] = nil
+			continue
+		}
+		row[col.Name] = r.value(table, col, n)
+	}
+
+	for _, foreignKey := range foreignKeys(schema) {
+		referenced := r.referencedRow(schema, foreignKey, n)
+		for i, column := range foreignKey.Columns {
+			if referenced == nil {
+				row[column] = nil
+			} else {
+				row[column] = referenced[foreignKey.ReferencesColumns[i]]
+			}
+		}
+	}
+	return row
+}
+
+// referencedRow returns the row referenced by row n, nil when the foreign key is NULL.
+// Rows referencing their own table reference the previous row, the first row references nothing
+// or itself when the columns can't be NULL.
+func (r syntheticRows) referencedRow(schema Schema, foreignKey ForeignKeySchema, n int) map[string]interface{} {
+	if foreignKey.ReferencesTable != schema.TableName {
+		if _, ok := r.schemas[foreignKey.ReferencesTable]; !ok {
+			return nil
+		}
+		return r.row(foreignKey.ReferencesTable, n)
+	}
+	if n > 1 {
+		return r.row(schema.TableName, n-1)
+	}
+	for _, column := range foreignKey.Columns {
+		col, _ := findColumnInSchema(column, schema.Columns)
+		if contains(col.Constraints, "NOT NULL") || col.IsPrimaryKey {
+			self := make(map[string]interface{})
+			for _, name := range foreignKey.ReferencesColumns {
+				referenced, _ := findColumnInSchema(name, schema.Columns)
+				self[name] = r.value(schema.TableName, referenced, n)
+			}
+			return self
+		}
+	}
+	return nil
+}
+
+// value returns the value of a column in row n, fitting the type and length of the column
+func (r syntheticRows) value(table string, col ColumnSchema, n int) interface{} {
+	if comparison, ok := r.comparisons[table][col.Name]; ok {
+		return numericValue(col, comparison.satisfying(n))
+	}
+	size := columnSize(col)
+	text := fmt.Sprintf("%s_%d", col.Name, n)
+	if size > 0 && len(text) > size {
+		// Keep the end of the value, which tells the rows apart
+		text = text[len(text)-size:]
+	}
+
+	sqlType := baseColumnType(col.Type)
+	switch {
+	case sqlType == "BOOLEAN" || sqlType == "BOOL":
+		return n%2 == 1
+	case isIntegerType(sqlType):
+		return int64(n)
+	case isNumericType(sqlType):
+		return float64(n)
+	case sqlType == "DATE":
+		return fmt.Sprintf("2024-01-%02d", (n-1)%28+1)
+	case sqlType == "TIME":
+		return fmt.Sprintf("%02d:00:00", n%24)
+	case isTemporalType(sqlType):
+		return fmt.Sprintf("2024-01-%02d 00:00:00", (n-1)%28+1)
+	case sqlType == "JSON":
+		return fmt.Sprintf(`{"n": %d}`, n)
+	case strings.Contains(sqlType, "BLOB") || strings.Contains(sqlType, "BINARY"):
+		return []byte(text)
+	}
+	return text
+}
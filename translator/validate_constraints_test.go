@@ -0,0 +1,176 @@
+package proto_db
+
+import (
+	"database/sql"
+	"testing"
+
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidateConstraints(t *testing.T) {
+	results, err := NewSqliteTranslator().ValidateConstraints([]proto.Message{
+		// Referencing tables before the referenced ones, rows are inserted in dependency order
+		&userauth.Payment{},
+		&userauth.OrderItems{},
+		&userauth.Orders{},
+		&userauth.Customer{},
+		&userauth.Product{},
+		&userauth.Role{},
+		&userauth.OrderDetails{},
+		&userauth.OrderDetailShipments{},
+	})
+	require.NoError(t, err)
+
+	enforced := make(map[string]bool)
+	for _, result := range results {
+		require.False(t, result.Skipped, "%s %s: %s", result.TableName, result.Constraint, result.Detail)
+		enforced[result.TableName+" "+string(result.Kind)+" "+result.Constraint] = result.Enforced
+	}
+	for _, expected := range []string{
+		"Orders not_null total_amount NOT NULL",
+		"Orders check orders_total_amount_chk",
+		"Orders foreign_key FOREIGN KEY (customer_id) REFERENCES Customer (customer_id)",
+		"Orders on_delete_cascade FOREIGN KEY (customer_id) REFERENCES Customer (customer_id)",
+		"OrderItems unique UNIQUE (order_id, product_id)",
+		"OrderItems check orderitems_price_per_unit_chk",
+		"Payment check payment_method_chk",
+		"Role foreign_key FOREIGN KEY (parent_role_id) REFERENCES Role (role_id)",
+		"Role on_delete_cascade FOREIGN KEY (parent_role_id) REFERENCES Role (role_id)",
+		"OrderDetailShipments foreign_key orderdetailshipments_order_detail_fk",
+		"OrderDetailShipments on_delete_cascade orderdetailshipments_order_detail_fk",
+	} {
+		require.True(t, enforced[expected], expected)
+	}
+}
+
+func TestValidateConstraintsInvalidSchema(t *testing.T) {
+	// Orders references Customer, which isn't part of the set, so the schema is rejected
+	_, err := NewSqliteTranslator().ValidateConstraints([]proto.Message{&userauth.Orders{}})
+	require.Error(t, err)
+}
+
+func TestValidateConstraintsNotEnforced(t *testing.T) {
+	database, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer database.Close()
+	database.SetMaxOpenConns(1)
+
+	// The table was created without the constraints its schema declares
+	_, err = database.Exec("CREATE TABLE `Stock` (id INT PRIMARY KEY, quantity INT, sku VARCHAR(8))")
+	require.NoError(t, err)
+	schema := Schema{
+		TableName: "Stock",
+		Columns: []ColumnSchema{
+			{Name: "id", Type: "INT", IsPrimaryKey: true},
+			{Name: "quantity", Type: "INT", Constraints: []string{"NOT NULL"}},
+			{Name: "sku", Type: "VARCHAR(8)", Constraints: []string{"UNIQUE"}},
+		},
+		CheckConstraints: []CheckConstraint{{Name: "stock_quantity_chk", Expression: "quantity >= 10"}},
+	}
+	validator := constraintValidator{
+		translator: NewSqliteTranslator(),
+		database:   database,
+		rows:       newSyntheticRows([]Schema{schema}),
+		sorted:     []Schema{schema},
+	}
+	require.NoError(t, validator.insertValidRows(schema))
+
+	check := validator.probeCheck(schema, schema.CheckConstraints[0])
+	require.False(t, check.Enforced)
+	require.False(t, check.Skipped)
+	require.Equal(t, []string{"quantity"}, check.Columns)
+
+	unique := validator.probeUnique(schema, []string{"sku"})
+	require.False(t, unique.Enforced)
+	require.Equal(t, "the violating row was accepted", unique.Detail)
+
+	skipped := validator.probeCheck(schema, CheckConstraint{Name: "stock_sku_chk", Expression: "LENGTH(sku) > 2"})
+	require.True(t, skipped.Skipped)
+}
+
+func TestSyntheticRows(t *testing.T) {
+	schemas := []Schema{
+		{
+			TableName: "Orders",
+			Columns: []ColumnSchema{
+				{Name: "order_id", Type: "INT", IsPrimaryKey: true},
+				{Name: "total_amount", Type: "DECIMAL", Precision: 10, Scale: 2},
+				{Name: "status", Type: "VARCHAR(4)"},
+				{Name: "status_upper", Type: "VARCHAR(4)", GeneratedExpression: "UPPER(status)"},
+				{Name: "placed_at", Type: "DATETIME"},
+			},
+			CheckConstraints: []CheckConstraint{{Name: "orders_total_amount_chk", Expression: "total_amount <= -5"}},
+		},
+		{
+			TableName: "OrderItems",
+			Columns: []ColumnSchema{
+				{Name: "order_id", Type: "INT", ForeignKeyTable: "Orders", ForeignKeyColumn: "order_id"},
+				{Name: "parent_id", Type: "INT", ForeignKeyTable: "OrderItems", ForeignKeyColumn: "order_id"},
+			},
+		},
+	}
+	rows := newSyntheticRows(schemas)
+
+	order := rows.row("Orders", 2)
+	require.Equal(t, map[string]interface{}{
+		"order_id":     int64(2),
+		"total_amount": float64(-7),
+		"status":       "us_2",
+		"placed_at":    "2024-01-02 00:00:00",
+	}, order)
+
+	require.Equal(t, map[string]interface{}{"order_id": int64(1), "parent_id": nil}, rows.row("OrderItems", 1))
+	require.Equal(t, map[string]interface{}{"order_id": int64(3), "parent_id": int64(2)}, rows.row("OrderItems", 3))
+}
+
+func TestComparisonCheck(t *testing.T) {
+	schema := Schema{Columns: []ColumnSchema{{Name: "quantity", Type: "INT"}, {Name: "price", Type: "DECIMAL(10,2)"}, {Name: "name", Type: "TEXT"}}}
+	tests := []struct {
+		expression string
+		ok         bool
+		satisfying float64
+		violating  float64
+	}{
+		{"quantity > 0", true, 1, 0},
+		{"(quantity >= 5)", true, 6, 4},
+		{"quantity < 10", true, 9, 10},
+		{"quantity <= 10", true, 9, 11},
+		{"quantity = 3", true, 3, 4},
+		{"quantity <> 3", true, 4, 3},
+		{"price >= 0.5", true, 1.5, -0.5},
+		{"quantity > 0.5", false, 0, 0},
+		{"name > 0", false, 0, 0},
+		{"quantity > 0 AND quantity < 10", false, 0, 0},
+	}
+	for _, test := range tests {
+		_, comparison, ok := parseComparisonCheck(schema, test.expression)
+		require.Equal(t, test.ok, ok, test.expression)
+		if ok {
+			require.Equal(t, test.satisfying, comparison.satisfying(1), test.expression)
+			require.Equal(t, test.violating, comparison.violating(), test.expression)
+		}
+	}
+}
+
+func TestSortByDependencies(t *testing.T) {
+	schemas := []Schema{
+		{TableName: "OrderItems", Columns: []ColumnSchema{{Name: "order_id", ForeignKeyTable: "Orders", ForeignKeyColumn: "order_id"}}},
+		{TableName: "Orders", Columns: []ColumnSchema{{Name: "customer_id", ForeignKeyTable: "Customer", ForeignKeyColumn: "customer_id"}}},
+		{TableName: "Role", Columns: []ColumnSchema{{Name: "parent_role_id", ForeignKeyTable: "Role", ForeignKeyColumn: "role_id"}}},
+		{TableName: "Customer"},
+		{TableName: "A", ForeignKeys: []ForeignKeySchema{{Columns: []string{"b_id"}, ReferencesTable: "B", ReferencesColumns: []string{"id"}}}},
+		{TableName: "B", ForeignKeys: []ForeignKeySchema{{Columns: []string{"a_id"}, ReferencesTable: "A", ReferencesColumns: []string{"id"}}}},
+	}
+	sorted, cyclic := sortByDependencies(schemas)
+	var names, cyclicNames []string
+	for _, schema := range sorted {
+		names = append(names, schema.TableName)
+	}
+	for _, schema := range cyclic {
+		cyclicNames = append(cyclicNames, schema.TableName)
+	}
+	require.Equal(t, []string{"Role", "Customer", "Orders", "OrderItems"}, names)
+	require.Equal(t, []string{"A", "B"}, cyclicNames)
+}
@@ -0,0 +1,23 @@
+package proto_db
+
+import (
+	"github.com/imran31415/proto-db-translator/translator/db"
+	"github.com/imran31415/proto-db-translator/translator/mysqlddl"
+	"google.golang.org/protobuf/proto"
+)
+
+// ValidateSchemaMysqlOffline validates the MySQL schema of the messages without a database. The CREATE TABLE
+// statements are parsed by an embedded MySQL parser and applied to an in-memory catalog enforcing the rules of
+// MySQL 8, rejected statements are reported as ValidationErrors wrapping a StatementError with the MySQL error
+// number, like ValidateSchema reports them against a MySQL server.
+func (t Translator) ValidateSchemaMysqlOffline(protoMessages []proto.Message) ([]SqlStatement, error) {
+	// The statements are generated for MySQL whatever the database of the translator
+	t.dbConnection.DbType = db.DatabaseTypeMySQL
+
+	statements, _, errs := t.schemaStatements(protoMessages)
+	errs = append(errs, applyStatements(mysqlddl.NewCatalog().Exec, statements, protoMessages)...)
+	if len(errs) > 0 {
+		return statements, t.locateErrors(errs)
+	}
+	return statements, nil
+}
@@ -0,0 +1,88 @@
+package proto_db
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
+	"github.com/imran31415/proto-db-translator/translator/mysqlddl"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidateSchemaMysqlOffline(t *testing.T) {
+	statements, err := NewTranslator(db.DefaultMysqlConnection()).ValidateSchemaMysqlOffline([]proto.Message{
+		&userauth.User{},
+		&userauth.Role{},
+		&userauth.RoleHierarchy{},
+		&userauth.Customer{},
+		&userauth.Product{},
+		&userauth.Orders{},
+		&userauth.OrderDetails{},
+		&userauth.OrderDetailShipments{},
+		&userauth.OrderItems{},
+		&userauth.Payment{},
+	})
+	require.NoError(t, err)
+	require.Len(t, statements, 10)
+	require.Contains(t, statements[5].Statement, "ENGINE=InnoDB", "statements are generated for MySQL")
+}
+
+func TestValidateSchemaMysqlOfflineSqliteTranslator(t *testing.T) {
+	// The MySQL dialect is validated whatever the database of the translator
+	statements, err := NewSqliteTranslator().ValidateSchemaMysqlOffline([]proto.Message{&userauth.Product{}})
+	require.NoError(t, err)
+	require.Contains(t, statements[0].Statement, "AUTO_INCREMENT")
+}
+
+func TestValidateSchemaMysqlOfflineErrors(t *testing.T) {
+	// The same errors a MySQL server returns for the invalid schemas, see TestInvalidSqlSchemaValidation
+	tests := []struct {
+		name       string
+		schema     proto.Message
+		driverCode int
+		expected   string
+	}{
+		{"Missing Referenced Table", &userauth.InvalidSqlSchema2{}, 1824, "Failed to open the referenced table 'NonExistentTable'"},
+		{"Unparenthesized Expression Default", &userauth.InvalidSqlSchema4{}, 1064, "You have an error in your SQL syntax"},
+		{"Unknown Character Set", &userauth.InvalidSqlSchema5{}, 1115, "Unknown character set: 'unsupported_charset'"},
+		{"Duplicate Column", &userauth.InvalidSqlSchema6{}, 1060, "Duplicate column name 'code'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewTranslator(db.DefaultMysqlConnection()).ValidateSchemaMysqlOffline([]proto.Message{test.schema})
+			errs := unwrapErrors(t, err)
+			require.Len(t, errs, 1)
+
+			var statementErr StatementError
+			require.True(t, errors.As(errs[0], &statementErr))
+			require.Equal(t, string(test.schema.ProtoReflect().Descriptor().Name()), statementErr.TableName)
+			require.Equal(t, test.driverCode, statementErr.DriverCode)
+			require.ErrorContains(t, statementErr, test.expected)
+		})
+	}
+}
+
+func TestValidateSchemaMysqlOfflineCollectsErrors(t *testing.T) {
+	statements, err := NewTranslator(db.DefaultMysqlConnection()).ValidateSchemaMysqlOffline([]proto.Message{
+		&userauth.InvalidSqlSchema1{},
+		&userauth.Customer{},
+		&userauth.Orders{},
+		&userauth.Orders{},
+	})
+	require.Len(t, statements, 3)
+
+	errs := unwrapErrors(t, err)
+	require.Len(t, errs, 2)
+
+	var validationErr ValidationError
+	require.True(t, errors.As(errs[0], &validationErr))
+	require.Equal(t, CodeMissingAnnotation, validationErr.Code)
+
+	var statementErr StatementError
+	require.True(t, errors.As(errs[1], &statementErr))
+	require.Equal(t, "Orders", statementErr.TableName)
+	require.Equal(t, int(mysqlddl.ErrTableExists), statementErr.DriverCode)
+}
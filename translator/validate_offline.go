@@ -0,0 +1,275 @@
+package proto_db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
+	"github.com/imran31415/proto-db-translator/translator/internal/schemautil"
+	"github.com/imran31415/proto-db-translator/translator/mysqlddl"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Character sets SQLite can store, it only knows UTF-8 and UTF-16 encodings and drops column character sets
+var sqliteCharsets = map[string]bool{
+	"ascii": true, "utf8": true, "utf8mb3": true, "utf8mb4": true, "utf16": true, "utf16le": true, "utf16be": true,
+}
+
+// ValidateSchemaOffline checks the schemas of the messages without connecting to a database.
+// It reports every error found as a ValidationError, joined into one error, or nil when the schemas are valid.
+func (t Translator) ValidateSchemaOffline(protoMessages []proto.Message) error {
+	var errs []error
+	schemas := make(map[string]Schema)
+	var validated []protoreflect.MessageDescriptor
+	for _, protoMessage := range protoMessages {
+		md := protoMessage.ProtoReflect().Descriptor()
+		fieldErrs := t.validateFields(md)
+		if len(fieldErrs) > 0 {
+			errs = append(errs, fieldErrs...)
+			continue
+		}
+		schema, err := t.GenerateSchema(protoMessage)
+		if err != nil {
+			errs = appendErrors(errs, err)
+			continue
+		}
+		schemas[schema.TableName] = schema
+		validated = append(validated, md)
+	}
+
+	// Foreign keys may reference any table of the set, they are checked once all schemas are known
+	for _, md := range validated {
+		errs = append(errs, t.validateTable(md, schemas[string(md.Name())], schemas)...)
+	}
+	return t.locateErrors(errs)
+}
+
+// validateFields checks the columns of a message independently of the other messages
+func (t Translator) validateFields(md protoreflect.MessageDescriptor) []error {
+	var errs []error
+	columnFields := make(map[string]protoreflect.Name)
+	primaryKeys := 0
+	for i := 0; i < md.Fields().Len(); i++ {
+		field := md.Fields().Get(i)
+		column, err := extractFieldSchema(field, t.dbConnection.DbType)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if other, exists := columnFields[column.Name]; exists {
+			errs = append(errs, newFieldError(field, CodeDuplicateColumn, "duplicate column '%s', already used by field '%s'", column.Name, other))
+		}
+		columnFields[column.Name] = field.Name()
+
+		if column.IsPrimaryKey {
+			primaryKeys++
+			if primaryKeys > 1 {
+				errs = append(errs, newFieldError(field, CodeMultiplePrimaryKeys, "more than one primary key column, use db_composite_primary_key for composite keys"))
+			}
+		}
+		if column.AutoIncrement && !isIntegerType(column.Type) {
+			errs = append(errs, newFieldError(field, CodeAutoIncrementType, "AUTO_INCREMENT requires an integer column, not %s", column.Type))
+		}
+		if err := validateDefault(column, t.dbConnection.DbType); err != nil {
+			errs = append(errs, newFieldError(field, CodeIncompatibleDefault, "%s", err))
+		}
+		if column.CharacterSet != "" && !t.supportedCharset(column.CharacterSet) {
+			errs = append(errs, newFieldError(field, CodeUnsupportedCharset, "unsupported character set '%s'", column.CharacterSet))
+		}
+		if reference := fieldReference(field); reference != "" {
+			if _, _, err := t.resolveFieldReference(reference, column); err != nil {
+				errs = append(errs, newFieldError(field, CodeInvalidReference, "%s", err))
+			}
+		}
+	}
+	if primaryKeys > 0 && parseCompositePrimaryKeys(md) != "" {
+		errs = append(errs, newMessageError(md, CodeMultiplePrimaryKeys, "both a composite primary key and a primary key column are declared"))
+	}
+	return errs
+}
+
+// validateTable checks the table level constraints of a schema and its foreign keys to the other tables
+func (t Translator) validateTable(md protoreflect.MessageDescriptor, schema Schema, schemas map[string]Schema) []error {
+	var errs []error
+	unknownColumn := func(format string, args ...interface{}) {
+		errs = append(errs, newMessageError(md, CodeUnknownColumn, format, args...))
+	}
+
+	if schema.CompositePrimaryKeys != "" {
+		for _, column := range splitColumns(schema.CompositePrimaryKeys) {
+			if _, ok := findColumnInSchema(column, schema.Columns); !ok {
+				unknownColumn("composite primary key references unknown column '%s'", column)
+			}
+		}
+	}
+	for _, unique := range schema.UniqueConstraints {
+		for _, column := range splitColumns(unique) {
+			if _, ok := findColumnInSchema(column, schema.Columns); !ok {
+				unknownColumn("unique constraint references unknown column '%s'", column)
+			}
+		}
+	}
+	for _, index := range append(append([]IndexSchema{}, schema.Indexes...), schema.CompositeIndexes...) {
+		for _, column := range index.Columns {
+			if column.Expression != "" {
+				continue
+			}
+			if _, ok := findColumnInSchema(column.Name, schema.Columns); !ok {
+				unknownColumn("index '%s' references unknown column '%s'", index.Name, column.Name)
+			}
+		}
+	}
+	if charset := schema.Options.DefaultCharset; charset != "" && !t.supportedCharset(charset) {
+		errs = append(errs, newMessageError(md, CodeUnsupportedCharset, "unsupported default character set '%s'", charset))
+	}
+
+	for _, column := range schema.Columns {
+		// Like GenerateCreateTableSQL, column foreign keys need both a table and a column
+		if column.ForeignKeyTable == "" || column.ForeignKeyColumn == "" {
+			continue
+		}
+		if code, err := validateReference(schemas, column.ForeignKeyTable, []string{column.ForeignKeyColumn}); err != nil {
+			if field := columnField(md, column.Name); field != nil {
+				errs = append(errs, newFieldError(field, code, "%s", err))
+			} else {
+				errs = append(errs, newMessageError(md, code, "column '%s': %s", column.Name, err))
+			}
+		}
+	}
+	for _, foreignKey := range schema.ForeignKeys {
+		for _, column := range foreignKey.Columns {
+			if _, ok := findColumnInSchema(column, schema.Columns); !ok {
+				unknownColumn("foreign key '%s' references unknown column '%s'", foreignKey.Name, column)
+			}
+		}
+		if code, err := validateReference(schemas, foreignKey.ReferencesTable, foreignKey.ReferencesColumns); err != nil {
+			errs = append(errs, newMessageError(md, code, "foreign key '%s': %s", foreignKey.Name, err))
+		}
+	}
+	return errs
+}
+
+// validateReference checks that the referenced table is part of the validated set and has the referenced columns
+func validateReference(schemas map[string]Schema, table string, columns []string) (ErrorCode, error) {
+	referenced, ok := schemas[table]
+	if !ok {
+		return CodeUnknownTable, fmt.Errorf("foreign key references unknown table '%s'", table)
+	}
+	for _, column := range columns {
+		if _, ok := findColumnInSchema(column, referenced.Columns); !ok {
+			return CodeUnknownColumn, fmt.Errorf("foreign key references unknown column '%s' of table '%s'", column, table)
+		}
+	}
+	return "", nil
+}
+
+// columnField finds the field stored in a column, or nil for columns without a field like oneof discriminators
+func columnField(md protoreflect.MessageDescriptor, column string) protoreflect.FieldDescriptor {
+	return schemautil.ColumnField(md, column)
+}
+
+// supportedCharset reports whether the target database supports a character set
+func (t Translator) supportedCharset(charset string) bool {
+	charset = strings.ToLower(charset)
+	if t.dbConnection.DbType == db.DatabaseTypeSQLite {
+		return sqliteCharsets[charset]
+	}
+	return mysqlddl.SupportedCharset(charset)
+}
+
+// validateDefault checks that the default value and default function of a column fit its type
+func validateDefault(column ColumnSchema, dbType db.DatabaseType) error {
+	base := baseColumnType(column.Type)
+	for _, constraint := range column.Constraints {
+		if !strings.HasPrefix(constraint, "DEFAULT ") {
+			continue
+		}
+		value := strings.TrimPrefix(constraint, "DEFAULT ")
+		switch strings.ToUpper(value) {
+		case "NULL":
+			if contains(column.Constraints, "NOT NULL") {
+				return fmt.Errorf("DEFAULT NULL on a NOT NULL column")
+			}
+			continue
+		case "TRUE", "FALSE":
+			if !isIntegerType(column.Type) && base != "BOOLEAN" && base != "BIT" {
+				return fmt.Errorf("boolean default %s is incompatible with %s", value, column.Type)
+			}
+			continue
+		case "CURRENT_TIMESTAMP":
+			if !isTemporalType(column.Type) {
+				return fmt.Errorf("default CURRENT_TIMESTAMP is incompatible with %s", column.Type)
+			}
+			continue
+		}
+		if strings.HasPrefix(value, "(") {
+			// Expression defaults are evaluated by the database
+			continue
+		}
+		quoted := strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") && len(value) >= 2
+		switch {
+		case isNumericType(column.Type):
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return fmt.Errorf("default %s is not a number, incompatible with %s", value, column.Type)
+			}
+		case isTextType(column.Type) && dbType != db.DatabaseTypeSQLite:
+			return fmt.Errorf("MySQL doesn't support literal defaults for %s columns, use an expression default", column.Type)
+		case !quoted:
+			return fmt.Errorf("default %s must be a quoted literal for %s", value, column.Type)
+		}
+	}
+
+	switch column.DefaultFunction {
+	case "CURRENT_TIMESTAMP":
+		if !isTemporalType(column.Type) {
+			return fmt.Errorf("default function NOW is incompatible with %s", column.Type)
+		}
+	case "UUID()":
+		if !isStringType(column.Type) {
+			return fmt.Errorf("default function UUID is incompatible with %s", column.Type)
+		}
+	}
+	return nil
+}
+
+func isIntegerType(sqlType string) bool {
+	switch baseColumnType(sqlType) {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT":
+		return true
+	}
+	return false
+}
+
+func isNumericType(sqlType string) bool {
+	switch baseColumnType(sqlType) {
+	case "FLOAT", "DOUBLE", "DECIMAL", "REAL", "NUMERIC":
+		return true
+	}
+	return isIntegerType(sqlType)
+}
+
+func isTemporalType(sqlType string) bool {
+	switch baseColumnType(sqlType) {
+	case "DATETIME", "TIMESTAMP":
+		return true
+	}
+	return false
+}
+
+func isTextType(sqlType string) bool {
+	switch baseColumnType(sqlType) {
+	case "TINYTEXT", "TEXT", "MEDIUMTEXT", "LONGTEXT", "BLOB", "JSON":
+		return true
+	}
+	return false
+}
+
+func isStringType(sqlType string) bool {
+	switch baseColumnType(sqlType) {
+	case "CHAR", "VARCHAR", "BINARY", "VARBINARY":
+		return true
+	}
+	return isTextType(sqlType)
+}
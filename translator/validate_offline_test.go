@@ -0,0 +1,190 @@
+package proto_db
+
+import (
+	"testing"
+
+	"github.com/imran31415/proto-db-translator/translator/db"
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// unwrapErrors lists the errors joined by ValidateSchemaOffline
+func unwrapErrors(t *testing.T, err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	require.True(t, ok, "expected joined errors, got %v", err)
+	return joined.Unwrap()
+}
+
+// invalidSchemaError is the expected error of a message of invalid_schema.proto
+func invalidSchemaError(message, field string, code ErrorCode, reason string) ValidationError {
+	return ValidationError{
+		Message:  "proto_db_translator." + message,
+		Field:    field,
+		File:     "proto/invalid_schema.proto",
+		Code:     code,
+		Severity: SeverityError,
+		Reason:   reason,
+	}
+}
+
+func TestValidateSchemaOfflineValid(t *testing.T) {
+	messages := []proto.Message{
+		&userauth.User{},
+		&userauth.Role{},
+		&userauth.RoleHierarchy{},
+		&userauth.Customer{},
+		&userauth.Product{},
+		&userauth.Orders{},
+		&userauth.OrderDetails{},
+		&userauth.OrderItems{},
+		&userauth.OrderDetailShipments{},
+		&userauth.Payment{},
+	}
+	require.NoError(t, NewSqliteTranslator().ValidateSchemaOffline(messages))
+	require.NoError(t, NewTranslator(db.DefaultMysqlConnection()).ValidateSchemaOffline(messages))
+}
+
+func TestValidateSchemaOfflineErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []proto.Message
+		expected []error
+	}{
+		{
+			name:     "missing column annotation",
+			messages: []proto.Message{&userauth.InvalidSqlSchema1{}},
+			expected: []error{
+				invalidSchemaError("InvalidSqlSchema1", "id", CodeMissingAnnotation, "missing or invalid db_column annotation"),
+			},
+		},
+		{
+			name:     "foreign key to unknown table",
+			messages: []proto.Message{&userauth.InvalidSqlSchema2{}},
+			expected: []error{
+				invalidSchemaError("InvalidSqlSchema2", "parent_id", CodeUnknownTable, "foreign key references unknown table 'NonExistentTable'"),
+			},
+		},
+		{
+			name:     "foreign keys to validated tables",
+			messages: []proto.Message{&userauth.Customer{}, &userauth.Orders{}, &userauth.OrderDetails{}, &userauth.OrderDetailShipments{}},
+			expected: nil,
+		},
+		{
+			name:     "default function incompatible with the type",
+			messages: []proto.Message{&userauth.InvalidSqlSchema4{}},
+			expected: []error{
+				invalidSchemaError("InvalidSqlSchema4", "created_at", CodeIncompatibleDefault, "default function UUID is incompatible with DATETIME"),
+			},
+		},
+		{
+			name:     "unsupported character set",
+			messages: []proto.Message{&userauth.InvalidSqlSchema5{}},
+			expected: []error{
+				invalidSchemaError("InvalidSqlSchema5", "invalid_char_set_column", CodeUnsupportedCharset, "unsupported character set 'unsupported_charset'"),
+			},
+		},
+		{
+			name:     "column errors",
+			messages: []proto.Message{&userauth.InvalidSqlSchema6{}},
+			expected: []error{
+				invalidSchemaError("InvalidSqlSchema6", "code", CodeAutoIncrementType, "AUTO_INCREMENT requires an integer column, not VARCHAR(255)"),
+				invalidSchemaError("InvalidSqlSchema6", "version", CodeDuplicateColumn, "duplicate column 'code', already used by field 'code'"),
+				invalidSchemaError("InvalidSqlSchema6", "version", CodeMultiplePrimaryKeys, "more than one primary key column, use db_composite_primary_key for composite keys"),
+				invalidSchemaError("InvalidSqlSchema6", "quantity", CodeIncompatibleDefault, "default 'many' is not a number, incompatible with INT"),
+			},
+		},
+		{
+			name:     "table constraints naming unknown columns",
+			messages: []proto.Message{&userauth.InvalidSqlSchema7{}},
+			expected: []error{
+				invalidSchemaError("InvalidSqlSchema7", "", CodeUnknownColumn, "composite primary key references unknown column 'missing_column'"),
+				invalidSchemaError("InvalidSqlSchema7", "", CodeUnknownColumn, "index 'InvalidSqlSchema7_id_other_missing_column_idx' references unknown column 'other_missing_column'"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewSqliteTranslator().ValidateSchemaOffline(tt.messages)
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, tt.expected, unwrapErrors(t, err))
+		})
+	}
+}
+
+func TestValidateSchemaOfflineMissingReferencedTable(t *testing.T) {
+	// OrderItems is validated without the Orders table it references
+	err := NewSqliteTranslator().ValidateSchemaOffline([]proto.Message{&userauth.Product{}, &userauth.OrderItems{}})
+	require.Equal(t, []error{
+		ValidationError{
+			Message:  "userauth.OrderItems",
+			Field:    "order_id",
+			File:     "proto/order.proto",
+			Code:     CodeUnknownTable,
+			Severity: SeverityError,
+			Reason:   "foreign key references unknown table 'Orders'",
+		},
+	}, unwrapErrors(t, err))
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	require.EqualError(t, ValidationError{Message: "userauth.Orders", Field: "customer_id", Reason: "boom"}, "field 'userauth.Orders.customer_id': boom")
+	require.EqualError(t, ValidationError{Message: "userauth.Orders", Reason: "boom"}, "message 'userauth.Orders': boom")
+}
+
+func TestValidateDefault(t *testing.T) {
+	tests := []struct {
+		name        string
+		column      ColumnSchema
+		dbType      db.DatabaseType
+		expectedErr string
+	}{
+		{name: "numeric default", column: ColumnSchema{Type: "INT", Constraints: []string{"DEFAULT 1"}}},
+		{name: "decimal default", column: ColumnSchema{Type: "DECIMAL(10,2)", Constraints: []string{"DEFAULT 0.5"}}},
+		{name: "quoted string default", column: ColumnSchema{Type: "VARCHAR(255)", Constraints: []string{"DEFAULT 'pending'"}}},
+		{name: "expression default", column: ColumnSchema{Type: "TEXT", Constraints: []string{"DEFAULT ('')"}}},
+		{name: "boolean default", column: ColumnSchema{Type: "BOOLEAN", Constraints: []string{"DEFAULT FALSE"}}},
+		{name: "timestamp default", column: ColumnSchema{Type: "DATETIME", Constraints: []string{"NOT NULL", "DEFAULT CURRENT_TIMESTAMP"}}},
+		{name: "uuid function", column: ColumnSchema{Type: "VARCHAR(36)", DefaultFunction: "UUID()"}},
+		{name: "SQLite text literal", column: ColumnSchema{Type: "TEXT", Constraints: []string{"DEFAULT 'none'"}}, dbType: db.DatabaseTypeSQLite},
+		{
+			name:        "MySQL text literal",
+			column:      ColumnSchema{Type: "TEXT", Constraints: []string{"DEFAULT 'none'"}},
+			dbType:      db.DatabaseTypeMySQL,
+			expectedErr: "MySQL doesn't support literal defaults for TEXT columns, use an expression default",
+		},
+		{name: "unquoted string", column: ColumnSchema{Type: "VARCHAR(255)", Constraints: []string{"DEFAULT pending"}}, expectedErr: "default pending must be a quoted literal for VARCHAR(255)"},
+		{name: "boolean on string", column: ColumnSchema{Type: "VARCHAR(255)", Constraints: []string{"DEFAULT TRUE"}}, expectedErr: "boolean default TRUE is incompatible with VARCHAR(255)"},
+		{name: "timestamp on int", column: ColumnSchema{Type: "INT", Constraints: []string{"DEFAULT CURRENT_TIMESTAMP"}}, expectedErr: "default CURRENT_TIMESTAMP is incompatible with INT"},
+		{name: "null on not null", column: ColumnSchema{Type: "INT", Constraints: []string{"NOT NULL", "DEFAULT NULL"}}, expectedErr: "DEFAULT NULL on a NOT NULL column"},
+		{name: "now function on int", column: ColumnSchema{Type: "INT", DefaultFunction: "CURRENT_TIMESTAMP"}, expectedErr: "default function NOW is incompatible with INT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbType := tt.dbType
+			if dbType == db.DatabaseTypeUnknown {
+				dbType = db.DatabaseTypeMySQL
+			}
+			err := validateDefault(tt.column, dbType)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestSupportedCharset(t *testing.T) {
+	mysql := NewTranslator(db.DefaultMysqlConnection())
+	require.True(t, mysql.supportedCharset("utf8mb4"))
+	require.True(t, mysql.supportedCharset("LATIN1"))
+	require.False(t, mysql.supportedCharset("utf-8"))
+
+	sqlite := NewSqliteTranslator()
+	require.True(t, sqlite.supportedCharset("utf8mb4"))
+	require.False(t, sqlite.supportedCharset("latin1"))
+}
@@ -2,19 +2,50 @@ package proto_db
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 
-	_ "github.com/go-sql-driver/mysql" // MySQL driver
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/go-sql-driver/mysql" // MySQL driver
+	"github.com/mattn/go-sqlite3"
 
 	"github.com/google/uuid"
 	"github.com/imran31415/proto-db-translator/translator/db"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
+// StatementError is a CREATE TABLE statement rejected by the database
+type StatementError struct {
+	TableName  string
+	Statement  string
+	DriverCode int // MySQL error number or SQLite extended result code, 0 when unknown
+	Err        error
+}
+
+func (e StatementError) Error() string {
+	return fmt.Sprintf("table '%s' was rejected (driver code %d): %s\nSQL: %s", e.TableName, e.DriverCode, e.Err, e.Statement)
+}
+
+func (e StatementError) Unwrap() error {
+	return e.Err
+}
+
+// driverErrorCode extracts the error code of a MySQL or SQLite driver error
+func driverErrorCode(err error) int {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return int(mysqlErr.Number)
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return int(sqliteErr.ExtendedCode)
+	}
+	return 0
+}
+
 func protoList(p proto.Message) []proto.Message {
 	return []proto.Message{p}
 }
@@ -22,85 +53,144 @@ func protoList(p proto.Message) []proto.Message {
 // ValidateSchema validates the schema by applying it to a test database
 func (t Translator) ValidateSchema(protoMessages []proto.Message) ([]SqlStatement, error) {
 	outputStatements := []SqlStatement{}
-	var database *sql.DB
-	var openErr error
+	database, closeDatabase, err := t.openValidationDatabase()
+	if err != nil {
+		return outputStatements, err
+	}
+	defer closeDatabase()
+
+	outputStatements, _, errs := t.schemaStatements(protoMessages)
+	errs = append(errs, applyStatements(func(query string) error {
+		_, err := database.Exec(query)
+		return err
+	}, outputStatements, protoMessages)...)
+	if len(errs) > 0 {
+		return outputStatements, t.locateErrors(errs)
+	}
+	log.Printf("Successfully validated %d tables", len(outputStatements))
+
+	return outputStatements, nil
+}
+
+// schemaStatements generates the CREATE TABLE statements of the messages and their schemas, collecting the errors
+// of all messages before giving up
+func (t Translator) schemaStatements(protoMessages []proto.Message) ([]SqlStatement, []Schema, []error) {
+	statements := []SqlStatement{}
+	var schemas []Schema
+	var errs []error
+	for _, protoMessage := range protoMessages {
+		md := protoMessage.ProtoReflect().Descriptor()
+		tableName := string(md.Name())
+		schema, err := t.GenerateSchema(protoMessage)
+		if err != nil {
+			errs = appendErrors(errs, err)
+			continue
+		}
+		if err := t.validateIndexes(schema); err != nil {
+			errs = append(errs, newMessageError(md, CodeInvalidIndex, "invalid index for table '%s': %s", tableName, err))
+			continue
+		}
+		if err := validateForeignKeys(schema); err != nil {
+			errs = append(errs, newMessageError(md, CodeInvalidForeignKey, "invalid foreign key for table '%s': %s", tableName, err))
+			continue
+		}
+
+		statements = append(statements, SqlStatement{
+			Statement: t.GenerateCreateTableSQL(schema),
+			TableName: tableName,
+		})
+		schemas = append(schemas, schema)
+	}
+	return statements, schemas, errs
+}
+
+// applyStatements applies the tables one at a time, so a rejected statement is attributed to its table.
+// Later tables are still applied to report every broken table in one run. A rejected statement is reported as
+// a ValidationError of its message wrapping the StatementError.
+func applyStatements(exec func(query string) error, statements []SqlStatement, protoMessages []proto.Message) []error {
+	descriptors := make(map[string]protoreflect.MessageDescriptor)
+	for _, protoMessage := range protoMessages {
+		md := protoMessage.ProtoReflect().Descriptor()
+		descriptors[string(md.Name())] = md
+	}
+	var errs []error
+	for _, statement := range statements {
+		err := exec(strings.TrimSpace(statement.Statement))
+		if err == nil {
+			continue
+		}
+		statementErr := StatementError{
+			TableName:  statement.TableName,
+			Statement:  statement.Statement,
+			DriverCode: driverErrorCode(err),
+			Err:        err,
+		}
+		md, ok := descriptors[statement.TableName]
+		if !ok {
+			errs = append(errs, statementErr)
+			continue
+		}
+		validationErr := newMessageError(md, CodeSchemaRejected, "%s", statementErr)
+		validationErr.Err = statementErr
+		errs = append(errs, validationErr)
+	}
+	return errs
+}
 
+// openValidationDatabase opens an empty database to apply the schema to, an in-memory database for SQLite
+// and a temporary database for MySQL. The returned function drops the database and closes the connection.
+func (t Translator) openValidationDatabase() (*sql.DB, func(), error) {
 	switch t.dbConnection.DbType {
 	case db.DatabaseTypeSQLite:
 		// Open an in-memory SQLite database
-		database, openErr = sql.Open("sqlite3", ":memory:")
-		if openErr != nil {
-			return outputStatements, fmt.Errorf("failed to connect to SQLite database: %w", openErr)
+		database, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to SQLite database: %w", err)
 		}
-		defer database.Close()
+		// Each connection opens its own in-memory database
+		database.SetMaxOpenConns(1)
 
 		// Enable foreign key constraints for SQLite
-		_, err := database.Exec("PRAGMA foreign_keys = ON;")
-		if err != nil {
-			return outputStatements, fmt.Errorf("failed to enable foreign key constraints: %w", err)
+		if _, err := database.Exec("PRAGMA foreign_keys = ON;"); err != nil {
+			database.Close()
+			return nil, nil, fmt.Errorf("failed to enable foreign key constraints: %w", err)
 		}
+		return database, func() { database.Close() }, nil
 
 	case db.DatabaseTypeMySQL:
 		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", t.dbConnection.DbUser, t.dbConnection.DbPass, t.dbConnection.DbHost, t.dbConnection.DbPort, t.dbConnection.DbName)
 		dsn += "?parseTime=true&multiStatements=true"
 		// Connect to MySQL
-		database, openErr = sql.Open("mysql", dsn)
-		if openErr != nil {
-			return outputStatements, fmt.Errorf("failed to connect to MySQL database: %w", openErr)
+		database, err := sql.Open("mysql", dsn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to MySQL database: %w", err)
 		}
+		// USE only switches the database of one connection
+		database.SetMaxOpenConns(1)
 
 		// Create a temporary database for validation
 		tempDB := "tempdb" + strings.Replace(uuid.NewString(), "-", "", 10)
 		// ensure clean validation
-		_, err := database.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s;", tempDB))
-		if err != nil {
-			return outputStatements, fmt.Errorf("failed to create temporary database: %w", err)
+		if _, err := database.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s;", tempDB)); err != nil {
+			database.Close()
+			return nil, nil, fmt.Errorf("failed to create temporary database: %w", err)
 		}
-
-		// Ensure the temporary database is dropped after validation
-		_, err = database.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s;", tempDB))
-		if err != nil {
-			return outputStatements, fmt.Errorf("failed to create temporary database: %w", err)
+		if _, err := database.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s;", tempDB)); err != nil {
+			database.Close()
+			return nil, nil, fmt.Errorf("failed to create temporary database: %w", err)
 		}
-		defer func() {
+		// Ensure the temporary database is dropped after validation
+		closeDatabase := func() {
 			database.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s;", tempDB))
-
-		}()
-
-		// Switch to the temporary database
-		_, err = database.Exec(fmt.Sprintf("USE %s", tempDB))
-		if err != nil {
-			return outputStatements, fmt.Errorf("failed to switch to temporary database: %w", err)
-		}
-
-	}
-	execute := ""
-	// Process each proto message
-	for x, protoMessage := range protoMessages {
-		tableName := string(protoMessage.ProtoReflect().Descriptor().Name())
-		schema, err := t.GenerateSchema(protoMessage)
-		if err != nil {
-			return outputStatements, fmt.Errorf("failed to generate schema for table '%s': %w", tableName, err)
+			database.Close()
 		}
 
-		statement := SqlStatement{
-			Statement: t.GenerateCreateTableSQL(schema),
-			TableName: tableName,
-		}
-		outputStatements = append(outputStatements, statement)
-		if x == 0 {
-			execute += statement.Statement
-		} else {
-			// execute += "\n"
-			execute += statement.Statement
+		// Switch to the temporary database
+		if _, err := database.Exec(fmt.Sprintf("USE %s", tempDB)); err != nil {
+			closeDatabase()
+			return nil, nil, fmt.Errorf("failed to switch to temporary database: %w", err)
 		}
+		return database, closeDatabase, nil
 	}
-
-	_, err := database.Exec(strings.TrimSpace(execute))
-	if err != nil {
-		return outputStatements, fmt.Errorf("schema validation failed. err: %s\nSQL: %s", err, execute)
-	}
-	log.Printf("Successfully validated %s", execute)
-
-	return outputStatements, nil
+	return nil, nil, fmt.Errorf("unsupported database type %v", t.dbConnection.DbType)
 }
@@ -1,12 +1,15 @@
 package proto_db
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	userauth "github.com/imran31415/proto-db-translator/user"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/imran31415/proto-db-translator/translator/db"
+	"github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 )
@@ -27,6 +30,16 @@ func TestValidateSqlite(t *testing.T) {
 			proto:     &userauth.Role{},
 			expectErr: false,
 		},
+		{
+			name:      "Valid Customer Table With Generated Column",
+			proto:     &userauth.Customer{},
+			expectErr: false,
+		},
+		{
+			name:      "Valid Payment Table With Oneof",
+			proto:     &userauth.Payment{},
+			expectErr: false,
+		},
 		{
 			name:      "Invalid Schema Example",
 			proto:     &userauth.InvalidSqlSchema1{}, // Add an invalid schema case if needed
@@ -184,3 +197,35 @@ func TestInvalidSqlSchemaValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSchemaStatementErrors(t *testing.T) {
+	statements, err := NewSqliteTranslator().ValidateSchema([]proto.Message{
+		&userauth.User{},
+		&userauth.InvalidSqlSchema6{},
+		&userauth.Role{},
+		&userauth.User{},
+	})
+	require.Len(t, statements, 4)
+
+	// Every rejected table is reported, the valid tables in between are still applied
+	errs := unwrapErrors(t, err)
+	require.Len(t, errs, 2)
+
+	var duplicateColumns StatementError
+	require.True(t, errors.As(errs[0], &duplicateColumns))
+	require.Equal(t, "InvalidSqlSchema6", duplicateColumns.TableName)
+	require.Equal(t, statements[1].Statement, duplicateColumns.Statement)
+	require.Equal(t, 1, duplicateColumns.DriverCode) // SQLITE_ERROR
+	require.ErrorContains(t, duplicateColumns, "table 'InvalidSqlSchema6' was rejected (driver code 1)")
+
+	var existingTable StatementError
+	require.True(t, errors.As(errs[1], &existingTable))
+	require.Equal(t, "User", existingTable.TableName)
+	require.ErrorContains(t, existingTable.Err, "already exists")
+}
+
+func TestDriverErrorCode(t *testing.T) {
+	require.Equal(t, 1213, driverErrorCode(fmt.Errorf("wrapped: %w", &mysql.MySQLError{Number: 1213, Message: "Deadlock found"})))
+	require.Equal(t, 2067, driverErrorCode(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique}))
+	require.Zero(t, driverErrorCode(errors.New("boom")))
+}
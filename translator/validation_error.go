@@ -0,0 +1,132 @@
+package proto_db
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Severity of a validation error
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// ErrorCode identifies the kind of a validation error
+type ErrorCode string
+
+const (
+	CodeMissingAnnotation     ErrorCode = "missing_annotation"
+	CodeDuplicateColumn       ErrorCode = "duplicate_column"
+	CodeMultiplePrimaryKeys   ErrorCode = "multiple_primary_keys"
+	CodeAutoIncrementType     ErrorCode = "auto_increment_type"
+	CodeIncompatibleDefault   ErrorCode = "incompatible_default"
+	CodeUnsupportedCharset    ErrorCode = "unsupported_charset"
+	CodeUnknownColumn         ErrorCode = "unknown_column"
+	CodeUnknownTable          ErrorCode = "unknown_table"
+	CodeInvalidReference      ErrorCode = "invalid_reference"
+	CodeInvalidIndex          ErrorCode = "invalid_index"
+	CodeInvalidForeignKey     ErrorCode = "invalid_foreign_key"
+	CodeInvalidCheck          ErrorCode = "invalid_check"
+	CodeSchemaRejected        ErrorCode = "schema_rejected" // The database rejected the generated DDL, see StatementError
+	CodeRowRejected           ErrorCode = "row_rejected"    // The database rejected a synthetic row satisfying the constraints
+	CodeConstraintNotEnforced ErrorCode = "constraint_not_enforced"
+)
+
+// ValidationError is an error of a message, or of one of its fields, located in its proto file.
+// Line and Column are only known when the translator has source info, see WithSourceInfo.
+type ValidationError struct {
+	Message  string    `json:"message"`          // Full name of the message
+	Field    string    `json:"field,omitempty"`  // Name of the field, empty for errors concerning the whole message
+	File     string    `json:"file,omitempty"`   // Proto file declaring the message
+	Line     int       `json:"line,omitempty"`   // 1-based line of the message or field declaration
+	Column   int       `json:"column,omitempty"` // 1-based column of the message or field declaration
+	Code     ErrorCode `json:"code"`
+	Severity Severity  `json:"severity"`
+	Reason   string    `json:"reason"`
+	Err      error     `json:"-"` // Underlying error, e.g. the StatementError of a rejected table
+}
+
+func (e ValidationError) Error() string {
+	var location string
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d: ", e.File, e.Line, e.Column)
+	}
+	if e.Field == "" {
+		return fmt.Sprintf("%smessage '%s': %s", location, e.Message, e.Reason)
+	}
+	return fmt.Sprintf("%sfield '%s.%s': %s", location, e.Message, e.Field, e.Reason)
+}
+
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// newMessageError creates an error concerning a whole message
+func newMessageError(md protoreflect.MessageDescriptor, code ErrorCode, format string, args ...interface{}) ValidationError {
+	return ValidationError{
+		Message:  string(md.FullName()),
+		File:     md.ParentFile().Path(),
+		Code:     code,
+		Severity: SeverityError,
+		Reason:   fmt.Sprintf(format, args...),
+	}
+}
+
+// newFieldError creates an error concerning a field of a message
+func newFieldError(field protoreflect.FieldDescriptor, code ErrorCode, format string, args ...interface{}) ValidationError {
+	err := newMessageError(field.ContainingMessage(), code, format, args...)
+	err.Field = string(field.Name())
+	return err
+}
+
+// appendErrors appends an error to a list, flattening joined errors
+func appendErrors(errs []error, err error) []error {
+	if err == nil {
+		return errs
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			errs = appendErrors(errs, e)
+		}
+		return errs
+	}
+	return append(errs, err)
+}
+
+// locateErrors joins the errors, filling in the lines and columns of the validation errors from the source info
+func (t Translator) locateErrors(errs []error) error {
+	for i, err := range errs {
+		if validationErr, ok := err.(ValidationError); ok {
+			errs[i] = t.Locate(validationErr)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Locate fills in the position of the message or field declaration when source info is available, see
+// WithSourceInfo
+func (t Translator) Locate(err ValidationError) ValidationError {
+	if t.sourceFiles == nil || err.Line > 0 {
+		return err
+	}
+	name := err.Message
+	if err.Field != "" {
+		name += "." + err.Field
+	}
+	desc, findErr := t.sourceFiles.FindDescriptorByName(protoreflect.FullName(name))
+	if findErr != nil {
+		return err
+	}
+	location := desc.ParentFile().SourceLocations().ByDescriptor(desc)
+	if location.Path == nil {
+		return err
+	}
+	err.File = desc.ParentFile().Path()
+	err.Line = location.StartLine + 1
+	err.Column = location.StartColumn + 1
+	return err
+}
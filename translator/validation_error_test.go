@@ -0,0 +1,113 @@
+package proto_db
+
+import (
+	"errors"
+	"testing"
+
+	userauth "github.com/imran31415/proto-db-translator/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// invalidSchemaDescriptorSet builds the descriptor set of proto/invalid_schema.proto with the
+// source location of the id field of InvalidSqlSchema1
+func invalidSchemaDescriptorSet() *descriptorpb.FileDescriptorSet {
+	fdp := protodesc.ToFileDescriptorProto(userauth.File_proto_invalid_schema_proto)
+	md := (&userauth.InvalidSqlSchema1{}).ProtoReflect().Descriptor()
+	rejected := (&userauth.InvalidSqlSchema6{}).ProtoReflect().Descriptor()
+	fdp.SourceCodeInfo = &descriptorpb.SourceCodeInfo{
+		Location: []*descriptorpb.SourceCodeInfo_Location{
+			{Path: []int32{4, int32(md.Index())}, Span: []int32{7, 0, 15, 1}},
+			{Path: []int32{4, int32(md.Index()), 2, 0}, Span: []int32{9, 2, 13, 4}},
+			{Path: []int32{4, int32(rejected.Index())}, Span: []int32{64, 0, 87, 1}},
+		},
+	}
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fdp}}
+}
+
+func TestValidationErrorString(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      ValidationError
+		expected string
+	}{
+		{
+			name:     "field",
+			err:      ValidationError{Message: "userauth.Orders", Field: "customer_id", File: "proto/order.proto", Reason: "boom"},
+			expected: "field 'userauth.Orders.customer_id': boom",
+		},
+		{
+			name:     "message",
+			err:      ValidationError{Message: "userauth.Orders", File: "proto/order.proto", Reason: "boom"},
+			expected: "message 'userauth.Orders': boom",
+		},
+		{
+			name:     "located field",
+			err:      ValidationError{Message: "userauth.Orders", Field: "customer_id", File: "proto/order.proto", Line: 29, Column: 3, Reason: "boom"},
+			expected: "proto/order.proto:29:3: field 'userauth.Orders.customer_id': boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.EqualError(t, tt.err, tt.expected)
+		})
+	}
+}
+
+func TestGenerateSchemaErrorLocation(t *testing.T) {
+	_, err := NewSqliteTranslator().GenerateSchema(&userauth.InvalidSqlSchema1{})
+	var validationErr ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Equal(t, invalidSchemaError("InvalidSqlSchema1", "id", CodeMissingAnnotation, "missing or invalid db_column annotation"), validationErr)
+
+	// With source info the error points at the field declaration
+	translator, err := NewSqliteTranslator().WithSourceInfo(invalidSchemaDescriptorSet())
+	require.NoError(t, err)
+	_, err = translator.GenerateSchema(&userauth.InvalidSqlSchema1{})
+	require.True(t, errors.As(err, &validationErr))
+	require.Equal(t, 10, validationErr.Line)
+	require.Equal(t, 3, validationErr.Column)
+	require.EqualError(t, err, "proto/invalid_schema.proto:10:3: field 'proto_db_translator.InvalidSqlSchema1.id': missing or invalid db_column annotation")
+}
+
+func TestValidateSchemaAggregatesErrors(t *testing.T) {
+	_, err := NewSqliteTranslator().ValidateSchema([]proto.Message{&userauth.InvalidSqlSchema1{}, &userauth.User{}, &userauth.InvalidSqlSchema7{}})
+	require.Equal(t, []error{
+		invalidSchemaError("InvalidSqlSchema1", "id", CodeMissingAnnotation, "missing or invalid db_column annotation"),
+		invalidSchemaError("InvalidSqlSchema7", "", CodeInvalidIndex,
+			"invalid index for table 'InvalidSqlSchema7': index 'InvalidSqlSchema7_id_other_missing_column_idx' references unknown column 'other_missing_column'"),
+	}, unwrapErrors(t, err))
+}
+
+func TestValidateSchemaRejectedLocation(t *testing.T) {
+	translator, err := NewSqliteTranslator().WithSourceInfo(invalidSchemaDescriptorSet())
+	require.NoError(t, err)
+	_, err = translator.ValidateSchema([]proto.Message{&userauth.InvalidSqlSchema6{}})
+	errs := unwrapErrors(t, err)
+	require.Len(t, errs, 1)
+
+	// The table rejected by the database is reported at the message declaration
+	var validationErr ValidationError
+	require.True(t, errors.As(errs[0], &validationErr))
+	require.Equal(t, CodeSchemaRejected, validationErr.Code)
+	require.Equal(t, "proto_db_translator.InvalidSqlSchema6", validationErr.Message)
+	require.Equal(t, "proto/invalid_schema.proto", validationErr.File)
+	require.Equal(t, 65, validationErr.Line)
+	require.Equal(t, 1, validationErr.Column)
+	require.ErrorContains(t, err, "proto/invalid_schema.proto:65:1: message 'proto_db_translator.InvalidSqlSchema6': table 'InvalidSqlSchema6' was rejected")
+
+	var statementErr StatementError
+	require.True(t, errors.As(errs[0], &statementErr))
+	require.Equal(t, "InvalidSqlSchema6", statementErr.TableName)
+}
+
+func TestAppendErrors(t *testing.T) {
+	first, second, third := errors.New("first"), errors.New("second"), errors.New("third")
+	errs := appendErrors(nil, nil)
+	require.Empty(t, errs)
+	errs = appendErrors(errs, first)
+	errs = appendErrors(errs, errors.Join(second, errors.Join(third)))
+	require.Equal(t, []error{first, second, third}, errs)
+}
@@ -253,6 +253,115 @@ func (x *InvalidSqlSchema5) GetInvalidCharSetColumn() string {
 	return ""
 }
 
+type InvalidSqlSchema6 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Error 6: More than one primary key column and AUTO_INCREMENT on a string column.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Error 7: Duplicate column name.
+	Version int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Error 8: Default incompatible with the column type.
+	Quantity int32 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *InvalidSqlSchema6) Reset() {
+	*x = InvalidSqlSchema6{}
+	mi := &file_proto_invalid_schema_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidSqlSchema6) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidSqlSchema6) ProtoMessage() {}
+
+func (x *InvalidSqlSchema6) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_invalid_schema_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidSqlSchema6.ProtoReflect.Descriptor instead.
+func (*InvalidSqlSchema6) Descriptor() ([]byte, []int) {
+	return file_proto_invalid_schema_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InvalidSqlSchema6) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *InvalidSqlSchema6) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *InvalidSqlSchema6) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type InvalidSqlSchema7 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *InvalidSqlSchema7) Reset() {
+	*x = InvalidSqlSchema7{}
+	mi := &file_proto_invalid_schema_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidSqlSchema7) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidSqlSchema7) ProtoMessage() {}
+
+func (x *InvalidSqlSchema7) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_invalid_schema_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidSqlSchema7.ProtoReflect.Descriptor instead.
+func (*InvalidSqlSchema7) Descriptor() ([]byte, []int) {
+	return file_proto_invalid_schema_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *InvalidSqlSchema7) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 var File_proto_invalid_schema_proto protoreflect.FileDescriptor
 
 var file_proto_invalid_schema_proto_rawDesc = []byte{
@@ -292,7 +401,24 @@ var file_proto_invalid_schema_proto_rawDesc = []byte{
 	0x75, 0x6d, 0x6e, 0xa0, 0xb5, 0x18, 0x02, 0xaa, 0xb5, 0x18, 0x01, 0x01, 0xa2, 0xb6, 0x18, 0x13,
 	0x75, 0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x68, 0x61, 0x72,
 	0x73, 0x65, 0x74, 0x52, 0x14, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x43, 0x68, 0x61, 0x72,
-	0x53, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x42, 0x07, 0x5a, 0x05, 0x2f, 0x75, 0x73,
+	0x53, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x22, 0xa5, 0x01, 0x0a, 0x11, 0x49, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x71, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x36, 0x12,
+	0x28, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x14, 0x8a,
+	0xb5, 0x18, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x90, 0xb5, 0x18, 0x01, 0xa0, 0xb5, 0x18, 0x02, 0xf8,
+	0xb5, 0x18, 0x01, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x42, 0x10, 0x8a, 0xb5, 0x18, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x90, 0xb5, 0x18, 0x01, 0xa0, 0xb5, 0x18, 0x01, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x42, 0x1e, 0x8a, 0xb5, 0x18, 0x08, 0x71, 0x75, 0x61,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0xa0, 0xb5, 0x18, 0x01, 0xb0, 0xb5, 0x18, 0x06, 0xba, 0xb5, 0x18,
+	0x06, 0x27, 0x6d, 0x61, 0x6e, 0x79, 0x27, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x22, 0x66, 0x0a, 0x11, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x53, 0x71, 0x6c, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x37, 0x12, 0x1f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x42, 0x0f, 0x8a, 0xb5, 0x18, 0x02, 0x69, 0x64, 0xa0, 0xb5, 0x18, 0x01, 0xaa, 0xb5,
+	0x18, 0x01, 0x01, 0x52, 0x02, 0x69, 0x64, 0x3a, 0x30, 0xc2, 0xb6, 0x18, 0x11, 0x69, 0x64, 0x2c,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0xca, 0xb6,
+	0x18, 0x17, 0x69, 0x64, 0x2c, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x42, 0x07, 0x5a, 0x05, 0x2f, 0x75, 0x73,
 	0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
@@ -308,17 +434,19 @@ func file_proto_invalid_schema_proto_rawDescGZIP() []byte {
 	return file_proto_invalid_schema_proto_rawDescData
 }
 
-var file_proto_invalid_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_invalid_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_proto_invalid_schema_proto_goTypes = []any{
 	(*InvalidSqlSchema1)(nil),     // 0: proto_db_translator.InvalidSqlSchema1
 	(*InvalidSqlSchema2)(nil),     // 1: proto_db_translator.InvalidSqlSchema2
 	(*InvalidSqlSchema3)(nil),     // 2: proto_db_translator.InvalidSqlSchema3
 	(*InvalidSqlSchema4)(nil),     // 3: proto_db_translator.InvalidSqlSchema4
 	(*InvalidSqlSchema5)(nil),     // 4: proto_db_translator.InvalidSqlSchema5
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*InvalidSqlSchema6)(nil),     // 5: proto_db_translator.InvalidSqlSchema6
+	(*InvalidSqlSchema7)(nil),     // 6: proto_db_translator.InvalidSqlSchema7
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
 }
 var file_proto_invalid_schema_proto_depIdxs = []int32{
-	5, // 0: proto_db_translator.InvalidSqlSchema4.created_at:type_name -> google.protobuf.Timestamp
+	7, // 0: proto_db_translator.InvalidSqlSchema4.created_at:type_name -> google.protobuf.Timestamp
 	1, // [1:1] is the sub-list for method output_type
 	1, // [1:1] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
@@ -337,7 +465,7 @@ func file_proto_invalid_schema_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_invalid_schema_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},